@@ -17,6 +17,74 @@ limitations under the License.
 package v1beta1
 
 const (
-	RingConfigMapName = "swift-ring-files"
+	RingConfigMapName   = "swift-ring-files"
 	DeviceConfigMapName = "swift-storage-devices"
+	// DeviceListKeySuffix and FailedDeviceListKeySuffix are appended to
+	// the name of a SwiftStorage for its keys in the device ConfigMap,
+	// which is shared by all SwiftStorage instances of the namespace
+	DeviceListKeySuffix       = "-devices.csv"
+	FailedDeviceListKeySuffix = "-failed.csv"
+	// RingSecretName is the Secret with the rings if the RingDistribution
+	// is Secret
+	RingSecretName = "swift-ring-files"
+	// BuilderSecretName is the Secret with the ring builder files, which
+	// are only used by the rebalance Job
+	BuilderSecretName = "swift-ring-builders"
+	// PendingRingSecretName is the Secret with the builders and rings
+	// waiting for approval if the RingUpdatePolicy is Manual
+	PendingRingSecretName = "swift-ring-pending"
+	// RingStatsConfigMapName is the ConfigMap with the ring stats reported
+	// by the last rebalance Job
+	RingStatsConfigMapName = "swift-ring-stats"
+	// RingStatsKey is the key of the ring stats in the RingStatsConfigMapName
+	RingStatsKey = "stats.json"
+
+	// RingVersionLabel labels the saved copies of the rings with their
+	// version
+	RingVersionLabel = "swift.openstack.org/ring-version"
+
+	// ApproveRingsAnnotation approves the pending rings of a SwiftRing if
+	// set to the value of status.pendingRings
+	ApproveRingsAnnotation = "swift.openstack.org/approve-rings"
+
+	// DrainAnnotation drains a proxy pod if set to "true", see
+	// SwiftProxyDrainSpec
+	DrainAnnotation = "swift.openstack.org/drain"
+
+	// RingDistributionConfigMap publishes the rings in a ConfigMap
+	RingDistributionConfigMap = "ConfigMap"
+	// RingDistributionSecret publishes the rings in a Secret
+	RingDistributionSecret = "Secret"
 )
+
+// LogForwardingSpec defines an optional sidecar that receives the syslog
+// messages of all Swift services and forwards them to a remote endpoint
+// +kubebuilder:validation:XValidation:rule="!self.enabled || (has(self.host) && size(self.host) > 0)",message="host is required when log forwarding is enabled"
+type LogForwardingSpec struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=false
+	// Enabled - add a log forwarding sidecar to the pods
+	Enabled bool `json:"enabled"`
+
+	// +kubebuilder:validation:Optional
+	// Image URL for the log forwarding sidecar
+	ContainerImage string `json:"containerImage,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Host - hostname or IP of the remote syslog or Fluentd endpoint,
+	// required when enabled
+	Host string `json:"host,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=514
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// Port of the remote syslog or Fluentd endpoint
+	Port int32 `json:"port,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=udp
+	// +kubebuilder:validation:Enum=udp;tcp
+	// Protocol used to forward the log messages
+	Protocol string `json:"protocol,omitempty"`
+}
@@ -24,11 +24,59 @@ const (
 	// SwiftRingReadyCondition Status=True condition which indicates if the SwiftRing is configured and operational
 	SwiftRingReadyCondition condition.Type = "SwiftRingReady"
 
+	// SwiftRingVerifiedCondition Status=True condition which indicates that the rings restored from a snapshot match the data on the storage devices
+	SwiftRingVerifiedCondition condition.Type = "SwiftRingVerified"
+
+	// SwiftRingSizeCondition Status=True condition which indicates that the published rings are well below the size limit of their ConfigMap or Secret
+	SwiftRingSizeCondition condition.Type = "SwiftRingSize"
+
+	// SwiftRingApprovalCondition Status=True condition which indicates that no rebalanced rings are waiting for approval
+	SwiftRingApprovalCondition condition.Type = "SwiftRingApproval"
+
+	// SwiftRingPartPowerCondition Status=True condition which indicates that no partition power increase is in progress
+	SwiftRingPartPowerCondition condition.Type = "SwiftRingPartPower"
+
+	// SwiftRingAuditCondition Status=True condition which indicates that the devices of the published rings match the storage pods
+	SwiftRingAuditCondition condition.Type = "SwiftRingAudit"
+
+	// SwiftRingFailureDomainsCondition Status=True condition which indicates that all rings have at least as many devices and zones as replicas
+	SwiftRingFailureDomainsCondition condition.Type = "SwiftRingFailureDomains"
+
+	// SwiftRingReplicaChangeCondition Status=True condition which indicates that no gradual replica count change is in progress
+	SwiftRingReplicaChangeCondition condition.Type = "SwiftRingReplicaChange"
+
 	// SwiftStorageReadyCondition Status=True condition which indicates if the SwiftStorage is configured and operational
 	SwiftStorageReadyCondition condition.Type = "SwiftStorageReady"
 
+	// SwiftStorageAlertsCondition Status=True condition which indicates that no SwiftStorage alert threshold is exceeded
+	SwiftStorageAlertsCondition condition.Type = "SwiftStorageAlerts"
+
+	// SwiftStoragePrometheusRuleCondition Status=True condition which indicates that the PrometheusRule of the SwiftStorage is up to date
+	SwiftStoragePrometheusRuleCondition condition.Type = "SwiftStoragePrometheusRule"
+
+	// SwiftStorageDevicesCondition Status=True condition which indicates that no SwiftStorage device is failed
+	SwiftStorageDevicesCondition condition.Type = "SwiftStorageDevices"
+
 	// SwiftProxyReadyCondition Status=True condition which indicates if the SwiftProxy is configured and operational
 	SwiftProxyReadyCondition condition.Type = "SwiftProxyReady"
+
+	// SwiftProxyDeprecationCondition Status=True condition which indicates that no deprecated SwiftProxy options are used
+	SwiftProxyDeprecationCondition condition.Type = "SwiftProxyDeprecation"
+
+	// SwiftProxyEncryptionCondition Status=True condition which indicates that all proxies use the active encryption root secret
+	SwiftProxyEncryptionCondition condition.Type = "SwiftProxyEncryption"
+
+	// SwiftProxyRolloutCondition Status=True condition which indicates that all proxies use the proxy image
+	SwiftProxyRolloutCondition condition.Type = "SwiftProxyRollout"
+
+	// SwiftVersionSkewCondition Status=True condition which indicates if the Swift versions of all images are a supported combination
+	SwiftVersionSkewCondition condition.Type = "SwiftVersionSkew"
+)
+
+// Swift Reasons used by API objects.
+const (
+	// DeprecatedReason - a deprecated option is used
+	DeprecatedReason condition.Reason = "Deprecated"
 )
 
 // Common Messages used by API objects.
@@ -42,6 +90,87 @@ const (
 	// SwiftRingReadyErrorMessage
 	SwiftRingReadyErrorMessage = "SwiftRing error occured %s"
 
+	//
+	// SwiftRingVerified condition messages
+	//
+	// SwiftRingVerifiedRunningMessage
+	SwiftRingVerifiedRunningMessage = "Restored rings verification in progress"
+
+	// SwiftRingVerifiedReadyMessage
+	SwiftRingVerifiedReadyMessage = "Restored rings match the storage devices"
+
+	// SwiftRingVerifiedHandoffsMessage
+	SwiftRingVerifiedHandoffsMessage = "Restored rings match the storage devices, %d partitions found on handoff devices: %s"
+
+	// SwiftRingVerifiedMismatchMessage
+	SwiftRingVerifiedMismatchMessage = "Restored rings do not match the storage devices, %d mismatches: %s"
+
+	// SwiftRingVerifiedErrorMessage
+	SwiftRingVerifiedErrorMessage = "Restored rings verification error occured %s"
+
+	//
+	// SwiftRingSize condition messages
+	//
+	// SwiftRingSizeReadyMessage
+	SwiftRingSizeReadyMessage = "Rings use %d%% of the %s size limit"
+
+	// SwiftRingSizeExceededMessage
+	SwiftRingSizeExceededMessage = "Rings use %d%% of the %s size limit, reduce the part power or use more storage policies"
+
+	//
+	// SwiftRingApproval condition messages
+	//
+	// SwiftRingApprovalReadyMessage
+	SwiftRingApprovalReadyMessage = "No rings waiting for approval"
+
+	// SwiftRingApprovalPendingMessage
+	SwiftRingApprovalPendingMessage = "Rings waiting for approval, set the swift.openstack.org/approve-rings annotation to %s"
+
+	//
+	// SwiftRingPartPower condition messages
+	//
+	// SwiftRingPartPowerReadyMessage
+	SwiftRingPartPowerReadyMessage = "No partition power increase in progress"
+
+	// SwiftRingPartPowerRunningMessage
+	SwiftRingPartPowerRunningMessage = "Partition power increase of the %s ring to %d in progress, phase %s"
+
+	// SwiftRingPartPowerErrorMessage
+	SwiftRingPartPowerErrorMessage = "Partition power increase error occured %s"
+
+	//
+	// SwiftRingAudit condition messages
+	//
+	// SwiftRingAuditReadyMessage
+	SwiftRingAuditReadyMessage = "Ring devices match the storage pods"
+
+	// SwiftRingAuditMismatchMessage
+	SwiftRingAuditMismatchMessage = "Ring devices do not match the storage pods, %d mismatches: %s"
+
+	// SwiftRingAuditErrorMessage
+	SwiftRingAuditErrorMessage = "Ring audit error occured %s"
+
+	//
+	// SwiftRingFailureDomains condition messages
+	//
+	// SwiftRingFailureDomainsReadyMessage
+	SwiftRingFailureDomainsReadyMessage = "All rings have enough failure domains for their replicas"
+
+	// SwiftRingFailureDomainsLowMessage
+	SwiftRingFailureDomainsLowMessage = "Rings with fewer failure domains than replicas: %s"
+
+	//
+	// SwiftRingReplicaChange condition messages
+	//
+	// SwiftRingReplicaChangeReadyMessage
+	SwiftRingReplicaChangeReadyMessage = "No replica count change in progress"
+
+	// SwiftRingReplicaChangeRunningMessage
+	SwiftRingReplicaChangeRunningMessage = "Replica count change in progress: %s"
+
+	// SwiftRingReplicaChangeErrorMessage
+	SwiftRingReplicaChangeErrorMessage = "Replica count change error occured %s"
+
 	//
 	// SwiftStorageReady condition messages
 	//
@@ -51,6 +180,39 @@ const (
 	// SwiftStorageReadyErrorMessage
 	SwiftStorageReadyErrorMessage = "SwiftStorage error occured %s"
 
+	//
+	// SwiftStorageAlerts condition messages
+	//
+	// SwiftStorageAlertsReadyMessage
+	SwiftStorageAlertsReadyMessage = "SwiftStorage alert thresholds not exceeded"
+
+	// SwiftStorageAlertsExceededMessage
+	SwiftStorageAlertsExceededMessage = "SwiftStorage alert thresholds exceeded: %s"
+
+	// SwiftStorageAlertsErrorMessage
+	SwiftStorageAlertsErrorMessage = "SwiftStorage recon data not available: %s"
+
+	//
+	// SwiftStoragePrometheusRule condition messages
+	//
+	// SwiftStoragePrometheusRuleInitMessage
+	SwiftStoragePrometheusRuleInitMessage = "PrometheusRule not created yet"
+
+	// SwiftStoragePrometheusRuleReadyMessage
+	SwiftStoragePrometheusRuleReadyMessage = "PrometheusRule up to date"
+
+	// SwiftStoragePrometheusRuleErrorMessage
+	SwiftStoragePrometheusRuleErrorMessage = "PrometheusRule error occured %s"
+
+	//
+	// SwiftStorageDevices condition messages
+	//
+	// SwiftStorageDevicesReadyMessage
+	SwiftStorageDevicesReadyMessage = "SwiftStorage devices available"
+
+	// SwiftStorageDevicesFailedMessage
+	SwiftStorageDevicesFailedMessage = "SwiftStorage devices failed: %s"
+
 	//
 	// SwiftProxyReady condition messages
 	//
@@ -59,4 +221,85 @@ const (
 
 	// SwiftProxyReadyErrorMessage
 	SwiftProxyReadyErrorMessage = "SwiftProxy error occured %s"
+
+	//
+	// KeystoneServiceReady and KeystoneEndpointReady condition messages
+	//
+	// SwiftProxyKeystoneServiceInitMessage
+	SwiftProxyKeystoneServiceInitMessage = "KeystoneService not created"
+
+	// SwiftProxyKeystoneServiceErrorMessage
+	SwiftProxyKeystoneServiceErrorMessage = "KeystoneService error occured %s"
+
+	// SwiftProxyKeystoneServiceWaitingMessage
+	SwiftProxyKeystoneServiceWaitingMessage = "Waiting for KeystoneService %s of the public proxies"
+
+	// SwiftProxyKeystoneServiceReadyMessage
+	SwiftProxyKeystoneServiceReadyMessage = "KeystoneService %s ready"
+
+	// SwiftProxyKeystoneEndpointInitMessage
+	SwiftProxyKeystoneEndpointInitMessage = "KeystoneEndpoint not created"
+
+	// SwiftProxyKeystoneEndpointErrorMessage
+	SwiftProxyKeystoneEndpointErrorMessage = "KeystoneEndpoint error occured %s"
+
+	//
+	// TLSInputReady condition messages
+	//
+	// SwiftProxyTLSInputRunningMessage
+	SwiftProxyTLSInputRunningMessage = "TLS certificate %s not issued yet"
+
+	// SwiftProxyTLSInputReadyMessage
+	SwiftProxyTLSInputReadyMessage = "TLS certificate available"
+
+	//
+	// SwiftProxyDeprecation condition messages
+	//
+	// SwiftProxyDeprecationReadyMessage
+	SwiftProxyDeprecationReadyMessage = "No deprecated options used"
+
+	// SwiftProxyS3LegacyClientsMessage
+	SwiftProxyS3LegacyClientsMessage = "s3api legacyClients is enabled, AWS v2 signatures and path-style addressing are deprecated"
+
+	//
+	// SwiftProxyEncryption condition messages
+	//
+	// SwiftProxyEncryptionRunningMessage
+	SwiftProxyEncryptionRunningMessage = "Root secret rotation to %s in progress, %d of %d proxies updated"
+
+	// SwiftProxyEncryptionReadyMessage
+	SwiftProxyEncryptionReadyMessage = "Root secret %s used by all proxies"
+
+	// SwiftProxyEncryptionErrorMessage
+	SwiftProxyEncryptionErrorMessage = "Encryption error occured %s"
+
+	//
+	// SwiftProxyRollout condition messages
+	//
+	// SwiftProxyRolloutRunningMessage
+	SwiftProxyRolloutRunningMessage = "Canary rollout of %s in progress: %s"
+
+	// SwiftProxyRolloutReadyMessage
+	SwiftProxyRolloutReadyMessage = "Proxy image %s rolled out"
+
+	// SwiftProxyRolloutErrorMessage
+	SwiftProxyRolloutErrorMessage = "Canary rollout of %s rolled back: %s"
+
+	//
+	// SwiftVersionSkew condition messages
+	//
+	// SwiftVersionSkewInitMessage
+	SwiftVersionSkewInitMessage = "Swift versions not detected"
+
+	// SwiftVersionSkewRunningMessage
+	SwiftVersionSkewRunningMessage = "Swift version detection in progress"
+
+	// SwiftVersionSkewReadyMessage
+	SwiftVersionSkewReadyMessage = "Swift versions supported"
+
+	// SwiftVersionSkewWarningMessage
+	SwiftVersionSkewWarningMessage = "Swift versions not supported, ignored by versionSkewPolicy Warn: %s"
+
+	// SwiftVersionSkewErrorMessage
+	SwiftVersionSkewErrorMessage = "Swift versions not supported: %s"
 )
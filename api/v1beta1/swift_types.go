@@ -19,6 +19,7 @@ package v1beta1
 import (
 	condition "github.com/openstack-k8s-operators/lib-common/modules/common/condition"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/util"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -28,11 +29,17 @@ const (
 	ContainerImageContainer = "quay.io/podified-antelope-centos9/openstack-swift-container:current-podified"
 	ContainerImageObject    = "quay.io/podified-antelope-centos9/openstack-swift-object:current-podified"
 	ContainerImageProxy     = "quay.io/podified-antelope-centos9/openstack-swift-proxy-server:current-podified"
+	ContainerImageRing      = "quay.io/podified-antelope-centos9/openstack-swift-proxy-server:current-podified"
 	ContainerImageMemcached = "quay.io/podified-antelope-centos9/openstack-memcached:current-podified"
+	ContainerImageRsyslog   = "quay.io/podified-antelope-centos9/openstack-rsyslog:current-podified"
+	ContainerImageHAProxy   = "quay.io/podified-antelope-centos9/openstack-haproxy:current-podified"
+	ContainerImageStatsd    = "quay.io/prometheus/statsd-exporter:v0.26.1"
 )
 
 // SwiftSpec defines the desired state of Swift
 type SwiftSpec struct {
+	SwiftSpecBase `json:",inline"`
+
 	// +kubebuilder:validation:Required
 	// SwiftRing - Spec definition for the Ring service of this Swift deployment
 	SwiftRing SwiftRingSpec `json:"swiftRing"`
@@ -44,23 +51,186 @@ type SwiftSpec struct {
 	// +kubebuilder:validation:Required
 	// SwiftProxy - Spec definition for the Proxy service of this Swift deployment
 	SwiftProxy SwiftProxySpec `json:"swiftProxy"`
+}
+
+// SwiftSpecCore defines the desired state of Swift without the container
+// images. It is embedded in the OpenStackControlPlane, which sets the images
+// of its services itself
+type SwiftSpecCore struct {
+	SwiftSpecBase `json:",inline"`
+
+	// +kubebuilder:validation:Required
+	// SwiftRing - Spec definition for the Ring service of this Swift deployment
+	SwiftRing SwiftRingSpecCore `json:"swiftRing"`
+
+	// +kubebuilder:validation:Required
+	// SwiftStorage - Spec definition for the Storage service of this Swift deployment
+	SwiftStorage SwiftStorageSpecCore `json:"swiftStorage"`
+
+	// +kubebuilder:validation:Required
+	// SwiftProxy - Spec definition for the Proxy service of this Swift deployment
+	SwiftProxy SwiftProxySpecCore `json:"swiftProxy"`
+}
+
+// SwiftSpecBase defines the fields of the Swift spec shared by SwiftSpec and
+// SwiftSpecCore
+type SwiftSpecBase struct {
+	// +kubebuilder:validation:Optional
+	// InternalProxy - optional second set of proxies serving the internal
+	// endpoint, eg. for the Glance and Cinder backup traffic. They use the
+	// swiftProxy settings with the changes given here, the swiftProxy then
+	// only serves the public endpoint
+	InternalProxy SwiftInternalProxySpec `json:"internalProxy,omitempty"`
 
 	// +kubebuilder:validation:Required
 	// +kubebuilder:default=swift-conf
 	// Name of Secret containing swift.conf
 	SwiftConfSecret string `json:"swiftConfSecret"`
 
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=ConfigMap
+	// +kubebuilder:validation:Enum=ConfigMap;Secret
+	// RingDistribution - kind of the swift-ring-files object the rings are
+	// published in. This is passed to SwiftRing, SwiftStorage and SwiftProxy
+	RingDistribution string `json:"ringDistribution,omitempty"`
+
 	// Storage class. This is passed to SwiftStorage unless
 	// storageClass is explicitly set for the SwiftStorage.
 	// +kubebuilder:validation:Required
 	// +kubebuilder:default=""
 	StorageClass string `json:"storageClass"`
+
+	// +kubebuilder:validation:Optional
+	// MemcachedInstance - name of a shared Memcached instance in the same
+	// namespace. This is passed to SwiftStorage and SwiftProxy unless
+	// memcachedInstance is explicitly set for them
+	MemcachedInstance string `json:"memcachedInstance,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=Block
+	// +kubebuilder:validation:Enum=Block;Warn
+	// VersionSkewPolicy - what to do if the Swift versions of the proxy and
+	// storage images are not a supported combination. Block does not
+	// update SwiftStorage and SwiftProxy until the images are fixed, Warn
+	// only reports the unsupported combination
+	VersionSkewPolicy string `json:"versionSkewPolicy,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default={}
+	// Dispersion - optional Jobs populating and reporting the dispersion of
+	// the replicas, eg. to check the placement after rebalances
+	Dispersion SwiftDispersionSpec `json:"dispersion,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// ImagePullSecrets - Secrets used to pull the container images. This is
+	// passed to SwiftRing, SwiftStorage and SwiftProxy unless
+	// imagePullSecrets is explicitly set for them
+	ImagePullSecrets []corev1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
+}
+
+// SwiftInternalProxySpec defines the differences of the internal proxies to
+// the swiftProxy
+type SwiftInternalProxySpec struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=false
+	// Enabled - create a separate SwiftProxy for the internal endpoint
+	Enabled bool `json:"enabled"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=1
+	// +kubebuilder:validation:Minimum=0
+	// Replicas of the internal proxies
+	Replicas *int32 `json:"replicas"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=false
+	// Ratelimit - keep the ratelimit middleware of the swiftProxy, the
+	// OpenStack services are not rate limited by default
+	Ratelimit bool `json:"ratelimit"`
+
+	// +kubebuilder:validation:Optional
+	// KeystoneAuth - replaces the keystoneAuth of the swiftProxy, eg. to
+	// require service tokens
+	KeystoneAuth *SwiftKeystoneAuthSpec `json:"keystoneAuth,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// NodeSelector - replaces the nodeSelector of the swiftProxy
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
 }
 
 // SwiftStatus defines the observed state of Swift
 type SwiftStatus struct {
 	// Conditions
 	Conditions condition.Conditions `json:"conditions,omitempty" optional:"true"`
+
+	// Versions - Swift version detected per container image
+	Versions map[string]string `json:"versions,omitempty"`
+
+	// Map of hashes to track e.g. job status
+	Hash map[string]string `json:"hash,omitempty"`
+
+	// Dispersion - result of the last dispersion report
+	Dispersion *SwiftDispersionStatus `json:"dispersion,omitempty"`
+
+	// ObservedGeneration - the most recent generation reconciled, the
+	// conditions belong to it. The OpenStackControlPlane only uses the Ready
+	// condition if it matches the generation
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+// SwiftDispersionSpec defines the swift-dispersion-populate and
+// swift-dispersion-report Jobs. The populate Job creates the dispersion
+// containers and objects once, the report Job runs after every rebalance
+// and periodically. Both require the Keystone AuthMode
+type SwiftDispersionSpec struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=false
+	// Enabled - run the dispersion Jobs
+	Enabled bool `json:"enabled"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=1
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// Coverage - percentage of the partitions covered by the dispersion
+	// containers and objects
+	Coverage int32 `json:"coverage"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=3600
+	// +kubebuilder:validation:Minimum=300
+	// ReportIntervalSeconds - interval of the report Job between rebalances
+	ReportIntervalSeconds int32 `json:"reportIntervalSeconds"`
+}
+
+// SwiftDispersionStatus is the result of a dispersion report
+type SwiftDispersionStatus struct {
+	// Object - dispersion of the object replicas
+	Object *SwiftDispersionResult `json:"object,omitempty"`
+
+	// Container - dispersion of the container replicas
+	Container *SwiftDispersionResult `json:"container,omitempty"`
+
+	// RingVersion - version of the rings the report was created for
+	RingVersion int64 `json:"ringVersion,omitempty"`
+
+	// LastReportTime - time of the report
+	LastReportTime *metav1.Time `json:"lastReportTime,omitempty"`
+}
+
+// SwiftDispersionResult is the dispersion of the replicas of one ring
+type SwiftDispersionResult struct {
+	// PercentFound - percentage of the expected copies that were found
+	PercentFound string `json:"percentFound"`
+
+	// CopiesFound - number of copies found
+	CopiesFound int64 `json:"copiesFound"`
+
+	// CopiesExpected - number of copies expected
+	CopiesExpected int64 `json:"copiesExpected"`
+
+	// Missing - number of partitions per number of missing copies
+	Missing map[string]int64 `json:"missing,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -118,8 +288,13 @@ func SetupDefaults() {
 		ContainerContainerImageURL: util.GetEnvVar("RELATED_IMAGE_SWIFT_CONTAINER_IMAGE_URL_DEFAULT", ContainerImageContainer),
 		ObjectContainerImageURL:    util.GetEnvVar("RELATED_IMAGE_SWIFT_OBJECT_IMAGE_URL_DEFAULT", ContainerImageObject),
 		ProxyContainerImageURL:     util.GetEnvVar("RELATED_IMAGE_SWIFT_PROXY_IMAGE_URL_DEFAULT", ContainerImageProxy),
+		RingContainerImageURL:      util.GetEnvVar("RELATED_IMAGE_SWIFT_RING_IMAGE_URL_DEFAULT", ContainerImageRing),
 		MemcachedContainerImageURL: util.GetEnvVar("RELATED_IMAGE_SWIFT_MEMCACHED_IMAGE_URL_DEFAULT", ContainerImageMemcached),
+		RsyslogContainerImageURL:   util.GetEnvVar("RELATED_IMAGE_SWIFT_RSYSLOG_IMAGE_URL_DEFAULT", ContainerImageRsyslog),
+		HAProxyContainerImageURL:   util.GetEnvVar("RELATED_IMAGE_SWIFT_HAPROXY_IMAGE_URL_DEFAULT", ContainerImageHAProxy),
+		StatsdContainerImageURL:    util.GetEnvVar("RELATED_IMAGE_SWIFT_STATSD_IMAGE_URL_DEFAULT", ContainerImageStatsd),
 	}
 
 	SetupSwiftDefaults(swiftDefaults)
+	SetupOperatorNamespace(util.GetEnvVar("OPERATOR_NAMESPACE", ""))
 }
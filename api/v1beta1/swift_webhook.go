@@ -17,13 +17,18 @@ limitations under the License.
 package v1beta1
 
 import (
+	"context"
 	"fmt"
+	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 )
@@ -34,11 +39,30 @@ type SwiftDefaults struct {
 	ContainerContainerImageURL string
 	ObjectContainerImageURL    string
 	ProxyContainerImageURL     string
+	RingContainerImageURL      string
 	MemcachedContainerImageURL string
+	RsyslogContainerImageURL   string
+	HAProxyContainerImageURL   string
+	StatsdContainerImageURL    string
+	StorageClass               string
+	MemcachedInstance          string
 }
 
+// OperatorDefaultsConfigMap - name of the ConfigMap in the operator namespace
+// holding site-wide defaults for all Swift instances. Each key can be
+// prefixed with a namespace, e.g. "openstack.storageClass", to override the
+// default for the Swift instances in that namespace only
+const OperatorDefaultsConfigMap = "swift-operator-defaults"
+
 var swiftDefaults SwiftDefaults
 
+// operatorNamespace is the namespace of the OperatorDefaultsConfigMap
+var operatorNamespace string
+
+// defaultsReader reads the OperatorDefaultsConfigMap. It is not cached, as
+// the operator namespace might not be watched
+var defaultsReader client.Reader
+
 // log is for logging in this package.
 var swiftlog = logf.Log.WithName("swift-resource")
 
@@ -47,7 +71,26 @@ func SetupSwiftDefaults(defaults SwiftDefaults) {
 	swiftlog.Info("Swift defaults initialized", "defaults", defaults)
 }
 
+// SetupOperatorNamespace sets the namespace of the OperatorDefaultsConfigMap
+func SetupOperatorNamespace(namespace string) {
+	operatorNamespace = namespace
+}
+
+// OperatorNamespace returns the namespace the operator runs in, empty if it
+// is unknown
+func OperatorNamespace() string {
+	return operatorNamespace
+}
+
+// SetupDefaultsReader sets the client reading the OperatorDefaultsConfigMap,
+// for webhooks of other operators defaulting a SwiftSpecCore with
+// DefaultForNamespace
+func SetupDefaultsReader(reader client.Reader) {
+	defaultsReader = reader
+}
+
 func (r *Swift) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	SetupDefaultsReader(mgr.GetAPIReader())
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(r).
 		Complete()
@@ -63,18 +106,62 @@ var _ webhook.Defaulter = &Swift{}
 func (r *Swift) Default() {
 	swiftlog.Info("default", "name", r.Name)
 
-	r.Spec.Default()
+	r.Spec.DefaultWith(namespaceDefaults(r.Namespace))
+}
+
+// namespaceDefaults returns the defaults for Swift instances in the given
+// namespace. Values of the OperatorDefaultsConfigMap take precedence over
+// the environment defaults; a missing ConfigMap is not an error
+func namespaceDefaults(namespace string) SwiftDefaults {
+	defaults := swiftDefaults
+	if defaultsReader == nil || operatorNamespace == "" {
+		return defaults
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	cm := &corev1.ConfigMap{}
+	err := defaultsReader.Get(ctx, types.NamespacedName{Name: OperatorDefaultsConfigMap, Namespace: operatorNamespace}, cm)
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			swiftlog.Error(err, "unable to read operator defaults, using environment defaults")
+		}
+		return defaults
+	}
+
+	for _, prefix := range []string{"", namespace + "."} {
+		fields := map[string]*string{
+			"accountImage":      &defaults.AccountContainerImageURL,
+			"containerImage":    &defaults.ContainerContainerImageURL,
+			"objectImage":       &defaults.ObjectContainerImageURL,
+			"proxyImage":        &defaults.ProxyContainerImageURL,
+			"ringImage":         &defaults.RingContainerImageURL,
+			"memcachedImage":    &defaults.MemcachedContainerImageURL,
+			"rsyslogImage":      &defaults.RsyslogContainerImageURL,
+			"haproxyImage":      &defaults.HAProxyContainerImageURL,
+			"statsdImage":       &defaults.StatsdContainerImageURL,
+			"storageClass":      &defaults.StorageClass,
+			"memcachedInstance": &defaults.MemcachedInstance,
+		}
+		for key, field := range fields {
+			if value := cm.Data[prefix+key]; value != "" {
+				*field = value
+			}
+		}
+	}
+	return defaults
 }
 
 // Default - set defaults for this Swift spec
 func (spec *SwiftSpec) Default() {
+	spec.DefaultWith(swiftDefaults)
+}
+
+// DefaultWith - set defaults for this Swift spec using the given defaults
+func (spec *SwiftSpec) DefaultWith(swiftDefaults SwiftDefaults) {
 	// ring
 	if spec.SwiftRing.ContainerImage == "" {
-		spec.SwiftRing.ContainerImage = swiftDefaults.ProxyContainerImageURL
-	}
-	// StorageClass
-	if spec.SwiftStorage.StorageClass == "" {
-		spec.SwiftStorage.StorageClass = spec.StorageClass
+		spec.SwiftRing.ContainerImage = swiftDefaults.RingContainerImageURL
 	}
 
 	// storage
@@ -106,6 +193,66 @@ func (spec *SwiftSpec) Default() {
 	if spec.SwiftProxy.ContainerImageMemcached == "" {
 		spec.SwiftProxy.ContainerImageMemcached = swiftDefaults.MemcachedContainerImageURL
 	}
+
+	defaultCore(&spec.SwiftSpecBase, &spec.SwiftStorage.SwiftStorageSpecCore, &spec.SwiftProxy.SwiftProxySpecCore, swiftDefaults)
+}
+
+// Default - set defaults for this Swift spec core. It is called by the
+// OpenStackControlPlane webhook, which sets the container images itself.
+// Only the environment defaults are used, not the OperatorDefaultsConfigMap;
+// as the fields are set afterwards, the Swift webhook does not apply the
+// ConfigMap either. Use DefaultForNamespace to apply it
+func (spec *SwiftSpecCore) Default() {
+	spec.DefaultWith(swiftDefaults)
+}
+
+// DefaultForNamespace - set defaults for this Swift spec core of a Swift
+// instance in the given namespace, including the OperatorDefaultsConfigMap.
+// The ConfigMap is read once SetupOperatorNamespace and SetupDefaultsReader
+// were called, otherwise the environment defaults are used
+func (spec *SwiftSpecCore) DefaultForNamespace(namespace string) {
+	spec.DefaultWith(namespaceDefaults(namespace))
+}
+
+// DefaultWith - set defaults for this Swift spec core using the given
+// defaults
+func (spec *SwiftSpecCore) DefaultWith(swiftDefaults SwiftDefaults) {
+	defaultCore(&spec.SwiftSpecBase, &spec.SwiftStorage, &spec.SwiftProxy, swiftDefaults)
+}
+
+// defaultCore sets the defaults shared by SwiftSpec and SwiftSpecCore, the
+// images of the sidecars are part of the core specs. The memcachedInstance of
+// SwiftStorage and SwiftProxy is left empty, the controller passes the one of
+// the Swift unless it is set explicitly, so a later change of it applies
+func defaultCore(base *SwiftSpecBase, storage *SwiftStorageSpecCore, proxy *SwiftProxySpecCore, swiftDefaults SwiftDefaults) {
+	if base.StorageClass == "" {
+		base.StorageClass = swiftDefaults.StorageClass
+	}
+	if base.MemcachedInstance == "" {
+		base.MemcachedInstance = swiftDefaults.MemcachedInstance
+	}
+
+	// storage
+	if storage.StorageClass == "" {
+		storage.StorageClass = base.StorageClass
+	}
+
+	if storage.LogForwarding.ContainerImage == "" {
+		storage.LogForwarding.ContainerImage = swiftDefaults.RsyslogContainerImageURL
+	}
+
+	// proxy
+	if proxy.LogForwarding.ContainerImage == "" {
+		proxy.LogForwarding.ContainerImage = swiftDefaults.RsyslogContainerImageURL
+	}
+
+	if proxy.ReverseProxy.ContainerImage == "" {
+		proxy.ReverseProxy.ContainerImage = swiftDefaults.HAProxyContainerImageURL
+	}
+
+	if proxy.Metrics.ContainerImage == "" {
+		proxy.Metrics.ContainerImage = swiftDefaults.StatsdContainerImageURL
+	}
 }
 
 // TODO(user): change verbs to "verbs=create;update;delete" if you want to enable deletion validation.
@@ -130,24 +277,88 @@ func (r *Swift) ValidateUpdate(old runtime.Object) error {
 		return apierrors.NewInternalError(fmt.Errorf("unable to convert existing object"))
 	}
 
-	if *r.Spec.SwiftStorage.Replicas < *oldSwift.Spec.SwiftStorage.Replicas {
+	if errs := r.Spec.ValidateUpdate(oldSwift.Spec, field.NewPath("spec")); len(errs) != 0 {
 		return apierrors.NewForbidden(
 			schema.GroupResource{
 				Group:    GroupVersion.WithKind("Swift").Group,
 				Resource: GroupVersion.WithKind("Swift").Kind,
 			},
 			r.GetName(),
-			field.Invalid(
-				field.NewPath("spec").Child("swiftStorage").Child("replicas"),
-				*r.Spec.SwiftStorage.Replicas,
-				"SwiftStorage does not support scale-in",
-			),
+			errs.ToAggregate(),
 		)
 	}
 
 	return nil
 }
 
+// ValidateUpdate - validate the update of this Swift spec
+func (spec *SwiftSpec) ValidateUpdate(old SwiftSpec, basePath *field.Path) field.ErrorList {
+	return validateUpdate(&spec.SwiftRing.SwiftRingSpecCore, &old.SwiftRing.SwiftRingSpecCore,
+		&spec.SwiftStorage.SwiftStorageSpecCore, &old.SwiftStorage.SwiftStorageSpecCore, basePath)
+}
+
+// ValidateUpdate - validate the update of this Swift spec core. It is called
+// by the OpenStackControlPlane webhook with the path of its Swift template
+func (spec *SwiftSpecCore) ValidateUpdate(old SwiftSpecCore, basePath *field.Path) field.ErrorList {
+	return validateUpdate(&spec.SwiftRing, &old.SwiftRing, &spec.SwiftStorage, &old.SwiftStorage, basePath)
+}
+
+// validateUpdate validates the changes shared by SwiftSpec and SwiftSpecCore
+func validateUpdate(ring *SwiftRingSpecCore, oldRing *SwiftRingSpecCore, storage *SwiftStorageSpecCore, oldStorage *SwiftStorageSpecCore, basePath *field.Path) field.ErrorList {
+	if *storage.Replicas < *oldStorage.Replicas {
+		return field.ErrorList{field.Invalid(
+			basePath.Child("swiftStorage").Child("replicas"),
+			*storage.Replicas,
+			"SwiftStorage does not support scale-in",
+		)}
+	}
+
+	// The part power of existing rings can't be changed, except for
+	// increasing the part power of the object ring by one
+	ringPath := basePath.Child("swiftRing")
+	rings := map[string][2]SwiftRingParameters{
+		"account":   {ring.RingParameters.Account, oldRing.RingParameters.Account},
+		"container": {ring.RingParameters.Container, oldRing.RingParameters.Container},
+		"object":    {ring.RingParameters.Object, oldRing.RingParameters.Object},
+	}
+	for name, parameters := range rings {
+		partPower := ringPartPower(ring, parameters[0])
+		oldPartPower := ringPartPower(oldRing, parameters[1])
+		increased := name == "object" && !ring.Composite.Enabled && partPower == oldPartPower+1
+		if partPower != oldPartPower && !increased {
+			return field.ErrorList{field.Invalid(
+				ringPath.Child("ringParameters").Child(name).Child("partPower"),
+				partPower,
+				"the part power of existing rings can't be changed, the object ring can only be increased by one",
+			)}
+		}
+	}
+
+	// An existing object ring can't be replaced by a composite ring and
+	// vice versa
+	if ring.Composite.Enabled != oldRing.Composite.Enabled {
+		return field.ErrorList{field.Invalid(
+			ringPath.Child("composite").Child("enabled"),
+			ring.Composite.Enabled,
+			"composite rings can only be enabled for new rings",
+		)}
+	}
+
+	return nil
+}
+
+// ringPartPower returns the part power of a ring, rings created before the
+// part power was configurable use 8
+func ringPartPower(spec *SwiftRingSpecCore, parameters SwiftRingParameters) int32 {
+	if parameters.PartPower != nil {
+		return *parameters.PartPower
+	}
+	if spec.PartPower != nil {
+		return *spec.PartPower
+	}
+	return 8
+}
+
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type
 func (r *Swift) ValidateDelete() error {
 	swiftlog.Info("validate delete", "name", r.Name)
@@ -19,12 +19,150 @@ package v1beta1
 import (
 	condition "github.com/openstack-k8s-operators/lib-common/modules/common/condition"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/service"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+const (
+	TempURLKeyHash   = "tempurlkey"
+	AccountQuotaHash = "accountquota"
+
+	// AuthModeKeystone authenticates the requests using Keystone
+	AuthModeKeystone = "Keystone"
+	// AuthModeTempAuth authenticates the requests using the tempauth users
+	AuthModeTempAuth = "TempAuth"
+
+	// RolloutStrategyRollingUpdate updates all proxies with the Deployment
+	RolloutStrategyRollingUpdate = "RollingUpdate"
+	// RolloutStrategyCanary updates a share of the proxies first
+	RolloutStrategyCanary = "Canary"
+
+	// Phases of a canary rollout
+	RolloutPhaseProgressing = "Progressing"
+	RolloutPhaseAnalyzing   = "Analyzing"
+	RolloutPhaseCompleted   = "Completed"
+	RolloutPhaseRolledBack  = "RolledBack"
+
+	// Endpoints served by a SwiftProxy
+	EndpointsAll      = "All"
+	EndpointsPublic   = "Public"
+	EndpointsInternal = "Internal"
 )
 
 // EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
 // NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
 
+// SwiftMemcachePoolSpec defines the memcache connections of the proxy. The
+// Swift defaults are low for proxies handling many concurrent requests
+type SwiftMemcachePoolSpec struct {
+	// +kubebuilder:validation:Optional
+	// Servers - memcache servers, host:port, replacing the memcached sidecar
+	// or the servers of the shared Memcached instance
+	Servers []string `json:"servers,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// MaxConnections - maximum number of connections per server and worker
+	MaxConnections *int32 `json:"maxConnections,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Pattern=`^[0-9]+(\.[0-9]+)?$`
+	// ConnectTimeout - seconds to wait for a new connection
+	ConnectTimeout string `json:"connectTimeout,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Pattern=`^[0-9]+(\.[0-9]+)?$`
+	// PoolTimeout - seconds to wait for a free connection of the pool
+	PoolTimeout string `json:"poolTimeout,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Pattern=`^[0-9]+(\.[0-9]+)?$`
+	// IOTimeout - seconds to wait for a response of a server
+	IOTimeout string `json:"ioTimeout,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// Tries - number of servers tried per request
+	Tries *int32 `json:"tries,omitempty"`
+}
+
+// SwiftReplicaAffinitySpec defines the read and write affinity of the proxy
+type SwiftReplicaAffinitySpec struct {
+	// +kubebuilder:validation:Optional
+	// ReadAffinity - priorities of the regions and zones to read from, lower
+	// values are preferred, eg. "r1z1=100, r1=200". Enables the affinity
+	// sorting method
+	ReadAffinity string `json:"readAffinity,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// WriteAffinity - regions and zones to write the object replicas to first,
+	// eg. "r1, r2"
+	WriteAffinity string `json:"writeAffinity,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Pattern=`^[0-9]+( \* replicas)?$`
+	// WriteAffinityNodeCount - number of local nodes tried before the remote
+	// ones, eg. "2 * replicas"
+	WriteAffinityNodeCount string `json:"writeAffinityNodeCount,omitempty"`
+}
+
+// SwiftKeystoneAuthSpec defines the keystoneauth middleware
+type SwiftKeystoneAuthSpec struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default={admin,SwiftOperator}
+	// OperatorRoles - roles that own the account of their project
+	OperatorRoles []string `json:"operatorRoles,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=ResellerAdmin
+	// ResellerAdminRole - role that has access to all accounts
+	ResellerAdminRole string `json:"resellerAdminRole"`
+
+	// +kubebuilder:validation:Optional
+	// ServiceRoles - roles required in a service token to access the AUTH_
+	// accounts, no service token is required if empty
+	ServiceRoles []string `json:"serviceRoles,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +listType=map
+	// +listMapKey=prefix
+	// ResellerPrefixes - reseller prefixes in addition to AUTH_, which is
+	// used in the endpoints, eg. SERVICE_ with the service role for the
+	// data that services like Glance store on behalf of the users
+	ResellerPrefixes []SwiftResellerPrefix `json:"resellerPrefixes,omitempty"`
+}
+
+// SwiftResellerPrefix defines the roles of the accounts with a reseller
+// prefix
+type SwiftResellerPrefix struct {
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^[A-Za-z0-9]+_$`
+	// Prefix - reseller prefix of the accounts, eg. SERVICE_
+	Prefix string `json:"prefix"`
+
+	// +kubebuilder:validation:Optional
+	// OperatorRoles - roles that own the accounts with this prefix, the
+	// operatorRoles of the keystoneAuth are used if empty
+	OperatorRoles []string `json:"operatorRoles,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// ServiceRoles - roles required in a service token to access the
+	// accounts with this prefix, eg. service
+	ServiceRoles []string `json:"serviceRoles,omitempty"`
+}
+
+// SwiftTempAuthSpec defines the users of the tempauth middleware
+type SwiftTempAuthSpec struct {
+	// +kubebuilder:validation:Optional
+	// UsersSecret - name of a Secret with a key <account>_<user> per user,
+	// eg. test_tester. The value is the password, optionally followed by
+	// the groups of the user, eg. "testing .admin". The account must not
+	// contain an underscore
+	UsersSecret string `json:"usersSecret,omitempty"`
+}
+
 // PasswordSelector to identify the AdminUser password from the Secret
 type PasswordSelector struct {
 	// +kubebuilder:validation:Optional
@@ -35,11 +173,7 @@ type PasswordSelector struct {
 
 // SwiftProxySpec defines the desired state of SwiftProxy
 type SwiftProxySpec struct {
-	// +kubebuilder:validation:Required
-	// +kubebuilder:default=1
-	// +kubebuilder:validation:Minimum=0
-	// Replicas of Swift Proxy
-	Replicas *int32 `json:"replicas"`
+	SwiftProxySpecCore `json:",inline"`
 
 	// +kubebuilder:validation:Required
 	// Swift Proxy Container Image URL
@@ -48,6 +182,16 @@ type SwiftProxySpec struct {
 	// +kubebuilder:validation:Required
 	// Image URL for Memcache servicd
 	ContainerImageMemcached string `json:"containerImageMemcached"`
+}
+
+// SwiftProxySpecCore defines the desired state of SwiftProxy without the
+// container images, which are set by the OpenStackControlPlane
+type SwiftProxySpecCore struct {
+	// +kubebuilder:validation:Required
+	// +kubebuilder:default=1
+	// +kubebuilder:validation:Minimum=0
+	// Replicas of Swift Proxy
+	Replicas *int32 `json:"replicas"`
 
 	// +kubebuilder:validation:Required
 	// +kubebuilder:default=swift
@@ -64,20 +208,1103 @@ type SwiftProxySpec struct {
 	// PasswordSelector - Selector to choose the Swift user password from the Secret
 	PasswordSelectors PasswordSelector `json:"passwordSelectors"`
 
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=Keystone
+	// +kubebuilder:validation:Enum=Keystone;TempAuth
+	// AuthMode - authentication of the requests. TempAuth does not require
+	// Keystone, the service user and its Secret are not used then
+	AuthMode string `json:"authMode"`
+
+	// +kubebuilder:validation:Optional
+	// TempAuth - users of the TempAuth mode
+	TempAuth SwiftTempAuthSpec `json:"tempAuth,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default={}
+	// KeystoneAuth - roles and reseller prefixes of the Keystone mode
+	KeystoneAuth SwiftKeystoneAuthSpec `json:"keystoneAuth,omitempty"`
+
 	// +kubebuilder:validation:Required
 	// +kubebuilder:default=swift-conf
 	// Name of Secret containing swift.conf
 	SwiftConfSecret string `json:"swiftConfSecret"`
 
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=ConfigMap
+	// +kubebuilder:validation:Enum=ConfigMap;Secret
+	// RingDistribution - kind of the swift-ring-files object the rings are
+	// mounted from, see SwiftRing
+	RingDistribution string `json:"ringDistribution,omitempty"`
+
 	// +kubebuilder:validation:Optional
 	// Override, provides the ability to override the generated manifest of several child resources.
 	Override ProxyOverrideSpec `json:"override,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// MemcachedInstance - name of a shared Memcached instance in the same
+	// namespace. If set, it is used instead of the memcached sidecar
+	MemcachedInstance string `json:"memcachedInstance,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// MemcachePool - connection pool of the memcache middleware
+	MemcachePool SwiftMemcachePoolSpec `json:"memcachePool,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// CABundleSecretName - name of a Secret with the CA bundle in the
+	// tls-ca-bundle.pem key, used to verify TLS connections to the shared
+	// Memcached instance. The system trust store is used if not set
+	CABundleSecretName string `json:"caBundleSecretName,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// LogForwarding - optional sidecar forwarding the Swift proxy and access logs
+	LogForwarding LogForwardingSpec `json:"logForwarding,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// NetworkAttachments is a list of NetworkAttachment resource names to
+	// attach the proxy pods to, eg. the storage network used by SwiftStorage
+	NetworkAttachments []string `json:"networkAttachments,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=SingleStack;PreferDualStack;RequireDualStack
+	// IPFamilyPolicy of the proxy Services, eg. PreferDualStack on dual-stack clusters
+	IPFamilyPolicy *corev1.IPFamilyPolicy `json:"ipFamilyPolicy,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxItems=2
+	// IPFamilies of the Services. If the first family is IPv6, the proxy
+	// services bind to IPv6 addresses
+	IPFamilies []corev1.IPFamily `json:"ipFamilies,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default={}
+	// Ports - ports of the proxy services
+	Ports SwiftProxyPorts `json:"ports,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// ProxyServer - wsgi server tuning options of the proxy
+	ProxyServer SwiftProxyServerTuning `json:"proxyServer,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=All
+	// +kubebuilder:validation:Enum=All;Public;Internal
+	// Endpoints - endpoint types served by the proxies, each one with its
+	// own Service and Keystone endpoint. Public and Internal allow separate
+	// proxies for the users and for the OpenStack services. The Internal
+	// proxies do not register the Keystone service
+	Endpoints string `json:"endpoints"`
+
+	// +kubebuilder:validation:Optional
+	// ReverseProxy - optional sidecar in front of the proxy server
+	ReverseProxy SwiftReverseProxySpec `json:"reverseProxy,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// ForwardedHeaders - scheme and host of the URLs generated by the proxy,
+	// eg. in the Location headers and the storage URLs, when TLS is
+	// terminated by a Route or a load balancer
+	ForwardedHeaders SwiftForwardedHeadersSpec `json:"forwardedHeaders,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// TLS - certificate used by the reverse proxy to terminate HTTPS
+	TLS SwiftProxyTLSSpec `json:"tls,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Ingress - optional Ingress exposing the public endpoint
+	Ingress SwiftProxyIngressSpec `json:"ingress,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Autoscaling - optional KEDA ScaledObject scaling the proxies
+	Autoscaling SwiftProxyAutoscalingSpec `json:"autoscaling,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Drain - drain the proxies before they are terminated
+	Drain SwiftProxyDrainSpec `json:"drain,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Metrics - optional sidecar publishing the StatsD metrics of the proxy
+	// server for Prometheus, eg. the error limited storage nodes
+	Metrics SwiftProxyMetricsSpec `json:"metrics,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default={}
+	// PodDisruptionBudget - limits the voluntary disruptions of the proxies,
+	// eg. node drains during maintenance
+	PodDisruptionBudget SwiftProxyPDBSpec `json:"podDisruptionBudget,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default={}
+	// Rollout - strategy used to roll out a new proxy image
+	Rollout SwiftProxyRolloutSpec `json:"rollout,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// TempURL - options of the tempurl middleware
+	TempURL SwiftTempURLSpec `json:"tempURL,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// StaticWeb - static websites served from containers
+	StaticWeb SwiftStaticWebSpec `json:"staticWeb,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// DomainRemap - virtual-host style access to accounts and containers
+	DomainRemap SwiftDomainRemapSpec `json:"domainRemap,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default={}
+	// Ratelimit - options of the ratelimit middleware
+	Ratelimit SwiftRatelimitSpec `json:"ratelimit,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default={}
+	// Quotas - account and container quotas
+	Quotas SwiftQuotasSpec `json:"quotas,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default={}
+	// Bulk - options of the bulk middleware used by archive uploads and
+	// bulk deletes
+	Bulk SwiftBulkSpec `json:"bulk,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// LargeObjects - limits of the slo and dlo middlewares
+	LargeObjects SwiftLargeObjectsSpec `json:"largeObjects,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default={}
+	// CORS - cross-origin resource sharing of the proxy for browser clients
+	CORS SwiftCORSSpec `json:"cors,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// VersionedWrites - object versioning APIs of the versioned_writes
+	// middleware
+	VersionedWrites SwiftVersionedWritesSpec `json:"versionedWrites,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Symlink - options of the symlink middleware
+	Symlink SwiftSymlinkSpec `json:"symlink,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default={}
+	// Encryption - encryption at rest of the object data and metadata
+	Encryption SwiftEncryptionSpec `json:"encryption,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default={}
+	// Ceilometer - notifications of the ceilometer middleware used to meter
+	// the Swift traffic
+	Ceilometer SwiftCeilometerSpec `json:"ceilometer,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// AccessLog - format of the access log of the proxy-logging middleware
+	AccessLog SwiftProxyAccessLogSpec `json:"accessLog,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// ReplicaAffinity - regions and zones preferred by this proxy when
+	// reading and writing object replicas in a multi-region cluster
+	ReplicaAffinity SwiftReplicaAffinitySpec `json:"replicaAffinity,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Pipeline - middlewares of the proxy server replacing the pipeline
+	// generated from the enabled features, eg. to reorder them or to add
+	// filters. It must contain catch_errors and healthcheck and end with
+	// proxy-server. Filters of disabled features can't be used
+	Pipeline []string `json:"pipeline,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +listType=map
+	// +listMapKey=name
+	// Filters - additional filter sections, each one has to be used in the
+	// pipeline
+	Filters []SwiftProxyFilter `json:"filters,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// S3API - S3 compatible API using the s3api and s3token middlewares
+	S3API SwiftS3APISpec `json:"s3api,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// ImagePullSecrets - Secrets used to pull the container images
+	ImagePullSecrets []corev1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// PriorityClassName - PriorityClass of the proxy pods
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// NodeSelector - selects the nodes of the proxy pods, eg. the network
+	// edge nodes, independent from the storage pods
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Tolerations - tolerations of the proxy pods
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Affinity - affinity of the proxy pods
+	Affinity *corev1.Affinity `json:"affinity,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Resources - compute resources of the proxy-server container
+	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+}
+
+// SwiftProxyPorts defines the ports used by the proxy services
+type SwiftProxyPorts struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=11211
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// Memcached - port of the memcached sidecar
+	Memcached int32 `json:"memcached"`
+}
+
+// SwiftProxyServerTuning defines the eventlet wsgi server options of the
+// proxy server
+type SwiftProxyServerTuning struct {
+	SwiftServerTuning `json:",inline"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=epolls;poll;selects
+	// EventletHub - eventlet hub used by the proxy server, automatically
+	// selected by eventlet if unset
+	EventletHub string `json:"eventletHub,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// NodeTimeout - seconds to wait for a response of a storage node
+	NodeTimeout *int32 `json:"nodeTimeout,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// RecoverableNodeTimeout - seconds to wait for a response of a storage
+	// node for GET and HEAD requests that can be retried on another node,
+	// nodeTimeout is used if unset
+	RecoverableNodeTimeout *int32 `json:"recoverableNodeTimeout,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Pattern=`^[0-9]+(\.[0-9]+)?$`
+	// ConnTimeout - seconds to wait for a connection to a storage node
+	ConnTimeout string `json:"connTimeout,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// ClientTimeout - seconds to wait for the next chunk of a client upload
+	ClientTimeout *int32 `json:"clientTimeout,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// ErrorSuppressionInterval - seconds a storage node is skipped once it
+	// is error limited, and the time without errors after which its error
+	// count is reset
+	ErrorSuppressionInterval *int32 `json:"errorSuppressionInterval,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// ErrorSuppressionLimit - number of errors after which a storage node
+	// is error limited
+	ErrorSuppressionLimit *int32 `json:"errorSuppressionLimit,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=shuffle;timing
+	// SortingMethod - order in which the storage nodes are tried. timing
+	// prefers the nodes with the fastest connections. The affinity method
+	// is used if replicaAffinity.readAffinity is set
+	SortingMethod string `json:"sortingMethod,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// TimingExpiry - seconds the connection timings of the timing sorting
+	// method are kept
+	TimingExpiry *int32 `json:"timingExpiry,omitempty"`
+}
+
+// SwiftReverseProxySpec defines a sidecar that accepts the client
+// connections and forwards them to the proxy server, eg. to terminate TLS
+// and HTTP/2
+type SwiftReverseProxySpec struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=false
+	// Enabled - add a reverse proxy sidecar to the pods
+	Enabled bool `json:"enabled"`
+
+	// +kubebuilder:validation:Optional
+	// Image URL for the reverse proxy sidecar
+	ContainerImage string `json:"containerImage,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// TLSSecret - name of a Secret with tls.crt and tls.key, used to
+	// terminate TLS. Takes precedence over tls.secretName. Plain HTTP is
+	// used if neither is set
+	TLSSecret string `json:"tlsSecret,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=false
+	// HTTP2 - offer HTTP/2 to TLS clients
+	HTTP2 bool `json:"http2"`
+
+	// +kubebuilder:validation:Optional
+	// +listType=map
+	// +listMapKey=status
+	// ErrorPages - custom responses returned instead of the error responses
+	// of the proxy server and the reverse proxy itself
+	ErrorPages []SwiftErrorPage `json:"errorPages,omitempty"`
+}
+
+// SwiftForwardedHeadersSpec defines how the X-Forwarded-Proto,
+// X-Forwarded-Host and Forwarded headers of the requests are handled
+type SwiftForwardedHeadersSpec struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=false
+	// Enabled - use the scheme and host of the forwarded headers for the
+	// generated URLs, using the http_proxy_to_wsgi middleware
+	Enabled bool `json:"enabled"`
+
+	// +kubebuilder:validation:Optional
+	// TrustedCIDRs - the reverse proxy sidecar removes the forwarded
+	// headers of requests from other addresses. The headers of all
+	// clients are used if empty
+	TrustedCIDRs []string `json:"trustedCIDRs,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=false
+	// SecureScheme - the reverse proxy sidecar sets X-Forwarded-Proto to
+	// https on all requests, for load balancers terminating TLS without
+	// setting the header
+	SecureScheme bool `json:"secureScheme"`
+}
+
+// SwiftProxyTLSSpec defines the certificate of the proxy endpoints. TLS is
+// terminated by the reverse proxy sidecar, thus it has to be enabled
+type SwiftProxyTLSSpec struct {
+	// +kubebuilder:validation:Optional
+	// SecretName - name of a Secret with tls.crt and tls.key. If IssuerRef
+	// is set, the Secret is written by cert-manager and defaults to
+	// <name>-tls
+	SecretName string `json:"secretName,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// IssuerRef - cert-manager Issuer or ClusterIssuer used to request the
+	// certificate. The certificate is provided by the user if unset
+	IssuerRef *SwiftIssuerReference `json:"issuerRef,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// DNSNames - additional subject alternative names of the requested
+	// certificate. The hostnames of the public and internal endpoints are
+	// always included
+	DNSNames []string `json:"dnsNames,omitempty"`
+}
+
+// SwiftProxyIngressSpec defines an Ingress exposing the public endpoint on
+// clusters without OpenShift routes
+type SwiftProxyIngressSpec struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=false
+	// Enabled - create an Ingress for the public Service
+	Enabled bool `json:"enabled"`
+
+	// +kubebuilder:validation:Optional
+	// IngressClassName - class of the Ingress, the cluster default is used
+	// if unset
+	IngressClassName *string `json:"ingressClassName,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Host - hostname of the Ingress, also used for the public endpoint
+	// URL unless it is overridden. All hosts are matched if unset
+	Host string `json:"host,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// TLSSecret - name of a Secret with tls.crt and tls.key for the host.
+	// The public endpoint uses HTTPS if set
+	TLSSecret string `json:"tlsSecret,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default="0"
+	// MaxBodySize - maximum request body size accepted by the ingress
+	// controller, eg. 5g. 0 disables the limit, which is required for
+	// uploads of large objects
+	MaxBodySize string `json:"maxBodySize"`
+
+	// +kubebuilder:validation:Optional
+	// Annotations - additional annotations of the Ingress, eg. for ingress
+	// controllers other than ingress-nginx
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// SwiftProxyAutoscalingSpec defines a KEDA ScaledObject scaling the proxy
+// Deployment on a Prometheus metric, eg. the request rate or the number of
+// connections. The CPU usage is a poor signal for proxies streaming large
+// objects
+type SwiftProxyAutoscalingSpec struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=false
+	// Enabled - create a ScaledObject, the replicas of the SwiftProxy are
+	// only used until the first scaling
+	Enabled bool `json:"enabled"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=1
+	// +kubebuilder:validation:Minimum=1
+	// MinReplicas - minimum number of proxies
+	MinReplicas int32 `json:"minReplicas"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=10
+	// +kubebuilder:validation:Minimum=1
+	// MaxReplicas - maximum number of proxies
+	MaxReplicas int32 `json:"maxReplicas"`
+
+	// +kubebuilder:validation:Optional
+	// ServerAddress - URL of the Prometheus server
+	ServerAddress string `json:"serverAddress,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Query - PromQL query returning the load of all proxies, eg. the
+	// request rate reported by the ingress controller in front of them:
+	// sum(rate(nginx_ingress_controller_requests{service="swift-public"}[2m]))
+	Query string `json:"query,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default="100"
+	// Threshold - value of the query handled by a single proxy
+	Threshold string `json:"threshold"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=30
+	// +kubebuilder:validation:Minimum=1
+	// PollingInterval - seconds between two queries
+	PollingInterval int32 `json:"pollingInterval"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=300
+	// +kubebuilder:validation:Minimum=0
+	// CooldownPeriod - seconds to wait after the last active trigger before
+	// scaling down
+	CooldownPeriod int32 `json:"cooldownPeriod"`
+}
+
+// SwiftProxyDrainSpec defines the draining of the proxies using the
+// disable_path of the healthcheck middleware. A draining proxy returns 503
+// to the health checks, thus the load balancers stop sending new requests
+// to it. Proxies are drained before they are terminated, and while the pod
+// has the swift.openstack.org/drain annotation set to "true"
+type SwiftProxyDrainSpec struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=false
+	// Enabled - drain the proxies
+	Enabled bool `json:"enabled"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=30
+	// +kubebuilder:validation:Minimum=0
+	// Seconds - time given to the load balancers and the requests in
+	// flight before a draining proxy is stopped
+	Seconds int32 `json:"seconds"`
+}
+
+// SwiftProxyMetricsSpec defines the statsd exporter sidecar. The proxy server
+// counts the storage nodes it starts to error limit in
+// swift_proxy_error_limited_total, its increase over the last
+// errorSuppressionInterval is the number of currently suppressed nodes
+type SwiftProxyMetricsSpec struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=false
+	// Enabled - add a statsd exporter sidecar to the pods
+	Enabled bool `json:"enabled"`
+
+	// +kubebuilder:validation:Optional
+	// Image URL for the statsd exporter sidecar
+	ContainerImage string `json:"containerImage,omitempty"`
+}
+
+// SwiftProxyPDBSpec defines the PodDisruptionBudget of the proxies. It is
+// only created if there is more than one proxy, otherwise it would block the
+// node drains
+type SwiftProxyPDBSpec struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=true
+	// Enabled - create a PodDisruptionBudget for the proxy pods
+	Enabled bool `json:"enabled"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=1
+	// +kubebuilder:validation:XIntOrString
+	// MinAvailable - number or percentage of proxies that have to stay
+	// available during voluntary disruptions
+	MinAvailable *intstr.IntOrString `json:"minAvailable,omitempty"`
+}
+
+// SwiftProxyRolloutSpec defines how a new proxy image is rolled out. The
+// Canary strategy starts a separate canary Deployment with the new image in
+// addition to the proxies. They receive a share of the traffic from the
+// Services. Once the canaries passed the analysis, all proxies are updated.
+// Otherwise the canaries are removed and the proxies keep the previous image
+// until the image is changed again
+type SwiftProxyRolloutSpec struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=RollingUpdate
+	// +kubebuilder:validation:Enum=RollingUpdate;Canary
+	// Strategy - RollingUpdate or Canary
+	Strategy string `json:"strategy"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=25
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// CanaryPercent - number of canaries in percent of the replicas,
+	// rounded up
+	CanaryPercent int32 `json:"canaryPercent"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=600
+	// +kubebuilder:validation:Minimum=1
+	// TimeoutSeconds - time given to the canaries to pass their readiness
+	// probe, which uses the healthcheck middleware
+	TimeoutSeconds int32 `json:"timeoutSeconds"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=300
+	// +kubebuilder:validation:Minimum=0
+	// AnalysisSeconds - time the canaries have to stay ready without
+	// restarts before the rollout is completed
+	AnalysisSeconds int32 `json:"analysisSeconds"`
+
+	// +kubebuilder:validation:Optional
+	// ErrorRate - optional Prometheus check at the end of the analysis
+	ErrorRate SwiftProxyErrorRateSpec `json:"errorRate,omitempty"`
+}
+
+// SwiftProxyErrorRateSpec defines a Prometheus query returning the error rate
+// of the canaries
+type SwiftProxyErrorRateSpec struct {
+	// +kubebuilder:validation:Optional
+	// ServerAddress - URL of the Prometheus server, the check is disabled if
+	// unset
+	ServerAddress string `json:"serverAddress,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Query - PromQL query returning the error rate of the canary pods,
+	// whose names start with the SwiftProxy name and -canary-, eg. the
+	// share of 5xx responses
+	Query string `json:"query,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default="0.01"
+	// +kubebuilder:validation:Pattern=`^[0-9]+(\.[0-9]+)?$`
+	// Threshold - maximum value of the query
+	Threshold string `json:"threshold"`
+}
+
+// SwiftIssuerReference references a cert-manager issuer
+type SwiftIssuerReference struct {
+	// +kubebuilder:validation:Required
+	// Name of the issuer
+	Name string `json:"name"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=Issuer
+	// +kubebuilder:validation:Enum=Issuer;ClusterIssuer
+	// Kind of the issuer
+	Kind string `json:"kind"`
+}
+
+// SwiftErrorPage defines the response returned for an HTTP error status
+type SwiftErrorPage struct {
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Enum=400;401;403;404;405;408;410;413;425;429;500;501;502;503;504
+	// Status - HTTP status of the error responses to replace
+	Status int32 `json:"status"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default="text/html"
+	// ContentType of the body
+	ContentType string `json:"contentType"`
+
+	// +kubebuilder:validation:Optional
+	// Body of the response. The response has no body if unset
+	Body string `json:"body,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// RetryAfter - value of the Retry-After header, e.g. for 429 and 503
+	RetryAfter string `json:"retryAfter,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Headers - additional headers of the response
+	Headers map[string]string `json:"headers,omitempty"`
+}
+
+// SwiftTempURLSpec defines the tempurl and formpost middlewares, which allow
+// the access to objects using signed URLs and forms
+type SwiftTempURLSpec struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=false
+	// FormPost - add formpost to the proxy pipeline, which accepts browser
+	// uploads signed with the same keys and digests as the temp URLs
+	FormPost bool `json:"formPost"`
+
+	// +kubebuilder:validation:Optional
+	// Methods - HTTP methods allowed with temp URLs. The tempurl defaults
+	// GET, HEAD, PUT, POST and DELETE are used if unset
+	// +kubebuilder:validation:items:Enum=GET;HEAD;PUT;POST;DELETE
+	Methods []string `json:"methods,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// AllowedDigests - digests accepted in the signatures. The tempurl
+	// defaults sha256 and sha512 are used if unset
+	// +kubebuilder:validation:items:Enum=sha1;sha256;sha512
+	AllowedDigests []string `json:"allowedDigests,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// KeySecret - name of a Secret with the temp URL keys of the account of
+	// the service project, eg. used by the Image and Bare Metal services.
+	// The keys are set on the account by a Job
+	KeySecret string `json:"keySecret,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default={key: TempURLKey, key2: TempURLKey2}
+	// KeySelectors - keys of the Secret with the temp URL keys. The second
+	// key allows a rotation without invalidating the existing URLs, keys
+	// missing in the Secret are removed from the account
+	KeySelectors SwiftTempURLKeySelector `json:"keySelectors,omitempty"`
+}
+
+// SwiftTempURLKeySelector selects the temp URL keys from the Secret
+type SwiftTempURLKeySelector struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default="TempURLKey"
+	// Key - Temp-URL-Key of the account
+	Key string `json:"key"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default="TempURLKey2"
+	// Key2 - Temp-URL-Key-2 of the account
+	Key2 string `json:"key2"`
+}
+
+// SwiftStaticWebSpec defines the staticweb middleware, which serves the
+// objects of containers with the web-index metadata as static website. The
+// containers have to be readable by anonymous users
+type SwiftStaticWebSpec struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=false
+	// Enabled - add staticweb to the proxy pipeline
+	Enabled bool `json:"enabled"`
+}
+
+// SwiftDomainRemapSpec defines the domain_remap and cname_lookup
+// middlewares, which translate the host of a request into the account and
+// container, eg. container.AUTH-account.example.com
+type SwiftDomainRemapSpec struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=false
+	// Enabled - add domain_remap to the proxy pipeline
+	Enabled bool `json:"enabled"`
+
+	// +kubebuilder:validation:Optional
+	// StorageDomains - domains of the virtual hosts, eg. example.com
+	StorageDomains []string `json:"storageDomains,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default="v1"
+	// PathRoot - path prepended to the remapped account and container
+	PathRoot string `json:"pathRoot"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=false
+	// CNAMELookup - add cname_lookup to the proxy pipeline, which resolves
+	// custom domains with a CNAME record pointing to a storage domain
+	CNAMELookup bool `json:"cnameLookup"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// LookupDepth - maximum number of CNAME records followed
+	LookupDepth *int32 `json:"lookupDepth,omitempty"`
+}
+
+// SwiftRatelimitSpec defines the ratelimit middleware. The limits are
+// tracked in memcache, thus apply to all proxies together
+type SwiftRatelimitSpec struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=true
+	// Enabled - add the ratelimit middleware to the pipeline
+	Enabled bool `json:"enabled"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	// AccountRatelimit - container PUT and DELETE requests per second and
+	// account, 0 disables the limit
+	AccountRatelimit *int32 `json:"accountRatelimit,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// MaxSleepTimeSeconds - requests that would have to wait longer are
+	// rejected with 498
+	MaxSleepTimeSeconds *int32 `json:"maxSleepTimeSeconds,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +listType=map
+	// +listMapKey=size
+	// ContainerRatelimits - object PUT and DELETE requests per second and
+	// container, depending on the number of objects in the container
+	ContainerRatelimits []SwiftRatelimitBucket `json:"containerRatelimits,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +listType=map
+	// +listMapKey=size
+	// ContainerListingRatelimits - container GET requests per second and
+	// container, depending on the number of objects in the container
+	ContainerListingRatelimits []SwiftRatelimitBucket `json:"containerListingRatelimits,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// AccountWhitelist - accounts that are not rate limited, eg. AUTH_1234
+	AccountWhitelist []string `json:"accountWhitelist,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// AccountBlacklist - accounts whose write requests are all rejected
+	AccountBlacklist []string `json:"accountBlacklist,omitempty"`
+}
+
+// SwiftRatelimitBucket defines the rate limit of containers with at least
+// the given number of objects. The limit of smaller containers is
+// interpolated
+type SwiftRatelimitBucket struct {
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Minimum=0
+	// Size - number of objects in the container
+	Size int32 `json:"size"`
+
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Minimum=0
+	// Rate - requests per second
+	Rate int32 `json:"rate"`
+}
+
+// SwiftBulkSpec defines the bulk middleware, which extracts uploaded
+// archives (?extract-archive) and deletes many objects in a single request
+// (?bulk-delete)
+type SwiftBulkSpec struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=true
+	// Enabled - add bulk to the proxy pipeline
+	Enabled bool `json:"enabled"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// MaxContainersPerExtraction - maximum number of containers created by
+	// a single archive upload
+	MaxContainersPerExtraction *int32 `json:"maxContainersPerExtraction,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// MaxFailedExtractions - number of failed objects after which an
+	// archive upload is aborted
+	MaxFailedExtractions *int32 `json:"maxFailedExtractions,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// MaxDeletesPerRequest - maximum number of objects deleted by a single
+	// bulk delete request
+	MaxDeletesPerRequest *int32 `json:"maxDeletesPerRequest,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// MaxFailedDeletes - number of failed deletes after which a bulk delete
+	// request is aborted
+	MaxFailedDeletes *int32 `json:"maxFailedDeletes,omitempty"`
+}
+
+// SwiftLargeObjectsSpec defines the limits of static (slo) and dynamic (dlo)
+// large objects
+type SwiftLargeObjectsSpec struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// MaxManifestSegments - maximum number of segments of a static large
+	// object manifest
+	MaxManifestSegments *int32 `json:"maxManifestSegments,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// MaxManifestSize - maximum size in bytes of a static large object
+	// manifest
+	MaxManifestSize *int32 `json:"maxManifestSize,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	// RateLimitAfterSegment - number of segments of a large object
+	// download that are not rate limited, applies to slo and dlo
+	RateLimitAfterSegment *int32 `json:"rateLimitAfterSegment,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	// RateLimitSegmentsPerSec - segments per second of a large object
+	// download after RateLimitAfterSegment, 0 disables the limit. Applies
+	// to slo and dlo
+	RateLimitSegmentsPerSec *int32 `json:"rateLimitSegmentsPerSec,omitempty"`
+}
+
+// SwiftCORSSpec defines the CORS options of the proxy server. Containers
+// might allow additional origins using X-Container-Meta-Access-Control-*
+type SwiftCORSSpec struct {
+	// +kubebuilder:validation:Optional
+	// AllowOrigin - origins allowed for all containers, eg.
+	// https://dashboard.example.com
+	AllowOrigin []string `json:"allowOrigin,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// ExposeHeaders - headers exposed to the clients in addition to the
+	// default ones, for all containers
+	ExposeHeaders []string `json:"exposeHeaders,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=true
+	// StrictMode - only return the CORS headers to requests with an Origin
+	// header matching an allowed origin
+	StrictMode bool `json:"strictMode"`
+}
+
+// SwiftVersionedWritesSpec defines the object versioning APIs
+type SwiftVersionedWritesSpec struct {
+	// +kubebuilder:validation:Optional
+	// AllowVersionedWrites - allow the legacy X-Versions-Location and
+	// X-History-Location container headers. This requires allowVersions
+	// of the SwiftStorage, which the Swift controller sets accordingly
+	AllowVersionedWrites bool `json:"allowVersionedWrites,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// AllowObjectVersioning - allow the X-Versions-Enabled container header
+	// of the object versioning API. This adds symlink to the proxy pipeline
+	AllowObjectVersioning bool `json:"allowObjectVersioning,omitempty"`
+}
+
+// SwiftSymlinkSpec defines the symlink middleware
+type SwiftSymlinkSpec struct {
+	// +kubebuilder:validation:Optional
+	// Enabled - add symlink to the proxy pipeline. It is always added if
+	// versionedWrites.allowObjectVersioning is set
+	Enabled bool `json:"enabled,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// SymloopMax - maximum number of chained symlinks that are followed
+	// before a request is rejected with 409
+	SymloopMax *int32 `json:"symloopMax,omitempty"`
+}
+
+// SwiftEncryptionSpec defines the encryption middleware using the root secret
+// stored in Barbican or a KMIP server. Barbican is accessed by the
+// kms_keymaster using the service user, which needs read access to the
+// secret. Objects written before can't be read anymore if the root secret
+// changes
+type SwiftEncryptionSpec struct {
+	// +kubebuilder:validation:Optional
+	// Enabled - add the keymaster and encryption to the proxy pipeline
+	Enabled bool `json:"enabled,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=Barbican
+	// +kubebuilder:validation:Enum=Barbican;KMIP
+	// Keymaster - key management service storing the root secret
+	Keymaster string `json:"keymaster"`
+
+	// +kubebuilder:validation:Optional
+	// KeySecret - name of a Secret with the ID of the Barbican secret or
+	// the KMIP key used as root secret, required if encryption is enabled
+	KeySecret string `json:"keySecret,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=RootSecretID
+	// KeySelector - key of the Secret with the ID of the default root
+	// secret. It is optional if rootSecrets are given
+	KeySelector string `json:"keySelector"`
+
+	// +kubebuilder:validation:Optional
+	// +listType=map
+	// +listMapKey=id
+	// RootSecrets - additional root secrets, used to rotate the root secret.
+	// Objects are decrypted with the root secret they were encrypted with,
+	// thus root secrets must not be removed while objects use them
+	RootSecrets []SwiftEncryptionRootSecret `json:"rootSecrets,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// ActiveRootSecretID - ID of the root secret used to encrypt new
+	// objects, the default root secret is used if empty
+	ActiveRootSecretID string `json:"activeRootSecretID,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// KMIP - connection to the KMIP server, required if the keymaster is
+	// KMIP
+	KMIP SwiftKMIPSpec `json:"kmip,omitempty"`
+}
+
+// SwiftEncryptionRootSecret defines an additional root secret
+type SwiftEncryptionRootSecret struct {
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^[A-Za-z0-9_-]+$`
+	// ID - ID of the root secret stored in the object metadata
+	ID string `json:"id"`
+
+	// +kubebuilder:validation:Required
+	// KeySelector - key of the keySecret with the ID of the Barbican secret
+	// or the KMIP key
+	KeySelector string `json:"keySelector"`
+}
+
+// SwiftKMIPSpec defines the connection of the kmip_keymaster
+type SwiftKMIPSpec struct {
+	// +kubebuilder:validation:Optional
+	// Host - hostname or IP of the KMIP server
+	Host string `json:"host,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=5696
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// Port - port of the KMIP server
+	Port int32 `json:"port,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// CertSecret - name of a Secret with the client certificate (tls.crt),
+	// its key (tls.key) and the CA of the KMIP server (ca.crt)
+	CertSecret string `json:"certSecret,omitempty"`
+}
+
+// SwiftProxyAccessLogSpec defines the access log lines of the proxy
+type SwiftProxyAccessLogSpec struct {
+	// +kubebuilder:validation:Optional
+	// MsgTemplate - format of the access log lines using the proxy-logging
+	// fields, eg. "{client_ip} {method} {path} {status_int}"
+	MsgTemplate string `json:"msgTemplate,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// AnonymizeClientIP - log the hashes of the client_ip and remote_addr
+	// fields instead of the addresses
+	AnonymizeClientIP bool `json:"anonymizeClientIP,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=md5;sha1;sha224;sha256;sha384;sha512
+	// AnonymizationMethod - hash used for the anonymized fields, md5 if
+	// unset
+	AnonymizationMethod string `json:"anonymizationMethod,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// AnonymizationSalt - salt of the anonymized fields, so that the hashes
+	// of the addresses can't be precomputed
+	AnonymizationSalt string `json:"anonymizationSalt,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// LogHeaders - add the request headers to the access log lines
+	LogHeaders bool `json:"logHeaders,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// LogHeadersOnly - only log these request headers, eg. to skip the
+	// ones with credentials
+	LogHeadersOnly []string `json:"logHeadersOnly,omitempty"`
+}
+
+// SwiftCeilometerSpec defines the ceilometer middleware sending a notification
+// per request to RabbitMQ
+type SwiftCeilometerSpec struct {
+	// +kubebuilder:validation:Optional
+	// Enabled - add ceilometer to the proxy pipeline
+	Enabled bool `json:"enabled,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=rabbitmq
+	// RabbitMqClusterName - name of the RabbitMQ cluster, a TransportURL
+	// is requested for it
+	RabbitMqClusterName string `json:"rabbitMqClusterName"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=notifications
+	// Topic - topic of the notifications
+	Topic string `json:"topic"`
+
+	// +kubebuilder:validation:Optional
+	// IgnoreProjects - names of the projects whose requests are not
+	// metered, eg. the project of the telemetry storage. The names are
+	// resolved using the service user
+	IgnoreProjects []string `json:"ignoreProjects,omitempty"`
+}
+
+// SwiftProxyFilter defines an additional filter of the proxy pipeline
+type SwiftProxyFilter struct {
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^[A-Za-z0-9_-]+$`
+	// Name - name of the filter in the pipeline
+	Name string `json:"name"`
+
+	// +kubebuilder:validation:Optional
+	// Use - entry point of the filter, eg. egg:swift#xprofile. Required
+	// unless the paste.filter_factory option is given
+	Use string `json:"use,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Options - options of the filter section
+	Options map[string]string `json:"options,omitempty"`
+}
+
+// SwiftQuotasSpec defines the account_quotas and container_quotas
+// middlewares
+type SwiftQuotasSpec struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=true
+	// AccountQuotas - add account_quotas to the proxy pipeline
+	AccountQuotas bool `json:"accountQuotas"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=true
+	// ContainerQuotas - add container_quotas to the proxy pipeline
+	ContainerQuotas bool `json:"containerQuotas"`
+
+	// +kubebuilder:validation:Optional
+	// DefaultAccountQuota - quota set by a Job on the accounts of all
+	// existing projects without a quota, eg. 100Gi. The Job runs again
+	// once the quota changes, thus accounts of projects created later keep
+	// unlimited until then. The service user requires the admin role to list
+	// the projects and the ResellerAdmin role to set the quotas
+	DefaultAccountQuota *resource.Quantity `json:"defaultAccountQuota,omitempty"`
+}
+
+// SwiftS3APISpec defines the S3 compatible API of the proxy. The clients
+// authenticate using EC2 credentials of Keystone, created with "openstack
+// ec2 credentials create", which are validated by the s3token middleware
+type SwiftS3APISpec struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=false
+	// Enabled - add s3api and s3token to the proxy pipeline
+	Enabled bool `json:"enabled"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=false
+	// LegacyClients - compatibility with legacy swift3 clients that use
+	// AWS v2 signatures and path-style addressing with bucket names that
+	// are not DNS compliant. s3api accepts v2 signatures in any case, this
+	// allows the legacy bucket names. Both are deprecated by AWS and a
+	// warning condition is set if enabled
+	LegacyClients bool `json:"legacyClients"`
+
+	// +kubebuilder:validation:Optional
+	// Location - region name returned to the clients and accepted in the
+	// AWS v4 signatures. The s3api default us-east-1 is used if unset
+	Location string `json:"location,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=10000
+	// MaxUploadPartNum - maximum number of parts of a multipart upload
+	MaxUploadPartNum *int32 `json:"maxUploadPartNum,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// StorageDomain - domain of the virtual-hosted style requests, which
+	// pass the bucket name in the host, eg. bucket.s3.example.com for
+	// s3.example.com. Path-style addressing is used if unset
+	StorageDomain string `json:"storageDomain,omitempty"`
 }
 
 // ProxyOverrideSpec to override the generated manifest of several child resources.
 type ProxyOverrideSpec struct {
 	// Override configuration for the Service created to serve traffic to the cluster.
-	// The key must be the endpoint type (public, internal)
+	// The key must be the endpoint type (public, internal). The proxy is
+	// exposed on a VIP using type LoadBalancer, eg. with the
+	// metallb.universe.tf/address-pool and metallb.universe.tf/loadBalancerIPs
+	// annotations. The Service hostname is registered as endpoint unless
+	// endpointURL is set
 	Service map[service.Endpoint]service.RoutedOverrideSpec `json:"service,omitempty"`
 }
 
@@ -86,10 +1313,49 @@ type SwiftProxyStatus struct {
 	// ReadyCount of SwiftProxy instances
 	ReadyCount int32 `json:"readyCount,omitempty"`
 
+	// NetworkAttachments status of the proxy pods
+	NetworkAttachments map[string][]string `json:"networkAttachments,omitempty"`
+
+	// Map of hashes to track e.g. job status
+	Hash map[string]string `json:"hash,omitempty"`
+
+	// LoadBalancerIPs - IPs assigned to the LoadBalancer Services per
+	// endpoint type
+	LoadBalancerIPs map[string][]string `json:"loadBalancerIPs,omitempty"`
+
+	// ActiveRootSecretID - ID of the encryption root secret used by all
+	// proxies, "default" for the default root secret. It is updated once
+	// all proxies are rolled out after a root secret rotation
+	ActiveRootSecretID string `json:"activeRootSecretID,omitempty"`
+
+	// Rollout - state of the canary rollout
+	Rollout SwiftProxyRolloutStatus `json:"rollout,omitempty"`
+
 	// Conditions
 	Conditions condition.Conditions `json:"conditions,omitempty" optional:"true"`
 }
 
+// SwiftProxyRolloutStatus defines the state of the canary rollout
+type SwiftProxyRolloutStatus struct {
+	// StableImage - proxy image of all proxies except the canaries
+	StableImage string `json:"stableImage,omitempty"`
+
+	// CanaryImage - proxy image of the last canary rollout
+	CanaryImage string `json:"canaryImage,omitempty"`
+
+	// Phase - Progressing, Analyzing, Completed or RolledBack
+	Phase string `json:"phase,omitempty"`
+
+	// StartTime - creation of the canaries
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// ReadyTime - start of the analysis once all canaries are ready
+	ReadyTime *metav1.Time `json:"readyTime,omitempty"`
+
+	// Message - reason of a rollback
+	Message string `json:"message,omitempty"`
+}
+
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
 //+kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.conditions[0].status",description="Status"
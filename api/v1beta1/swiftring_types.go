@@ -18,12 +18,49 @@ package v1beta1
 
 import (
 	condition "github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 const (
-	RingCreateHash = "ringcreate"
-	DeviceListHash = "devicelist"
+	RingCreateHash     = "ringcreate"
+	RingVerifyHash     = "ringverify"
+	DeviceListHash     = "devicelist"
+	RingFilesHash      = "ringfiles"
+	RingParametersHash = "ringparameters"
+	DryRunHash         = "dryrun"
+	RingApprovalHash   = "ringapproval"
+	PartPowerHash      = "partpower"
+
+	// RingUpdatePolicyAuto publishes the rings after every rebalance
+	RingUpdatePolicyAuto = "Auto"
+	// RingUpdatePolicyManual publishes the rebalanced rings once approved
+	RingUpdatePolicyManual = "Manual"
+
+	// FailureDomainPolicyWarn reports rings with fewer devices or zones than
+	// replicas
+	FailureDomainPolicyWarn = "Warn"
+	// FailureDomainPolicyEnforce refuses to rebalance rings with fewer
+	// devices than replicas
+	FailureDomainPolicyEnforce = "Enforce"
+
+	// PartPowerPhasePrepare prepares the builder of the partition power
+	// increase, the objects written afterwards are linked to their new
+	// location
+	PartPowerPhasePrepare = "Prepare"
+	// PartPowerPhaseRelink links the existing objects to their new location
+	PartPowerPhaseRelink = "Relink"
+	// PartPowerPhaseIncrease increases the part power of the ring
+	PartPowerPhaseIncrease = "Increase"
+	// PartPowerPhaseCleanup removes the objects from their old location
+	PartPowerPhaseCleanup = "Cleanup"
+	// PartPowerPhaseFinish finishes the partition power increase
+	PartPowerPhaseFinish = "Finish"
+
+	// PartPowerDeviceRelinked and PartPowerDeviceCleanedUp are the states
+	// of the storage pods during a partition power increase
+	PartPowerDeviceRelinked  = "Relinked"
+	PartPowerDeviceCleanedUp = "CleanedUp"
 )
 
 // EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
@@ -31,6 +68,17 @@ const (
 
 // SwiftRingSpec defines the desired state of SwiftRing
 type SwiftRingSpec struct {
+	SwiftRingSpecCore `json:",inline"`
+
+	// +kubebuilder:validation:Required
+	// Image URL of the rebalance and verification Jobs, it must contain
+	// swift-ring-builder
+	ContainerImage string `json:"containerImage"`
+}
+
+// SwiftRingSpecCore defines the desired state of SwiftRing without the
+// container image, which is set by the OpenStackControlPlane
+type SwiftRingSpecCore struct {
 	// INSERT ADDITIONAL SPEC FIELDS - desired state of cluster
 	// Important: Run "make" to regenerate code after modifying this file
 
@@ -40,14 +88,348 @@ type SwiftRingSpec struct {
 	// Number of Swift object replicas (=copies)
 	RingReplicas *int64 `json:"ringReplicas"`
 
-	// +kubebuilder:validation:Required
-	// Image URL for Swift proxy service
-	ContainerImage string `json:"containerImage"`
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=8
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=32
+	// PartPower - the rings have 2^partPower partitions. It can't be
+	// changed once the rings are created
+	PartPower *int32 `json:"partPower,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// MaxDevices - expected maximum number of devices of a ring. If set,
+	// the part power of new rings is computed from it instead of using
+	// partPower, giving at least 100 partitions per device. Existing rings
+	// keep their part power
+	MaxDevices *int32 `json:"maxDevices,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=1
+	// +kubebuilder:validation:Minimum=0
+	// MinPartHours - hours before a partition can be moved again by a
+	// rebalance
+	MinPartHours *int32 `json:"minPartHours,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// Overload - additional partitions a device may get, in percent of its
+	// weight, to spread the replicas across failure domains. A higher
+	// overload improves the dispersion of small or skewed clusters at the
+	// cost of the balance
+	Overload *int32 `json:"overload,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// RingParameters - parameters of the individual rings, overriding
+	// partPower, minPartHours, overload and ringReplicas. The object ring is
+	// the ring of the storage policy 0
+	RingParameters SwiftRingParametersSpec `json:"ringParameters,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Composite - compose the object ring from component builders using
+	// swift-ring-composer. It can only be used for new rings
+	Composite SwiftRingCompositeSpec `json:"composite,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +listType=map
+	// +listMapKey=index
+	// StoragePolicies - additional storage policies, each with its own
+	// object-<index> ring. Policy 0 uses the object ring. The policies are
+	// published with the rings and appended to swift.conf, which must not
+	// define storage policies itself. A policy can't be removed once its
+	// ring exists, only deprecated
+	StoragePolicies []SwiftRingStoragePolicy `json:"storagePolicies,omitempty"`
 
 	// +kubebuilder:validation:Required
 	// +kubebuilder:default=swift-conf
 	// Name of Secret containing swift.conf
 	SwiftConfSecret string `json:"swiftConfSecret"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=ConfigMap
+	// +kubebuilder:validation:Enum=ConfigMap;Secret
+	// RingDistribution - kind of the swift-ring-files object the rings are
+	// published in. A Secret is not readable by everyone with access to the
+	// namespace
+	RingDistribution string `json:"ringDistribution,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// RingSnapshotSecret - name of a Secret with a ring snapshot in the
+	// swiftrings.tar.gz key and the builders in the swiftbuilders.tar.gz key
+	// of the swift-ring-builders Secret. It is used to create the rings if
+	// none exist yet, e.g. when restoring a deployment with existing data.
+	// Rings without builders are only restored with recoverBuilders. The
+	// restored rings are verified against the data on the storage devices
+	// before the SwiftRing becomes ready
+	RingSnapshotSecret string `json:"ringSnapshotSecret,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// RingImportSecret - name of a Secret with the builder and ring files of
+	// an existing Swift cluster, one key per file, e.g. account.builder and
+	// account.ring.gz. The builders are adopted as the initial state if no
+	// rings exist yet, thus the partitions of the existing devices are not
+	// moved. The builders must match the ring parameters of the SwiftRing
+	RingImportSecret string `json:"ringImportSecret,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=5
+	// +kubebuilder:validation:Minimum=1
+	// RingHistoryLimit - number of ring versions kept after each rebalance.
+	// Older versions are deleted, except the published one
+	RingHistoryLimit *int32 `json:"ringHistoryLimit,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// RollbackToVersion - ring version to roll back to. As long as it is
+	// set, the rings and builders saved for that version are published and
+	// the rings are not rebalanced. Removing it rebalances the rings again,
+	// starting from the builders of that version
+	RollbackToVersion *int64 `json:"rollbackToVersion,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=3
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=10
+	// BuilderHistoryLimit - number of previous builder files kept in the
+	// swift-ring-builders Secret
+	BuilderHistoryLimit *int32 `json:"builderHistoryLimit,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=false
+	// RecoverBuilders - recreate lost builders from the published rings.
+	// Without it, the rebalance fails if the builder of a published ring is
+	// missing instead of creating a new builder, which would move all
+	// partitions
+	RecoverBuilders bool `json:"recoverBuilders,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default={}
+	// AutoRebalance - rebalance the rings periodically in addition to the
+	// rebalances after device changes
+	AutoRebalance SwiftRingAutoRebalanceSpec `json:"autoRebalance,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default={}
+	// Audit - compare the devices of the published rings periodically with
+	// the devices of the storage pods
+	Audit SwiftRingAuditSpec `json:"audit,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default={}
+	// ReplicaChange - apply a changed replica count of an existing ring in
+	// steps instead of a single rebalance
+	ReplicaChange SwiftRingReplicaChangeSpec `json:"replicaChange,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=false
+	// DryRun - run the rebalance without publishing the rings or storing
+	// the builders. The changes of the rebalance are reported in the
+	// preview of the status
+	DryRun bool `json:"dryRun,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=Auto
+	// +kubebuilder:validation:Enum=Auto;Manual
+	// RingUpdatePolicy - publish the rings after every rebalance (Auto), or
+	// keep the rebalanced rings in the swift-ring-pending Secret until they
+	// are approved by setting the swift.openstack.org/approve-rings
+	// annotation to the value of status.pendingRings (Manual). The initial
+	// rings are always published
+	RingUpdatePolicy string `json:"ringUpdatePolicy,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=Warn
+	// +kubebuilder:validation:Enum=Warn;Enforce
+	// FailureDomainPolicy - report rings with fewer devices or zones than
+	// replicas in the SwiftRingFailureDomains condition (Warn), or also
+	// refuse to rebalance rings with fewer devices than replicas (Enforce)
+	FailureDomainPolicy string `json:"failureDomainPolicy,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// ImagePullSecrets - Secrets used to pull the container images
+	ImagePullSecrets []corev1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// NodeSelector - selects the nodes of the rebalance and verification
+	// Jobs
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Tolerations - tolerations of the rebalance and verification Jobs
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Resources - compute resources of the rebalance and verification Jobs.
+	// Rebalancing rings with a high part power needs a lot of CPU and memory
+	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+}
+
+// SwiftRingParametersSpec defines the parameters per ring
+type SwiftRingParametersSpec struct {
+	// +kubebuilder:validation:Optional
+	// Account - parameters of the account ring
+	Account SwiftRingParameters `json:"account,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Container - parameters of the container ring
+	Container SwiftRingParameters `json:"container,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Object - parameters of the object ring
+	Object SwiftRingParameters `json:"object,omitempty"`
+}
+
+// SwiftRingCompositeSpec defines the component builders of a composite
+// object ring. Each component contains the devices of its regions and has
+// its own replica count, the composite ring has the sum of the replicas.
+// The part power and min_part_hours of the object ring are used for all
+// components
+type SwiftRingCompositeSpec struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=false
+	// Enabled - compose the object ring
+	Enabled bool `json:"enabled"`
+
+	// +kubebuilder:validation:Optional
+	// +listType=map
+	// +listMapKey=name
+	// Components - component builders of the object ring
+	Components []SwiftRingComponent `json:"components,omitempty"`
+}
+
+// SwiftRingComponent defines a component builder of a composite ring
+type SwiftRingComponent struct {
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([a-z0-9-]*[a-z0-9])?$`
+	// Name - name of the component, the builder is object.<name>.builder
+	Name string `json:"name"`
+
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	// Regions - regions of the devices of this component. A region can
+	// only be used by one component
+	Regions []int32 `json:"regions"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=1
+	// +kubebuilder:validation:Minimum=1
+	// Replicas - number of replicas stored in the devices of this component
+	Replicas int32 `json:"replicas"`
+}
+
+// SwiftRingParameters defines the builder parameters of a ring
+type SwiftRingParameters struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=32
+	// PartPower - the ring has 2^partPower partitions. It can't be changed
+	// once the ring is created, except for increasing the part power of an
+	// object ring by one, which relinks the data on all devices
+	PartPower *int32 `json:"partPower,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	// MinPartHours - hours before a partition can be moved again
+	MinPartHours *int32 `json:"minPartHours,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// Overload - overload factor of the ring in percent
+	Overload *int32 `json:"overload,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// Replicas - replica count of the ring. A changed replica count is
+	// applied by the next rebalance, which only moves the partitions
+	// allowed by minPartHours, or in steps with replicaChange
+	Replicas *int64 `json:"replicas,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// DeviceSelector - labels of the devices used by the ring, see
+	// deviceLabels of the SwiftStorage. All devices are used if unset.
+	// Devices no longer matching are not removed from an existing ring
+	DeviceSelector map[string]string `json:"deviceSelector,omitempty"`
+}
+
+// SwiftRingStoragePolicy defines an additional storage policy and the
+// parameters of its ring
+type SwiftRingStoragePolicy struct {
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Minimum=1
+	// Index - index of the policy, its ring is object-<index>
+	Index int32 `json:"index"`
+
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^[A-Za-z0-9-]+$`
+	// Name - name of the policy used by the clients
+	Name string `json:"name"`
+
+	// +kubebuilder:validation:Optional
+	// Default - use the policy for containers created without a policy.
+	// Policy 0 is the default unless another policy is
+	Default bool `json:"default,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Deprecated - no new containers can use the policy, the existing
+	// ones are still served
+	Deprecated bool `json:"deprecated,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Parameters - parameters of the ring, defaulting to partPower,
+	// minPartHours and ringReplicas
+	Parameters SwiftRingParameters `json:"parameters,omitempty"`
+}
+
+// SwiftRingAutoRebalanceSpec defines the scheduled rebalances. A rebalance
+// only moves partitions that were not moved within min_part_hours, the
+// rings are only published if their balance improved
+type SwiftRingAutoRebalanceSpec struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=false
+	// Enabled - rebalance the rings periodically
+	Enabled bool `json:"enabled"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=86400
+	// +kubebuilder:validation:Minimum=3600
+	// IntervalSeconds - time between the rebalances, at least the
+	// min_part_hours of the rings
+	IntervalSeconds int32 `json:"intervalSeconds,omitempty"`
+}
+
+// SwiftRingAuditSpec defines the periodic audit of the ring devices
+type SwiftRingAuditSpec struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=true
+	// Enabled - audit the ring devices periodically
+	Enabled bool `json:"enabled"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=300
+	// +kubebuilder:validation:Minimum=60
+	// IntervalSeconds - time between the audits
+	IntervalSeconds int32 `json:"intervalSeconds,omitempty"`
+}
+
+// SwiftRingReplicaChangeSpec defines the gradual change of the replica
+// count of existing rings. Every step is published by a rebalance, the next
+// step waits until all storage pods finished a replication pass using the
+// rings of the current step
+type SwiftRingReplicaChangeSpec struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=false
+	// Enabled - change the replica count in steps
+	Enabled bool `json:"enabled"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=50
+	// +kubebuilder:validation:Minimum=10
+	// +kubebuilder:validation:Maximum=100
+	// StepPercent - change of the replica count per step, in percent of a
+	// replica
+	StepPercent int32 `json:"stepPercent,omitempty"`
 }
 
 // SwiftRingStatus defines the observed state of SwiftRing
@@ -57,12 +439,211 @@ type SwiftRingStatus struct {
 
 	// Map of hashes to track e.g. job status
 	Hash map[string]string `json:"hash,omitempty"`
+
+	// Rings - balance and dispersion figures per ring after the last
+	// rebalance
+	Rings map[string]SwiftRingStats `json:"rings,omitempty"`
+
+	// Preview - changes and figures per ring of the last dry run or of the
+	// rings pending approval
+	Preview map[string]SwiftRingStats `json:"preview,omitempty"`
+
+	// PendingRings - hash of the rings waiting for approval
+	PendingRings string `json:"pendingRings,omitempty"`
+
+	// LastRebalanceTime - time of the last successful rebalance
+	LastRebalanceTime *metav1.Time `json:"lastRebalanceTime,omitempty"`
+
+	// LastScheduledRebalanceTime - time of the last scheduled rebalance,
+	// also if the rings were unchanged
+	LastScheduledRebalanceTime *metav1.Time `json:"lastScheduledRebalanceTime,omitempty"`
+
+	// ScheduledRebalance - the rebalance Job was started by the schedule
+	// only, its rings are published if the balance improves
+	ScheduledRebalance bool `json:"scheduledRebalance,omitempty"`
+
+	// RingVersion - version of the rings published by the last rebalance
+	RingVersion int64 `json:"ringVersion,omitempty"`
+
+	// ActiveRingVersion - version of the currently published rings, which
+	// differs from RingVersion after a rollback
+	ActiveRingVersion int64 `json:"activeRingVersion,omitempty"`
+
+	// Rollback - the rollback currently applied
+	Rollback *SwiftRingRollbackStatus `json:"rollback,omitempty"`
+
+	// PartPowerIncrease - progress of the partition power increase in
+	// progress
+	PartPowerIncrease *SwiftRingPartPowerIncreaseStatus `json:"partPowerIncrease,omitempty"`
+
+	// ReplicaChanges - progress of the replica count changes in progress
+	// per ring
+	ReplicaChanges map[string]SwiftRingReplicaChangeStatus `json:"replicaChanges,omitempty"`
+
+	// RingDataSize - size of the published swiftrings.tar.gz in bytes
+	RingDataSize int64 `json:"ringDataSize,omitempty"`
+
+	// Verification - result of the verification of the rings restored from
+	// the ring snapshot against the storage devices
+	Verification *SwiftRingVerification `json:"verification,omitempty"`
+
+	// Audit - result of the last audit of the ring devices
+	Audit *SwiftRingAudit `json:"audit,omitempty"`
+}
+
+// SwiftRingRollbackStatus describes a rollback to a saved ring version
+type SwiftRingRollbackStatus struct {
+	// Version the rings were rolled back to
+	Version int64 `json:"version"`
+
+	// Time the rollback was applied
+	Time metav1.Time `json:"time"`
+}
+
+// SwiftRingPartPowerIncreaseStatus describes the progress of the partition
+// power increase of an object ring
+type SwiftRingPartPowerIncreaseStatus struct {
+	// Ring - name of the object ring
+	Ring string `json:"ring"`
+
+	// PartPower - part power of the ring after the increase
+	PartPower int32 `json:"partPower"`
+
+	// Phase - current step of the increase: Prepare, Relink, Increase,
+	// Cleanup or Finish
+	Phase string `json:"phase"`
+
+	// PhaseTime - time the current step started
+	PhaseTime metav1.Time `json:"phaseTime"`
+
+	// Devices - progress of the relinker per storage pod, Relinked or
+	// CleanedUp
+	Devices map[string]string `json:"devices,omitempty"`
+}
+
+// SwiftRingReplicaChangeStatus describes the progress of the gradual replica
+// count change of a ring
+type SwiftRingReplicaChangeStatus struct {
+	// Replicas - replica count of the current step
+	Replicas string `json:"replicas"`
+
+	// TargetReplicas - replica count after the last step
+	TargetReplicas int64 `json:"targetReplicas"`
+
+	// StepTime - time the current step started
+	StepTime metav1.Time `json:"stepTime"`
+
+	// +kubebuilder:validation:Optional
+	// LastCheckTime - time the replication of the storage pods was last
+	// checked for the current step
+	LastCheckTime *metav1.Time `json:"lastCheckTime,omitempty"`
+}
+
+// SwiftRingVerification contains the result of a ring verification
+type SwiftRingVerification struct {
+	// Verified - true if the data on all devices matches the rings
+	Verified bool `json:"verified"`
+
+	// Devices - number of ring devices checked
+	Devices int64 `json:"devices,omitempty"`
+
+	// Mismatches - the first mismatches found, e.g. partitions outside the
+	// ring, or hashes stored in the wrong partition
+	Mismatches []string `json:"mismatches,omitempty"`
+
+	// TotalMismatches - number of all mismatches found
+	TotalMismatches int64 `json:"totalMismatches,omitempty"`
+
+	// Handoffs - the first partitions found on devices the ring does not
+	// assign them to, e.g. written to handoff devices during a failure. They
+	// are moved by the replicators and are no mismatch
+	Handoffs []string `json:"handoffs,omitempty"`
+
+	// TotalHandoffs - number of all partitions found on handoff devices
+	TotalHandoffs int64 `json:"totalHandoffs,omitempty"`
+
+	// LastVerificationTime - time of the last verification
+	LastVerificationTime *metav1.Time `json:"lastVerificationTime,omitempty"`
+}
+
+// SwiftRingAudit contains the result of a ring device audit
+type SwiftRingAudit struct {
+	// Devices - number of ring devices checked
+	Devices int64 `json:"devices,omitempty"`
+
+	// Mismatches - the first mismatches found, e.g. ring devices without a
+	// storage pod, devices of storage pods missing in a ring or ports that
+	// differ from the storage pod
+	Mismatches []string `json:"mismatches,omitempty"`
+
+	// TotalMismatches - number of all mismatches found
+	TotalMismatches int64 `json:"totalMismatches,omitempty"`
+
+	// LastAuditTime - time of the last audit
+	LastAuditTime *metav1.Time `json:"lastAuditTime,omitempty"`
+}
+
+// SwiftRingStats contains the quality figures of a single ring
+type SwiftRingStats struct {
+	// Balance - how far the most unbalanced device is from its desired
+	// number of partitions, in percent
+	Balance string `json:"balance,omitempty"`
+
+	// Dispersion - percentage of partitions with replicas that are not
+	// spread as widely as possible
+	Dispersion string `json:"dispersion,omitempty"`
+
+	// Overload - overload factor of the ring in percent
+	Overload string `json:"overload,omitempty"`
+
+	// RequiredOverload - overload needed to spread the replicas as widely
+	// as possible, in percent. An overload below it trades dispersion for
+	// balance
+	RequiredOverload string `json:"requiredOverload,omitempty"`
+
+	// Partitions - number of partitions of the ring
+	Partitions int64 `json:"partitions,omitempty"`
+
+	// PartPower - part power of the ring
+	PartPower int32 `json:"partPower,omitempty"`
+
+	// MinPartHours - min_part_hours of the ring
+	MinPartHours int32 `json:"minPartHours,omitempty"`
+
+	// PartitionsMovableTime - time the partitions moved by the last
+	// rebalance can be moved again, once min_part_hours passed
+	PartitionsMovableTime *metav1.Time `json:"partitionsMovableTime,omitempty"`
+
+	// Replicas - replica count of the ring
+	Replicas string `json:"replicas,omitempty"`
+
+	// PartitionsReassigned - number of partitions moved by the last
+	// rebalance
+	PartitionsReassigned int64 `json:"partitionsReassigned,omitempty"`
+
+	// Devices - number of devices in the ring
+	Devices int64 `json:"devices,omitempty"`
+
+	// DevicesAdded - number of devices added by the last rebalance
+	DevicesAdded int64 `json:"devicesAdded,omitempty"`
+
+	// DevicesRemoved - number of devices removed by the last rebalance
+	DevicesRemoved int64 `json:"devicesRemoved,omitempty"`
+
+	// WeightsChanged - number of devices whose weight was changed by the
+	// last rebalance
+	WeightsChanged int64 `json:"weightsChanged,omitempty"`
+
+	// BalanceBefore - balance of the ring before the last rebalance
+	BalanceBefore string `json:"balanceBefore,omitempty"`
 }
 
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
 //+kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.conditions[0].status",description="Status"
 //+kubebuilder:printcolumn:name="Message",type="string",JSONPath=".status.conditions[0].message",description="Message"
+//+kubebuilder:printcolumn:name="Balance",type="string",JSONPath=".status.rings.object.balance",description="Object ring balance",priority=1
+//+kubebuilder:printcolumn:name="Dispersion",type="string",JSONPath=".status.rings.object.dispersion",description="Object ring dispersion",priority=1
 
 // SwiftRing is the Schema for the swiftrings API
 type SwiftRing struct {
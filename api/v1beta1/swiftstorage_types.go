@@ -18,28 +18,25 @@ package v1beta1
 
 import (
 	condition "github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+const (
+	// FailedDeviceNone keeps failed devices in the rings
+	FailedDeviceNone = "None"
+	// FailedDeviceZeroWeight sets the weight of failed devices to 0
+	FailedDeviceZeroWeight = "ZeroWeight"
+	// FailedDeviceRemove removes failed devices from the rings
+	FailedDeviceRemove = "Remove"
+)
+
 // EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
 // NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
 
 // SwiftStorageSpec defines the desired state of SwiftStorage
 type SwiftStorageSpec struct {
-	// +kubebuilder:validation:Required
-	// +kubebuilder:default=1
-	// +kubebuilder:validation:Minimum=0
-	Replicas *int32 `json:"replicas"`
-
-	// +kubebuilder:validation:Required
-	// Name of StorageClass to use for Swift PVs
-	// +kubebuilder:default=""
-	StorageClass string `json:"storageClass"`
-
-	// +kubebuilder:validation:Required
-	// +kubebuilder:default="10Gi"
-	// Minimum size for Swift PVs
-	StorageRequest string `json:"storageRequest"`
+	SwiftStorageSpecCore `json:",inline"`
 
 	// +kubebuilder:validation:Required
 	// Image URL for Swift account service
@@ -60,11 +57,714 @@ type SwiftStorageSpec struct {
 	// +kubebuilder:validation:Required
 	// Image URL for Memcache servicd
 	ContainerImageMemcached string `json:"containerImageMemcached"`
+}
+
+// SwiftStorageSpecCore defines the desired state of SwiftStorage without the
+// container images, which are set by the OpenStackControlPlane
+type SwiftStorageSpecCore struct {
+	// +kubebuilder:validation:Required
+	// +kubebuilder:default=1
+	// +kubebuilder:validation:Minimum=0
+	Replicas *int32 `json:"replicas"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	// OrdinalStart - ordinal of the first storage pod. The pod names are
+	// used as hostnames in the rings, increasing it when recreating the
+	// StatefulSet with new volumes ensures that no ring device is reused
+	// for a different volume. Requires the StatefulSetStartOrdinal feature
+	OrdinalStart *int32 `json:"ordinalStart,omitempty"`
+
+	// +kubebuilder:validation:Required
+	// Name of StorageClass to use for Swift PVs
+	// +kubebuilder:default=""
+	StorageClass string `json:"storageClass"`
+
+	// +kubebuilder:validation:Required
+	// +kubebuilder:default="10Gi"
+	// Minimum size for Swift PVs
+	StorageRequest string `json:"storageRequest"`
 
 	// +kubebuilder:validation:Required
 	// +kubebuilder:default=swift-conf
 	// Name of Secret containing swift.conf
 	SwiftConfSecret string `json:"swiftConfSecret"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=ConfigMap
+	// +kubebuilder:validation:Enum=ConfigMap;Secret
+	// RingDistribution - kind of the swift-ring-files object the rings are
+	// mounted from, see SwiftRing
+	RingDistribution string `json:"ringDistribution,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// MemcachedInstance - name of a shared Memcached instance in the same
+	// namespace. If set, it is used instead of the memcached sidecar
+	MemcachedInstance string `json:"memcachedInstance,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// CABundleSecretName - name of a Secret with the CA bundle in the
+	// tls-ca-bundle.pem key, used to verify TLS connections to the shared
+	// Memcached instance. The system trust store is used if not set
+	CABundleSecretName string `json:"caBundleSecretName,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// LogForwarding - optional sidecar forwarding the Swift service logs
+	LogForwarding LogForwardingSpec `json:"logForwarding,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// NetworkAttachments is a list of NetworkAttachment resource names to
+	// attach the storage pods to. The first one is used for the storage
+	// traffic, ie. its IPs are used for the ring devices and rsync
+	NetworkAttachments []string `json:"networkAttachments,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=SingleStack;PreferDualStack;RequireDualStack
+	// IPFamilyPolicy of the headless Service, eg. PreferDualStack on dual-stack clusters
+	IPFamilyPolicy *corev1.IPFamilyPolicy `json:"ipFamilyPolicy,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxItems=2
+	// IPFamilies of the Service. If the first family is IPv6, the storage
+	// services bind to IPv6 addresses
+	IPFamilies []corev1.IPFamily `json:"ipFamilies,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=Preferred
+	// +kubebuilder:validation:Enum=Preferred;Required;None
+	// AntiAffinity - spread the storage pods across the nodes, so that a
+	// failing node does not affect more than one replica. Required keeps
+	// pods pending if there are fewer nodes than replicas
+	AntiAffinity string `json:"antiAffinity,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Affinity - replaces the affinity generated from antiAffinity
+	Affinity *corev1.Affinity `json:"affinity,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default={}
+	// Ports - ports of the storage services
+	Ports SwiftStoragePorts `json:"ports,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// ReplicationServers - run dedicated account, container and object
+	// servers for the replication traffic on the replication ports. These
+	// are used as replication ports in the rings, thus client requests and
+	// replication don't share the same workers
+	ReplicationServers bool `json:"replicationServers,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// AccountServer - tuning options for the account servers
+	AccountServer SwiftServerTuning `json:"accountServer,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// ContainerServer - tuning options for the container servers
+	ContainerServer SwiftServerTuning `json:"containerServer,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// AllowVersions - allow the legacy X-Versions-Location versioning on
+	// the container servers. Required by versionedWrites.allowVersionedWrites
+	// of the SwiftProxy
+	AllowVersions bool `json:"allowVersions,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// ObjectServer - tuning options for the object servers
+	ObjectServer SwiftObjectServerTuning `json:"objectServer,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default="/srv/node"
+	// +kubebuilder:validation:Pattern=`^/.+`
+	// DevicesRoot - parent directory of all devices
+	DevicesRoot string `json:"devicesRoot,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// MountCheck - refuse to use devices that are not mounted, instead of
+	// writing into the parent filesystem. Defaults to false for PVCs, as
+	// these are always mounted by the kubelet
+	MountCheck *bool `json:"mountCheck,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Pattern=`^[0-9]+(\.[0-9]+)?%?$`
+	// FallocateReserve - free space to keep on every device, either in
+	// bytes or as percentage, eg. "2%". Writes are rejected once reached
+	FallocateReserve string `json:"fallocateReserve,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// DBPreallocation - preallocate disk space for new account and
+	// container databases
+	DBPreallocation *bool `json:"dbPreallocation,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// IONice - I/O scheduling class and priority of the background daemons,
+	// eg. replicators, auditors and updaters
+	IONice SwiftIONiceSpec `json:"ionice,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// AccountReaper - tuning options of the account reaper
+	AccountReaper SwiftAccountReaperSpec `json:"accountReaper,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// AccountReplicator - tuning options of the account replicator
+	AccountReplicator SwiftReplicatorSpec `json:"accountReplicator,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// ContainerReplicator - tuning options of the container replicator
+	ContainerReplicator SwiftReplicatorSpec `json:"containerReplicator,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// ObjectReplicator - tuning options of the object replicator
+	ObjectReplicator SwiftObjectReplicatorSpec `json:"objectReplicator,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Auditors - rate limits of the account, container and object auditors
+	Auditors SwiftAuditorsSpec `json:"auditors,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// ContainerUpdater - tuning options of the container updater
+	ContainerUpdater SwiftUpdaterSpec `json:"containerUpdater,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// ObjectUpdater - tuning options of the object updater, which processes
+	// the async pending container updates
+	ObjectUpdater SwiftObjectUpdaterSpec `json:"objectUpdater,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// ObjectExpirer - parallelism of the object expirers
+	ObjectExpirer SwiftObjectExpirerSpec `json:"objectExpirer,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default={}
+	// RingTopology - derive the ring regions and zones of the devices from
+	// the topology labels of the nodes running the storage pods
+	RingTopology SwiftStorageRingTopologySpec `json:"ringTopology,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// DeviceWeights - ring weight per storage pod, eg. swift-storage-0,
+	// overriding the weight derived from the PVC size. A weight of 0 drains
+	// the device
+	DeviceWeights map[string]int32 `json:"deviceWeights,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// DeviceLabels - labels of the devices of this SwiftStorage, eg.
+	// media: ssd. The rings only use the devices matching their
+	// deviceSelector, thus several SwiftStorage instances can back
+	// different storage policies
+	DeviceLabels map[string]string `json:"deviceLabels,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default={}
+	// FailedDevices - handling of the devices whose PVC or PersistentVolume
+	// is permanently lost, e.g. after the node of a local volume was removed
+	FailedDevices SwiftStorageFailedDevicesSpec `json:"failedDevices,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// RestoreClaims - existing volumes to use for the given StatefulSet
+	// ordinals, eg. when restoring from backed up PVs
+	RestoreClaims []SwiftStorageRestoreClaim `json:"restoreClaims,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=10
+	// +kubebuilder:validation:Minimum=0
+	// RevisionHistoryLimit - number of StatefulSet revisions kept, together
+	// with the configuration used by them, for rollbacks
+	RevisionHistoryLimit *int32 `json:"revisionHistoryLimit,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// RollbackToRevision - StatefulSet revision to roll back to. As long as
+	// it is set, the pod template and the configuration of that revision are
+	// used instead of the ones generated from this spec
+	RollbackToRevision *int64 `json:"rollbackToRevision,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// ExtraMetadata - additional labels and annotations of the generated
+	// resources, e.g. for backup tooling or cost allocation. Labels and
+	// annotations managed by the operator take precedence
+	ExtraMetadata SwiftStorageExtraMetadata `json:"extraMetadata,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// ImagePullSecrets - Secrets used to pull the container images
+	ImagePullSecrets []corev1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// PriorityClassName - PriorityClass of the storage pods. The serving and
+	// background daemons run in the same pod and share the class
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=120
+	// +kubebuilder:validation:Minimum=0
+	// TerminationGracePeriodSeconds - time given to the storage pods to shut
+	// down. The servers finish the requests in flight and the replicators
+	// their current rsync transfers before the containers are killed
+	TerminationGracePeriodSeconds *int64 `json:"terminationGracePeriodSeconds,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Alerts - thresholds used for the SwiftStorageAlerts condition and the
+	// generated PrometheusRule
+	Alerts SwiftStorageAlerts `json:"alerts,omitempty"`
+}
+
+// SwiftStoragePorts defines the ports used by the storage services. These
+// are also used in the rings
+type SwiftStoragePorts struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=6202
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// AccountServer - port of the account server
+	AccountServer int32 `json:"accountServer"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=6201
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// ContainerServer - port of the container server
+	ContainerServer int32 `json:"containerServer"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=6200
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// ObjectServer - port of the object server
+	ObjectServer int32 `json:"objectServer"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=873
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// Rsync - port of the rsync daemon used for replication
+	Rsync int32 `json:"rsync"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=11211
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// Memcached - port of the memcached sidecar
+	Memcached int32 `json:"memcached"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=6212
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// AccountReplication - port of the account replication server
+	AccountReplication int32 `json:"accountReplication"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=6211
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// ContainerReplication - port of the container replication server
+	ContainerReplication int32 `json:"containerReplication"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=6210
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// ObjectReplication - port of the object replication server
+	ObjectReplication int32 `json:"objectReplication"`
+}
+
+// SwiftServerTuning defines the number of worker processes and concurrent
+// clients of a Swift storage server. Unset values use the Swift defaults
+type SwiftServerTuning struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	// Workers - number of pre-forked worker processes, 0 disables forking
+	Workers *int32 `json:"workers,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// MaxClients - maximum number of clients one worker can process
+	// simultaneously
+	MaxClients *int32 `json:"maxClients,omitempty"`
+}
+
+// SwiftObjectServerTuning defines the tuning options of the object servers
+type SwiftObjectServerTuning struct {
+	SwiftServerTuning `json:",inline"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	// ServersPerPort - number of object server workers per unique port in
+	// the ring. If set to a value greater than 0, Workers is ignored
+	ServersPerPort *int32 `json:"serversPerPort,omitempty"`
+}
+
+// SwiftStorageRestoreClaim maps an existing PersistentVolume to the PVC of
+// a StatefulSet ordinal. Exactly one of ClaimName, VolumeName and Selector
+// must be set
+type SwiftStorageRestoreClaim struct {
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Minimum=0
+	// Ordinal - StatefulSet ordinal that should use the volume
+	Ordinal int32 `json:"ordinal"`
+
+	// +kubebuilder:validation:Optional
+	// ClaimName - name of the PVC in the same namespace the volume was
+	// bound to before. The volume is adopted once this PVC is deleted
+	ClaimName string `json:"claimName,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// VolumeName - name of the PersistentVolume
+	VolumeName string `json:"volumeName,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Selector - labels of the PersistentVolume
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+}
+
+// SwiftIONiceSpec defines the I/O scheduling of Swift daemons. Unset values
+// keep the scheduling unchanged
+type SwiftIONiceSpec struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=IOPRIO_CLASS_RT;IOPRIO_CLASS_BE;IOPRIO_CLASS_IDLE
+	// Class - I/O scheduling class
+	Class string `json:"class,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=7
+	// Priority - I/O priority within the class, 0 is the highest priority
+	Priority *int32 `json:"priority,omitempty"`
+}
+
+// SwiftAccountReaperSpec defines how the account reaper removes the data of
+// deleted accounts. Unset values use the Swift defaults
+type SwiftAccountReaperSpec struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	// DelayReaping - seconds to wait before the data of a deleted account
+	// is removed, e.g. 604800 to be able to undelete accounts for a week
+	DelayReaping *int32 `json:"delayReaping,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// Concurrency - number of parallel deletes per account
+	Concurrency *int32 `json:"concurrency,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// Interval - seconds between two reaper passes
+	Interval *int32 `json:"interval,omitempty"`
+}
+
+// SwiftReplicatorSpec defines the concurrency and timeouts of a replicator.
+// Unset values use the Swift defaults
+type SwiftReplicatorSpec struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// Concurrency - number of parallel replication jobs
+	Concurrency *int32 `json:"concurrency,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// Interval - minimum seconds between two replication passes, also
+	// known as run_pause
+	Interval *int32 `json:"interval,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// NodeTimeout - seconds to wait for a response of another node
+	NodeTimeout *int32 `json:"nodeTimeout,omitempty"`
+}
+
+// SwiftObjectReplicatorSpec defines the concurrency and timeouts of the
+// object replicator. Unset values use the Swift defaults
+type SwiftObjectReplicatorSpec struct {
+	SwiftReplicatorSpec `json:",inline"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// RsyncTimeout - maximum seconds a single rsync of a partition may take
+	RsyncTimeout *int32 `json:"rsyncTimeout,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// RsyncIOTimeout - seconds without any I/O after which an rsync is
+	// aborted
+	RsyncIOTimeout *int32 `json:"rsyncIOTimeout,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// HandoffsFirst - replicate the handoff partitions before the primary
+	// partitions, e.g. to move the data back to the primaries faster when
+	// recovering from a rebalance
+	HandoffsFirst bool `json:"handoffsFirst,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// HandoffDelete - number of replicas that must be stored successfully
+	// before a handoff partition is deleted. Unset waits for all replicas
+	HandoffDelete *int32 `json:"handoffDelete,omitempty"`
+}
+
+// SwiftAuditorsSpec defines the rate limits of the auditors, so that
+// auditing does not saturate the disk I/O. Unset values use the Swift
+// defaults
+type SwiftAuditorsSpec struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// AccountsPerSecond - maximum number of account databases audited per
+	// second
+	AccountsPerSecond *int32 `json:"accountsPerSecond,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// ContainersPerSecond - maximum number of container databases audited
+	// per second
+	ContainersPerSecond *int32 `json:"containersPerSecond,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// FilesPerSecond - maximum number of objects audited per second
+	FilesPerSecond *int32 `json:"filesPerSecond,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// BytesPerSecond - maximum number of object bytes audited per second
+	BytesPerSecond *int32 `json:"bytesPerSecond,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// ZeroByteFilesPerSecond - maximum number of objects audited per second
+	// by the zero byte files auditor, which only checks the metadata
+	ZeroByteFilesPerSecond *int32 `json:"zeroByteFilesPerSecond,omitempty"`
+}
+
+// SwiftUpdaterSpec defines how fast an updater sends the updates to the
+// container servers. Unset values use the Swift defaults
+type SwiftUpdaterSpec struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// Interval - minimum seconds between two updater passes
+	Interval *int32 `json:"interval,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// Concurrency - number of parallel updates per device
+	Concurrency *int32 `json:"concurrency,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Pattern=`^[0-9]+(\.[0-9]+)?$`
+	// Slowdown - seconds to sleep between two updates, e.g. "0.01"
+	Slowdown string `json:"slowdown,omitempty"`
+}
+
+// SwiftObjectUpdaterSpec defines how the object updater processes the async
+// pending updates. Unset values use the Swift defaults
+type SwiftObjectUpdaterSpec struct {
+	SwiftUpdaterSpec `json:",inline"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// UpdaterWorkers - number of worker processes, each processing a
+	// subset of the devices
+	UpdaterWorkers *int32 `json:"updaterWorkers,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// ObjectsPerSecond - maximum number of async pending updates processed
+	// per second and worker. Replaces Slowdown in recent Swift releases
+	ObjectsPerSecond *int32 `json:"objectsPerSecond,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// MaxObjectsPerContainerPerSecond - maximum number of updates sent to a
+	// single container per second, further updates are deferred
+	MaxObjectsPerContainerPerSecond *int32 `json:"maxObjectsPerContainerPerSecond,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	// MaxDeferredUpdates - maximum number of deferred updates kept in memory
+	// and retried at the end of a pass
+	MaxDeferredUpdates *int32 `json:"maxDeferredUpdates,omitempty"`
+}
+
+// SwiftObjectExpirerSpec defines how the object expirers of the storage pods
+// process the expiration queue. Unset values use the Swift defaults
+type SwiftObjectExpirerSpec struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	// Processes - number of expirers splitting the expiration queue. The
+	// storage pods with the first ordinals each process their part of the
+	// queue, the remaining pods don't run an expirer. 0 lets every expirer
+	// process the whole queue
+	Processes *int32 `json:"processes,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// Concurrency - number of parallel deletes per expirer
+	Concurrency *int32 `json:"concurrency,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// ReportInterval - seconds between two progress reports in the log
+	ReportInterval *int32 `json:"reportInterval,omitempty"`
+}
+
+// SwiftStorageAlerts defines the alert thresholds of a SwiftStorage
+// instance. Thresholds are looked up per storage policy first, then per
+// service and finally the defaults are used
+type SwiftStorageAlerts struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=false
+	// Enabled - periodically check the recon data of all storage pods
+	// against the thresholds and export them as metrics
+	Enabled bool `json:"enabled"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=false
+	// PrometheusRule - create a PrometheusRule using the thresholds. Requires
+	// the Prometheus operator CRDs to be installed
+	PrometheusRule bool `json:"prometheusRule"`
+
+	// +kubebuilder:validation:Optional
+	// Default thresholds
+	SwiftAlertThresholds `json:",inline"`
+
+	// +kubebuilder:validation:Optional
+	// Account - thresholds for the account service
+	Account *SwiftAlertThresholds `json:"account,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Container - thresholds for the container service
+	Container *SwiftAlertThresholds `json:"container,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Object - thresholds for the object service
+	Object *SwiftAlertThresholds `json:"object,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Policies - thresholds for the object service per storage policy
+	// index, eg. "0"
+	Policies map[string]SwiftAlertThresholds `json:"policies,omitempty"`
+}
+
+// SwiftAlertThresholds defines the values that raise an alert when
+// exceeded. Unset values are inherited
+type SwiftAlertThresholds struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// DiskUsagePercent - maximum usage of a device in percent
+	DiskUsagePercent *int32 `json:"diskUsagePercent,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	// AsyncPending - maximum number of async pending container updates
+	AsyncPending *int32 `json:"asyncPending,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// ReplicationAgeSeconds - maximum time since the last completed
+	// replication pass
+	ReplicationAgeSeconds *int32 `json:"replicationAgeSeconds,omitempty"`
+}
+
+// SwiftStorageRingTopologySpec defines how the ring regions and zones are
+// derived from the node labels. Nodes without the label use region 1 or zone
+// 1. The device list is only published once all pods are scheduled
+type SwiftStorageRingTopologySpec struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=false
+	// Enabled - use the node labels instead of a single region and zone
+	Enabled bool `json:"enabled"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default="topology.kubernetes.io/region"
+	// RegionLabel - node label with the region
+	RegionLabel string `json:"regionLabel,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default="topology.kubernetes.io/zone"
+	// ZoneLabel - node label with the zone
+	ZoneLabel string `json:"zoneLabel,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Regions - ring region per value of the region label. Numeric label
+	// values are used as region if they are not mapped
+	Regions map[string]int32 `json:"regions,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Zones - ring zone per value of the zone label. Numeric label values
+	// are used as zone if they are not mapped
+	Zones map[string]int32 `json:"zones,omitempty"`
+}
+
+// SwiftStorageFailedDevicesSpec defines what happens to the ring device of a
+// storage pod whose volume is lost. A missing PVC is not a failure, the
+// StatefulSet creates it again
+type SwiftStorageFailedDevicesSpec struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=None
+	// +kubebuilder:validation:Enum=None;ZeroWeight;Remove
+	// Action - keep the device (None), set its weight to 0 (ZeroWeight) or
+	// remove it from the rings (Remove) once the grace period expired. The
+	// replicas of its partitions are recreated on the other devices
+	Action string `json:"action,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=3600
+	// +kubebuilder:validation:Minimum=0
+	// GracePeriodSeconds - time a device has to be failed before the action
+	// is applied
+	GracePeriodSeconds int32 `json:"gracePeriodSeconds,omitempty"`
+}
+
+// SwiftStorageFailedDevice describes the failed device of a storage pod
+type SwiftStorageFailedDevice struct {
+	// Reason - why the device is considered failed
+	Reason string `json:"reason"`
+
+	// Since - time the failure was detected
+	Since metav1.Time `json:"since"`
+
+	// Host - ring host of the device when the failure was detected
+	Host string `json:"host,omitempty"`
+
+	// Action - action applied to the device, empty during the grace period
+	Action string `json:"action,omitempty"`
+}
+
+// SwiftStorageExtraMetadata defines additional metadata per resource type
+type SwiftStorageExtraMetadata struct {
+	// +kubebuilder:validation:Optional
+	// StatefulSet - metadata of the StatefulSet
+	StatefulSet SwiftMetadata `json:"statefulSet,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Pod - metadata of the storage pods
+	Pod SwiftMetadata `json:"pod,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Service - metadata of the headless Service
+	Service SwiftMetadata `json:"service,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// PersistentVolumeClaim - metadata of the storage PVCs. It is added to
+	// existing PVCs, but never removed from them
+	PersistentVolumeClaim SwiftMetadata `json:"persistentVolumeClaim,omitempty"`
+}
+
+// SwiftMetadata defines additional labels and annotations of a resource
+type SwiftMetadata struct {
+	// +kubebuilder:validation:Optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// SwiftStorageRollbackStatus describes a rollback to a StatefulSet revision
+type SwiftStorageRollbackStatus struct {
+	// Revision number the StatefulSet was rolled back to
+	Revision int64 `json:"revision"`
+
+	// ControllerRevision - name of the ControllerRevision of the revision
+	ControllerRevision string `json:"controllerRevision"`
+
+	// Time the rollback was applied
+	Time metav1.Time `json:"time"`
 }
 
 // SwiftStorageStatus defines the observed state of SwiftStorage
@@ -72,6 +772,18 @@ type SwiftStorageStatus struct {
 	// ReadyCount of SwiftStorage instances
 	ReadyCount int32 `json:"readyCount,omitempty"`
 
+	// NetworkAttachments status of the storage pods
+	NetworkAttachments map[string][]string `json:"networkAttachments,omitempty"`
+
+	// UpdateRevision - StatefulSet revision of the current pod template
+	UpdateRevision string `json:"updateRevision,omitempty"`
+
+	// Rollback - the rollback currently applied
+	Rollback *SwiftStorageRollbackStatus `json:"rollback,omitempty"`
+
+	// FailedDevices - devices with a lost volume, per storage pod
+	FailedDevices map[string]SwiftStorageFailedDevice `json:"failedDevices,omitempty"`
+
 	// Conditions
 	Conditions condition.Conditions `json:"conditions,omitempty" optional:"true"`
 }
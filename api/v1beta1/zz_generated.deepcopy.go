@@ -24,9 +24,27 @@ package v1beta1
 import (
 	"github.com/openstack-k8s-operators/lib-common/modules/common/condition"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/service"
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LogForwardingSpec) DeepCopyInto(out *LogForwardingSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogForwardingSpec.
+func (in *LogForwardingSpec) DeepCopy() *LogForwardingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(LogForwardingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PasswordSelector) DeepCopyInto(out *PasswordSelector) {
 	*out = *in
@@ -92,355 +110,2247 @@ func (in *Swift) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SwiftDefaults) DeepCopyInto(out *SwiftDefaults) {
+func (in *SwiftAccountReaperSpec) DeepCopyInto(out *SwiftAccountReaperSpec) {
 	*out = *in
+	if in.DelayReaping != nil {
+		in, out := &in.DelayReaping, &out.DelayReaping
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Concurrency != nil {
+		in, out := &in.Concurrency, &out.Concurrency
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(int32)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwiftDefaults.
-func (in *SwiftDefaults) DeepCopy() *SwiftDefaults {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwiftAccountReaperSpec.
+func (in *SwiftAccountReaperSpec) DeepCopy() *SwiftAccountReaperSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(SwiftDefaults)
+	out := new(SwiftAccountReaperSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SwiftList) DeepCopyInto(out *SwiftList) {
+func (in *SwiftAlertThresholds) DeepCopyInto(out *SwiftAlertThresholds) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]Swift, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.DiskUsagePercent != nil {
+		in, out := &in.DiskUsagePercent, &out.DiskUsagePercent
+		*out = new(int32)
+		**out = **in
+	}
+	if in.AsyncPending != nil {
+		in, out := &in.AsyncPending, &out.AsyncPending
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ReplicationAgeSeconds != nil {
+		in, out := &in.ReplicationAgeSeconds, &out.ReplicationAgeSeconds
+		*out = new(int32)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwiftList.
-func (in *SwiftList) DeepCopy() *SwiftList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwiftAlertThresholds.
+func (in *SwiftAlertThresholds) DeepCopy() *SwiftAlertThresholds {
 	if in == nil {
 		return nil
 	}
-	out := new(SwiftList)
+	out := new(SwiftAlertThresholds)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *SwiftList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SwiftProxy) DeepCopyInto(out *SwiftProxy) {
+func (in *SwiftAuditorsSpec) DeepCopyInto(out *SwiftAuditorsSpec) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
+	if in.AccountsPerSecond != nil {
+		in, out := &in.AccountsPerSecond, &out.AccountsPerSecond
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ContainersPerSecond != nil {
+		in, out := &in.ContainersPerSecond, &out.ContainersPerSecond
+		*out = new(int32)
+		**out = **in
+	}
+	if in.FilesPerSecond != nil {
+		in, out := &in.FilesPerSecond, &out.FilesPerSecond
+		*out = new(int32)
+		**out = **in
+	}
+	if in.BytesPerSecond != nil {
+		in, out := &in.BytesPerSecond, &out.BytesPerSecond
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ZeroByteFilesPerSecond != nil {
+		in, out := &in.ZeroByteFilesPerSecond, &out.ZeroByteFilesPerSecond
+		*out = new(int32)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwiftProxy.
-func (in *SwiftProxy) DeepCopy() *SwiftProxy {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwiftAuditorsSpec.
+func (in *SwiftAuditorsSpec) DeepCopy() *SwiftAuditorsSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(SwiftProxy)
+	out := new(SwiftAuditorsSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *SwiftProxy) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SwiftProxyList) DeepCopyInto(out *SwiftProxyList) {
+func (in *SwiftBulkSpec) DeepCopyInto(out *SwiftBulkSpec) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]SwiftProxy, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.MaxContainersPerExtraction != nil {
+		in, out := &in.MaxContainersPerExtraction, &out.MaxContainersPerExtraction
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxFailedExtractions != nil {
+		in, out := &in.MaxFailedExtractions, &out.MaxFailedExtractions
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxDeletesPerRequest != nil {
+		in, out := &in.MaxDeletesPerRequest, &out.MaxDeletesPerRequest
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxFailedDeletes != nil {
+		in, out := &in.MaxFailedDeletes, &out.MaxFailedDeletes
+		*out = new(int32)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwiftProxyList.
-func (in *SwiftProxyList) DeepCopy() *SwiftProxyList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwiftBulkSpec.
+func (in *SwiftBulkSpec) DeepCopy() *SwiftBulkSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(SwiftProxyList)
+	out := new(SwiftBulkSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *SwiftProxyList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SwiftProxySpec) DeepCopyInto(out *SwiftProxySpec) {
+func (in *SwiftCORSSpec) DeepCopyInto(out *SwiftCORSSpec) {
 	*out = *in
-	if in.Replicas != nil {
-		in, out := &in.Replicas, &out.Replicas
-		*out = new(int32)
-		**out = **in
+	if in.AllowOrigin != nil {
+		in, out := &in.AllowOrigin, &out.AllowOrigin
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExposeHeaders != nil {
+		in, out := &in.ExposeHeaders, &out.ExposeHeaders
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	out.PasswordSelectors = in.PasswordSelectors
-	in.Override.DeepCopyInto(&out.Override)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwiftProxySpec.
-func (in *SwiftProxySpec) DeepCopy() *SwiftProxySpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwiftCORSSpec.
+func (in *SwiftCORSSpec) DeepCopy() *SwiftCORSSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(SwiftProxySpec)
+	out := new(SwiftCORSSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SwiftProxyStatus) DeepCopyInto(out *SwiftProxyStatus) {
+func (in *SwiftCeilometerSpec) DeepCopyInto(out *SwiftCeilometerSpec) {
 	*out = *in
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make(condition.Conditions, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.IgnoreProjects != nil {
+		in, out := &in.IgnoreProjects, &out.IgnoreProjects
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwiftProxyStatus.
-func (in *SwiftProxyStatus) DeepCopy() *SwiftProxyStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwiftCeilometerSpec.
+func (in *SwiftCeilometerSpec) DeepCopy() *SwiftCeilometerSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(SwiftProxyStatus)
+	out := new(SwiftCeilometerSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SwiftRing) DeepCopyInto(out *SwiftRing) {
+func (in *SwiftDefaults) DeepCopyInto(out *SwiftDefaults) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwiftRing.
-func (in *SwiftRing) DeepCopy() *SwiftRing {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwiftDefaults.
+func (in *SwiftDefaults) DeepCopy() *SwiftDefaults {
 	if in == nil {
 		return nil
 	}
-	out := new(SwiftRing)
+	out := new(SwiftDefaults)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *SwiftRing) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SwiftRingList) DeepCopyInto(out *SwiftRingList) {
+func (in *SwiftDispersionResult) DeepCopyInto(out *SwiftDispersionResult) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]SwiftRing, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
+	if in.Missing != nil {
+		in, out := &in.Missing, &out.Missing
+		*out = make(map[string]int64, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwiftRingList.
-func (in *SwiftRingList) DeepCopy() *SwiftRingList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwiftDispersionResult.
+func (in *SwiftDispersionResult) DeepCopy() *SwiftDispersionResult {
 	if in == nil {
 		return nil
 	}
-	out := new(SwiftRingList)
+	out := new(SwiftDispersionResult)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *SwiftRingList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SwiftRingSpec) DeepCopyInto(out *SwiftRingSpec) {
+func (in *SwiftDispersionSpec) DeepCopyInto(out *SwiftDispersionSpec) {
 	*out = *in
-	if in.RingReplicas != nil {
-		in, out := &in.RingReplicas, &out.RingReplicas
-		*out = new(int64)
-		**out = **in
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwiftRingSpec.
-func (in *SwiftRingSpec) DeepCopy() *SwiftRingSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwiftDispersionSpec.
+func (in *SwiftDispersionSpec) DeepCopy() *SwiftDispersionSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(SwiftRingSpec)
+	out := new(SwiftDispersionSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SwiftRingStatus) DeepCopyInto(out *SwiftRingStatus) {
+func (in *SwiftDispersionStatus) DeepCopyInto(out *SwiftDispersionStatus) {
 	*out = *in
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make(condition.Conditions, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.Object != nil {
+		in, out := &in.Object, &out.Object
+		*out = new(SwiftDispersionResult)
+		(*in).DeepCopyInto(*out)
 	}
-	if in.Hash != nil {
-		in, out := &in.Hash, &out.Hash
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
+	if in.Container != nil {
+		in, out := &in.Container, &out.Container
+		*out = new(SwiftDispersionResult)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LastReportTime != nil {
+		in, out := &in.LastReportTime, &out.LastReportTime
+		*out = (*in).DeepCopy()
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwiftRingStatus.
-func (in *SwiftRingStatus) DeepCopy() *SwiftRingStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwiftDispersionStatus.
+func (in *SwiftDispersionStatus) DeepCopy() *SwiftDispersionStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(SwiftRingStatus)
+	out := new(SwiftDispersionStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SwiftSpec) DeepCopyInto(out *SwiftSpec) {
+func (in *SwiftDomainRemapSpec) DeepCopyInto(out *SwiftDomainRemapSpec) {
 	*out = *in
-	in.SwiftRing.DeepCopyInto(&out.SwiftRing)
-	in.SwiftStorage.DeepCopyInto(&out.SwiftStorage)
-	in.SwiftProxy.DeepCopyInto(&out.SwiftProxy)
+	if in.StorageDomains != nil {
+		in, out := &in.StorageDomains, &out.StorageDomains
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LookupDepth != nil {
+		in, out := &in.LookupDepth, &out.LookupDepth
+		*out = new(int32)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwiftSpec.
-func (in *SwiftSpec) DeepCopy() *SwiftSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwiftDomainRemapSpec.
+func (in *SwiftDomainRemapSpec) DeepCopy() *SwiftDomainRemapSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(SwiftSpec)
+	out := new(SwiftDomainRemapSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SwiftStatus) DeepCopyInto(out *SwiftStatus) {
+func (in *SwiftEncryptionRootSecret) DeepCopyInto(out *SwiftEncryptionRootSecret) {
 	*out = *in
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make(condition.Conditions, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwiftStatus.
-func (in *SwiftStatus) DeepCopy() *SwiftStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwiftEncryptionRootSecret.
+func (in *SwiftEncryptionRootSecret) DeepCopy() *SwiftEncryptionRootSecret {
 	if in == nil {
 		return nil
 	}
-	out := new(SwiftStatus)
+	out := new(SwiftEncryptionRootSecret)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SwiftStorage) DeepCopyInto(out *SwiftStorage) {
+func (in *SwiftEncryptionSpec) DeepCopyInto(out *SwiftEncryptionSpec) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
+	if in.RootSecrets != nil {
+		in, out := &in.RootSecrets, &out.RootSecrets
+		*out = make([]SwiftEncryptionRootSecret, len(*in))
+		copy(*out, *in)
+	}
+	out.KMIP = in.KMIP
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwiftStorage.
-func (in *SwiftStorage) DeepCopy() *SwiftStorage {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwiftEncryptionSpec.
+func (in *SwiftEncryptionSpec) DeepCopy() *SwiftEncryptionSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(SwiftStorage)
+	out := new(SwiftEncryptionSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *SwiftStorage) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SwiftStorageList) DeepCopyInto(out *SwiftStorageList) {
+func (in *SwiftErrorPage) DeepCopyInto(out *SwiftErrorPage) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]SwiftStorage, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwiftStorageList.
-func (in *SwiftStorageList) DeepCopy() *SwiftStorageList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwiftErrorPage.
+func (in *SwiftErrorPage) DeepCopy() *SwiftErrorPage {
 	if in == nil {
 		return nil
 	}
-	out := new(SwiftStorageList)
+	out := new(SwiftErrorPage)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SwiftForwardedHeadersSpec) DeepCopyInto(out *SwiftForwardedHeadersSpec) {
+	*out = *in
+	if in.TrustedCIDRs != nil {
+		in, out := &in.TrustedCIDRs, &out.TrustedCIDRs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwiftForwardedHeadersSpec.
+func (in *SwiftForwardedHeadersSpec) DeepCopy() *SwiftForwardedHeadersSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SwiftForwardedHeadersSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SwiftIONiceSpec) DeepCopyInto(out *SwiftIONiceSpec) {
+	*out = *in
+	if in.Priority != nil {
+		in, out := &in.Priority, &out.Priority
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwiftIONiceSpec.
+func (in *SwiftIONiceSpec) DeepCopy() *SwiftIONiceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SwiftIONiceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SwiftInternalProxySpec) DeepCopyInto(out *SwiftInternalProxySpec) {
+	*out = *in
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.KeystoneAuth != nil {
+		in, out := &in.KeystoneAuth, &out.KeystoneAuth
+		*out = new(SwiftKeystoneAuthSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwiftInternalProxySpec.
+func (in *SwiftInternalProxySpec) DeepCopy() *SwiftInternalProxySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SwiftInternalProxySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SwiftIssuerReference) DeepCopyInto(out *SwiftIssuerReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwiftIssuerReference.
+func (in *SwiftIssuerReference) DeepCopy() *SwiftIssuerReference {
+	if in == nil {
+		return nil
+	}
+	out := new(SwiftIssuerReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SwiftKMIPSpec) DeepCopyInto(out *SwiftKMIPSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwiftKMIPSpec.
+func (in *SwiftKMIPSpec) DeepCopy() *SwiftKMIPSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SwiftKMIPSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SwiftKeystoneAuthSpec) DeepCopyInto(out *SwiftKeystoneAuthSpec) {
+	*out = *in
+	if in.OperatorRoles != nil {
+		in, out := &in.OperatorRoles, &out.OperatorRoles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ServiceRoles != nil {
+		in, out := &in.ServiceRoles, &out.ServiceRoles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ResellerPrefixes != nil {
+		in, out := &in.ResellerPrefixes, &out.ResellerPrefixes
+		*out = make([]SwiftResellerPrefix, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwiftKeystoneAuthSpec.
+func (in *SwiftKeystoneAuthSpec) DeepCopy() *SwiftKeystoneAuthSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SwiftKeystoneAuthSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SwiftLargeObjectsSpec) DeepCopyInto(out *SwiftLargeObjectsSpec) {
+	*out = *in
+	if in.MaxManifestSegments != nil {
+		in, out := &in.MaxManifestSegments, &out.MaxManifestSegments
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxManifestSize != nil {
+		in, out := &in.MaxManifestSize, &out.MaxManifestSize
+		*out = new(int32)
+		**out = **in
+	}
+	if in.RateLimitAfterSegment != nil {
+		in, out := &in.RateLimitAfterSegment, &out.RateLimitAfterSegment
+		*out = new(int32)
+		**out = **in
+	}
+	if in.RateLimitSegmentsPerSec != nil {
+		in, out := &in.RateLimitSegmentsPerSec, &out.RateLimitSegmentsPerSec
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwiftLargeObjectsSpec.
+func (in *SwiftLargeObjectsSpec) DeepCopy() *SwiftLargeObjectsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SwiftLargeObjectsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SwiftList) DeepCopyInto(out *SwiftList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Swift, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwiftList.
+func (in *SwiftList) DeepCopy() *SwiftList {
+	if in == nil {
+		return nil
+	}
+	out := new(SwiftList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SwiftList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SwiftMemcachePoolSpec) DeepCopyInto(out *SwiftMemcachePoolSpec) {
+	*out = *in
+	if in.Servers != nil {
+		in, out := &in.Servers, &out.Servers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MaxConnections != nil {
+		in, out := &in.MaxConnections, &out.MaxConnections
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Tries != nil {
+		in, out := &in.Tries, &out.Tries
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwiftMemcachePoolSpec.
+func (in *SwiftMemcachePoolSpec) DeepCopy() *SwiftMemcachePoolSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SwiftMemcachePoolSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SwiftMetadata) DeepCopyInto(out *SwiftMetadata) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwiftMetadata.
+func (in *SwiftMetadata) DeepCopy() *SwiftMetadata {
+	if in == nil {
+		return nil
+	}
+	out := new(SwiftMetadata)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SwiftObjectExpirerSpec) DeepCopyInto(out *SwiftObjectExpirerSpec) {
+	*out = *in
+	if in.Processes != nil {
+		in, out := &in.Processes, &out.Processes
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Concurrency != nil {
+		in, out := &in.Concurrency, &out.Concurrency
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ReportInterval != nil {
+		in, out := &in.ReportInterval, &out.ReportInterval
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwiftObjectExpirerSpec.
+func (in *SwiftObjectExpirerSpec) DeepCopy() *SwiftObjectExpirerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SwiftObjectExpirerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SwiftObjectReplicatorSpec) DeepCopyInto(out *SwiftObjectReplicatorSpec) {
+	*out = *in
+	in.SwiftReplicatorSpec.DeepCopyInto(&out.SwiftReplicatorSpec)
+	if in.RsyncTimeout != nil {
+		in, out := &in.RsyncTimeout, &out.RsyncTimeout
+		*out = new(int32)
+		**out = **in
+	}
+	if in.RsyncIOTimeout != nil {
+		in, out := &in.RsyncIOTimeout, &out.RsyncIOTimeout
+		*out = new(int32)
+		**out = **in
+	}
+	if in.HandoffDelete != nil {
+		in, out := &in.HandoffDelete, &out.HandoffDelete
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwiftObjectReplicatorSpec.
+func (in *SwiftObjectReplicatorSpec) DeepCopy() *SwiftObjectReplicatorSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SwiftObjectReplicatorSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SwiftObjectServerTuning) DeepCopyInto(out *SwiftObjectServerTuning) {
+	*out = *in
+	in.SwiftServerTuning.DeepCopyInto(&out.SwiftServerTuning)
+	if in.ServersPerPort != nil {
+		in, out := &in.ServersPerPort, &out.ServersPerPort
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwiftObjectServerTuning.
+func (in *SwiftObjectServerTuning) DeepCopy() *SwiftObjectServerTuning {
+	if in == nil {
+		return nil
+	}
+	out := new(SwiftObjectServerTuning)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SwiftObjectUpdaterSpec) DeepCopyInto(out *SwiftObjectUpdaterSpec) {
+	*out = *in
+	in.SwiftUpdaterSpec.DeepCopyInto(&out.SwiftUpdaterSpec)
+	if in.UpdaterWorkers != nil {
+		in, out := &in.UpdaterWorkers, &out.UpdaterWorkers
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ObjectsPerSecond != nil {
+		in, out := &in.ObjectsPerSecond, &out.ObjectsPerSecond
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxObjectsPerContainerPerSecond != nil {
+		in, out := &in.MaxObjectsPerContainerPerSecond, &out.MaxObjectsPerContainerPerSecond
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxDeferredUpdates != nil {
+		in, out := &in.MaxDeferredUpdates, &out.MaxDeferredUpdates
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwiftObjectUpdaterSpec.
+func (in *SwiftObjectUpdaterSpec) DeepCopy() *SwiftObjectUpdaterSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SwiftObjectUpdaterSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SwiftProxy) DeepCopyInto(out *SwiftProxy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwiftProxy.
+func (in *SwiftProxy) DeepCopy() *SwiftProxy {
+	if in == nil {
+		return nil
+	}
+	out := new(SwiftProxy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SwiftProxy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SwiftProxyAccessLogSpec) DeepCopyInto(out *SwiftProxyAccessLogSpec) {
+	*out = *in
+	if in.LogHeadersOnly != nil {
+		in, out := &in.LogHeadersOnly, &out.LogHeadersOnly
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwiftProxyAccessLogSpec.
+func (in *SwiftProxyAccessLogSpec) DeepCopy() *SwiftProxyAccessLogSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SwiftProxyAccessLogSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SwiftProxyAutoscalingSpec) DeepCopyInto(out *SwiftProxyAutoscalingSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwiftProxyAutoscalingSpec.
+func (in *SwiftProxyAutoscalingSpec) DeepCopy() *SwiftProxyAutoscalingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SwiftProxyAutoscalingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SwiftProxyDrainSpec) DeepCopyInto(out *SwiftProxyDrainSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwiftProxyDrainSpec.
+func (in *SwiftProxyDrainSpec) DeepCopy() *SwiftProxyDrainSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SwiftProxyDrainSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SwiftProxyErrorRateSpec) DeepCopyInto(out *SwiftProxyErrorRateSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwiftProxyErrorRateSpec.
+func (in *SwiftProxyErrorRateSpec) DeepCopy() *SwiftProxyErrorRateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SwiftProxyErrorRateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SwiftProxyFilter) DeepCopyInto(out *SwiftProxyFilter) {
+	*out = *in
+	if in.Options != nil {
+		in, out := &in.Options, &out.Options
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwiftProxyFilter.
+func (in *SwiftProxyFilter) DeepCopy() *SwiftProxyFilter {
+	if in == nil {
+		return nil
+	}
+	out := new(SwiftProxyFilter)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SwiftProxyIngressSpec) DeepCopyInto(out *SwiftProxyIngressSpec) {
+	*out = *in
+	if in.IngressClassName != nil {
+		in, out := &in.IngressClassName, &out.IngressClassName
+		*out = new(string)
+		**out = **in
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwiftProxyIngressSpec.
+func (in *SwiftProxyIngressSpec) DeepCopy() *SwiftProxyIngressSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SwiftProxyIngressSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SwiftProxyList) DeepCopyInto(out *SwiftProxyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]SwiftProxy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwiftProxyList.
+func (in *SwiftProxyList) DeepCopy() *SwiftProxyList {
+	if in == nil {
+		return nil
+	}
+	out := new(SwiftProxyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SwiftProxyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SwiftProxyMetricsSpec) DeepCopyInto(out *SwiftProxyMetricsSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwiftProxyMetricsSpec.
+func (in *SwiftProxyMetricsSpec) DeepCopy() *SwiftProxyMetricsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SwiftProxyMetricsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SwiftProxyPDBSpec) DeepCopyInto(out *SwiftProxyPDBSpec) {
+	*out = *in
+	if in.MinAvailable != nil {
+		in, out := &in.MinAvailable, &out.MinAvailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwiftProxyPDBSpec.
+func (in *SwiftProxyPDBSpec) DeepCopy() *SwiftProxyPDBSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SwiftProxyPDBSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SwiftProxyPorts) DeepCopyInto(out *SwiftProxyPorts) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwiftProxyPorts.
+func (in *SwiftProxyPorts) DeepCopy() *SwiftProxyPorts {
+	if in == nil {
+		return nil
+	}
+	out := new(SwiftProxyPorts)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SwiftProxyRolloutSpec) DeepCopyInto(out *SwiftProxyRolloutSpec) {
+	*out = *in
+	out.ErrorRate = in.ErrorRate
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwiftProxyRolloutSpec.
+func (in *SwiftProxyRolloutSpec) DeepCopy() *SwiftProxyRolloutSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SwiftProxyRolloutSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SwiftProxyRolloutStatus) DeepCopyInto(out *SwiftProxyRolloutStatus) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.ReadyTime != nil {
+		in, out := &in.ReadyTime, &out.ReadyTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwiftProxyRolloutStatus.
+func (in *SwiftProxyRolloutStatus) DeepCopy() *SwiftProxyRolloutStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SwiftProxyRolloutStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SwiftProxyServerTuning) DeepCopyInto(out *SwiftProxyServerTuning) {
+	*out = *in
+	in.SwiftServerTuning.DeepCopyInto(&out.SwiftServerTuning)
+	if in.NodeTimeout != nil {
+		in, out := &in.NodeTimeout, &out.NodeTimeout
+		*out = new(int32)
+		**out = **in
+	}
+	if in.RecoverableNodeTimeout != nil {
+		in, out := &in.RecoverableNodeTimeout, &out.RecoverableNodeTimeout
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ClientTimeout != nil {
+		in, out := &in.ClientTimeout, &out.ClientTimeout
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ErrorSuppressionInterval != nil {
+		in, out := &in.ErrorSuppressionInterval, &out.ErrorSuppressionInterval
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ErrorSuppressionLimit != nil {
+		in, out := &in.ErrorSuppressionLimit, &out.ErrorSuppressionLimit
+		*out = new(int32)
+		**out = **in
+	}
+	if in.TimingExpiry != nil {
+		in, out := &in.TimingExpiry, &out.TimingExpiry
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwiftProxyServerTuning.
+func (in *SwiftProxyServerTuning) DeepCopy() *SwiftProxyServerTuning {
+	if in == nil {
+		return nil
+	}
+	out := new(SwiftProxyServerTuning)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SwiftProxySpec) DeepCopyInto(out *SwiftProxySpec) {
+	*out = *in
+	in.SwiftProxySpecCore.DeepCopyInto(&out.SwiftProxySpecCore)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwiftProxySpec.
+func (in *SwiftProxySpec) DeepCopy() *SwiftProxySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SwiftProxySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SwiftProxySpecCore) DeepCopyInto(out *SwiftProxySpecCore) {
+	*out = *in
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	out.PasswordSelectors = in.PasswordSelectors
+	out.TempAuth = in.TempAuth
+	in.KeystoneAuth.DeepCopyInto(&out.KeystoneAuth)
+	in.Override.DeepCopyInto(&out.Override)
+	in.MemcachePool.DeepCopyInto(&out.MemcachePool)
+	out.LogForwarding = in.LogForwarding
+	if in.NetworkAttachments != nil {
+		in, out := &in.NetworkAttachments, &out.NetworkAttachments
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.IPFamilyPolicy != nil {
+		in, out := &in.IPFamilyPolicy, &out.IPFamilyPolicy
+		*out = new(v1.IPFamilyPolicy)
+		**out = **in
+	}
+	if in.IPFamilies != nil {
+		in, out := &in.IPFamilies, &out.IPFamilies
+		*out = make([]v1.IPFamily, len(*in))
+		copy(*out, *in)
+	}
+	out.Ports = in.Ports
+	in.ProxyServer.DeepCopyInto(&out.ProxyServer)
+	in.ReverseProxy.DeepCopyInto(&out.ReverseProxy)
+	in.ForwardedHeaders.DeepCopyInto(&out.ForwardedHeaders)
+	in.TLS.DeepCopyInto(&out.TLS)
+	in.Ingress.DeepCopyInto(&out.Ingress)
+	out.Autoscaling = in.Autoscaling
+	out.Drain = in.Drain
+	out.Metrics = in.Metrics
+	in.PodDisruptionBudget.DeepCopyInto(&out.PodDisruptionBudget)
+	out.Rollout = in.Rollout
+	in.TempURL.DeepCopyInto(&out.TempURL)
+	out.StaticWeb = in.StaticWeb
+	in.DomainRemap.DeepCopyInto(&out.DomainRemap)
+	in.Ratelimit.DeepCopyInto(&out.Ratelimit)
+	in.Quotas.DeepCopyInto(&out.Quotas)
+	in.Bulk.DeepCopyInto(&out.Bulk)
+	in.LargeObjects.DeepCopyInto(&out.LargeObjects)
+	in.CORS.DeepCopyInto(&out.CORS)
+	out.VersionedWrites = in.VersionedWrites
+	in.Symlink.DeepCopyInto(&out.Symlink)
+	in.Encryption.DeepCopyInto(&out.Encryption)
+	in.Ceilometer.DeepCopyInto(&out.Ceilometer)
+	in.AccessLog.DeepCopyInto(&out.AccessLog)
+	out.ReplicaAffinity = in.ReplicaAffinity
+	if in.Pipeline != nil {
+		in, out := &in.Pipeline, &out.Pipeline
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Filters != nil {
+		in, out := &in.Filters, &out.Filters
+		*out = make([]SwiftProxyFilter, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.S3API.DeepCopyInto(&out.S3API)
+	if in.ImagePullSecrets != nil {
+		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
+		*out = make([]v1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]v1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Affinity != nil {
+		in, out := &in.Affinity, &out.Affinity
+		*out = new(v1.Affinity)
+		(*in).DeepCopyInto(*out)
+	}
+	in.Resources.DeepCopyInto(&out.Resources)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwiftProxySpecCore.
+func (in *SwiftProxySpecCore) DeepCopy() *SwiftProxySpecCore {
+	if in == nil {
+		return nil
+	}
+	out := new(SwiftProxySpecCore)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SwiftProxyStatus) DeepCopyInto(out *SwiftProxyStatus) {
+	*out = *in
+	if in.NetworkAttachments != nil {
+		in, out := &in.NetworkAttachments, &out.NetworkAttachments
+		*out = make(map[string][]string, len(*in))
+		for key, val := range *in {
+			var outVal []string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = make([]string, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.Hash != nil {
+		in, out := &in.Hash, &out.Hash
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.LoadBalancerIPs != nil {
+		in, out := &in.LoadBalancerIPs, &out.LoadBalancerIPs
+		*out = make(map[string][]string, len(*in))
+		for key, val := range *in {
+			var outVal []string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = make([]string, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
+		}
+	}
+	in.Rollout.DeepCopyInto(&out.Rollout)
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make(condition.Conditions, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwiftProxyStatus.
+func (in *SwiftProxyStatus) DeepCopy() *SwiftProxyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SwiftProxyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SwiftProxyTLSSpec) DeepCopyInto(out *SwiftProxyTLSSpec) {
+	*out = *in
+	if in.IssuerRef != nil {
+		in, out := &in.IssuerRef, &out.IssuerRef
+		*out = new(SwiftIssuerReference)
+		**out = **in
+	}
+	if in.DNSNames != nil {
+		in, out := &in.DNSNames, &out.DNSNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwiftProxyTLSSpec.
+func (in *SwiftProxyTLSSpec) DeepCopy() *SwiftProxyTLSSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SwiftProxyTLSSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SwiftQuotasSpec) DeepCopyInto(out *SwiftQuotasSpec) {
+	*out = *in
+	if in.DefaultAccountQuota != nil {
+		in, out := &in.DefaultAccountQuota, &out.DefaultAccountQuota
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwiftQuotasSpec.
+func (in *SwiftQuotasSpec) DeepCopy() *SwiftQuotasSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SwiftQuotasSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SwiftRatelimitBucket) DeepCopyInto(out *SwiftRatelimitBucket) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwiftRatelimitBucket.
+func (in *SwiftRatelimitBucket) DeepCopy() *SwiftRatelimitBucket {
+	if in == nil {
+		return nil
+	}
+	out := new(SwiftRatelimitBucket)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SwiftRatelimitSpec) DeepCopyInto(out *SwiftRatelimitSpec) {
+	*out = *in
+	if in.AccountRatelimit != nil {
+		in, out := &in.AccountRatelimit, &out.AccountRatelimit
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxSleepTimeSeconds != nil {
+		in, out := &in.MaxSleepTimeSeconds, &out.MaxSleepTimeSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ContainerRatelimits != nil {
+		in, out := &in.ContainerRatelimits, &out.ContainerRatelimits
+		*out = make([]SwiftRatelimitBucket, len(*in))
+		copy(*out, *in)
+	}
+	if in.ContainerListingRatelimits != nil {
+		in, out := &in.ContainerListingRatelimits, &out.ContainerListingRatelimits
+		*out = make([]SwiftRatelimitBucket, len(*in))
+		copy(*out, *in)
+	}
+	if in.AccountWhitelist != nil {
+		in, out := &in.AccountWhitelist, &out.AccountWhitelist
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AccountBlacklist != nil {
+		in, out := &in.AccountBlacklist, &out.AccountBlacklist
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwiftRatelimitSpec.
+func (in *SwiftRatelimitSpec) DeepCopy() *SwiftRatelimitSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SwiftRatelimitSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SwiftReplicaAffinitySpec) DeepCopyInto(out *SwiftReplicaAffinitySpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwiftReplicaAffinitySpec.
+func (in *SwiftReplicaAffinitySpec) DeepCopy() *SwiftReplicaAffinitySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SwiftReplicaAffinitySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SwiftReplicatorSpec) DeepCopyInto(out *SwiftReplicatorSpec) {
+	*out = *in
+	if in.Concurrency != nil {
+		in, out := &in.Concurrency, &out.Concurrency
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(int32)
+		**out = **in
+	}
+	if in.NodeTimeout != nil {
+		in, out := &in.NodeTimeout, &out.NodeTimeout
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwiftReplicatorSpec.
+func (in *SwiftReplicatorSpec) DeepCopy() *SwiftReplicatorSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SwiftReplicatorSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SwiftResellerPrefix) DeepCopyInto(out *SwiftResellerPrefix) {
+	*out = *in
+	if in.OperatorRoles != nil {
+		in, out := &in.OperatorRoles, &out.OperatorRoles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ServiceRoles != nil {
+		in, out := &in.ServiceRoles, &out.ServiceRoles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwiftResellerPrefix.
+func (in *SwiftResellerPrefix) DeepCopy() *SwiftResellerPrefix {
+	if in == nil {
+		return nil
+	}
+	out := new(SwiftResellerPrefix)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SwiftReverseProxySpec) DeepCopyInto(out *SwiftReverseProxySpec) {
+	*out = *in
+	if in.ErrorPages != nil {
+		in, out := &in.ErrorPages, &out.ErrorPages
+		*out = make([]SwiftErrorPage, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwiftReverseProxySpec.
+func (in *SwiftReverseProxySpec) DeepCopy() *SwiftReverseProxySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SwiftReverseProxySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SwiftRing) DeepCopyInto(out *SwiftRing) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwiftRing.
+func (in *SwiftRing) DeepCopy() *SwiftRing {
+	if in == nil {
+		return nil
+	}
+	out := new(SwiftRing)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SwiftRing) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SwiftRingAudit) DeepCopyInto(out *SwiftRingAudit) {
+	*out = *in
+	if in.Mismatches != nil {
+		in, out := &in.Mismatches, &out.Mismatches
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastAuditTime != nil {
+		in, out := &in.LastAuditTime, &out.LastAuditTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwiftRingAudit.
+func (in *SwiftRingAudit) DeepCopy() *SwiftRingAudit {
+	if in == nil {
+		return nil
+	}
+	out := new(SwiftRingAudit)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SwiftRingAuditSpec) DeepCopyInto(out *SwiftRingAuditSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwiftRingAuditSpec.
+func (in *SwiftRingAuditSpec) DeepCopy() *SwiftRingAuditSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SwiftRingAuditSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SwiftRingAutoRebalanceSpec) DeepCopyInto(out *SwiftRingAutoRebalanceSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwiftRingAutoRebalanceSpec.
+func (in *SwiftRingAutoRebalanceSpec) DeepCopy() *SwiftRingAutoRebalanceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SwiftRingAutoRebalanceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SwiftRingComponent) DeepCopyInto(out *SwiftRingComponent) {
+	*out = *in
+	if in.Regions != nil {
+		in, out := &in.Regions, &out.Regions
+		*out = make([]int32, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwiftRingComponent.
+func (in *SwiftRingComponent) DeepCopy() *SwiftRingComponent {
+	if in == nil {
+		return nil
+	}
+	out := new(SwiftRingComponent)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SwiftRingCompositeSpec) DeepCopyInto(out *SwiftRingCompositeSpec) {
+	*out = *in
+	if in.Components != nil {
+		in, out := &in.Components, &out.Components
+		*out = make([]SwiftRingComponent, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwiftRingCompositeSpec.
+func (in *SwiftRingCompositeSpec) DeepCopy() *SwiftRingCompositeSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SwiftRingCompositeSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SwiftRingList) DeepCopyInto(out *SwiftRingList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]SwiftRing, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwiftRingList.
+func (in *SwiftRingList) DeepCopy() *SwiftRingList {
+	if in == nil {
+		return nil
+	}
+	out := new(SwiftRingList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SwiftRingList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SwiftRingParameters) DeepCopyInto(out *SwiftRingParameters) {
+	*out = *in
+	if in.PartPower != nil {
+		in, out := &in.PartPower, &out.PartPower
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MinPartHours != nil {
+		in, out := &in.MinPartHours, &out.MinPartHours
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Overload != nil {
+		in, out := &in.Overload, &out.Overload
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int64)
+		**out = **in
+	}
+	if in.DeviceSelector != nil {
+		in, out := &in.DeviceSelector, &out.DeviceSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwiftRingParameters.
+func (in *SwiftRingParameters) DeepCopy() *SwiftRingParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(SwiftRingParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SwiftRingParametersSpec) DeepCopyInto(out *SwiftRingParametersSpec) {
+	*out = *in
+	in.Account.DeepCopyInto(&out.Account)
+	in.Container.DeepCopyInto(&out.Container)
+	in.Object.DeepCopyInto(&out.Object)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwiftRingParametersSpec.
+func (in *SwiftRingParametersSpec) DeepCopy() *SwiftRingParametersSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SwiftRingParametersSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SwiftRingPartPowerIncreaseStatus) DeepCopyInto(out *SwiftRingPartPowerIncreaseStatus) {
+	*out = *in
+	in.PhaseTime.DeepCopyInto(&out.PhaseTime)
+	if in.Devices != nil {
+		in, out := &in.Devices, &out.Devices
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwiftRingPartPowerIncreaseStatus.
+func (in *SwiftRingPartPowerIncreaseStatus) DeepCopy() *SwiftRingPartPowerIncreaseStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SwiftRingPartPowerIncreaseStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SwiftRingReplicaChangeSpec) DeepCopyInto(out *SwiftRingReplicaChangeSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwiftRingReplicaChangeSpec.
+func (in *SwiftRingReplicaChangeSpec) DeepCopy() *SwiftRingReplicaChangeSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SwiftRingReplicaChangeSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SwiftRingReplicaChangeStatus) DeepCopyInto(out *SwiftRingReplicaChangeStatus) {
+	*out = *in
+	in.StepTime.DeepCopyInto(&out.StepTime)
+	if in.LastCheckTime != nil {
+		in, out := &in.LastCheckTime, &out.LastCheckTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwiftRingReplicaChangeStatus.
+func (in *SwiftRingReplicaChangeStatus) DeepCopy() *SwiftRingReplicaChangeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SwiftRingReplicaChangeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SwiftRingRollbackStatus) DeepCopyInto(out *SwiftRingRollbackStatus) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwiftRingRollbackStatus.
+func (in *SwiftRingRollbackStatus) DeepCopy() *SwiftRingRollbackStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SwiftRingRollbackStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SwiftRingSpec) DeepCopyInto(out *SwiftRingSpec) {
+	*out = *in
+	in.SwiftRingSpecCore.DeepCopyInto(&out.SwiftRingSpecCore)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwiftRingSpec.
+func (in *SwiftRingSpec) DeepCopy() *SwiftRingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SwiftRingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SwiftRingSpecCore) DeepCopyInto(out *SwiftRingSpecCore) {
+	*out = *in
+	if in.RingReplicas != nil {
+		in, out := &in.RingReplicas, &out.RingReplicas
+		*out = new(int64)
+		**out = **in
+	}
+	if in.PartPower != nil {
+		in, out := &in.PartPower, &out.PartPower
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxDevices != nil {
+		in, out := &in.MaxDevices, &out.MaxDevices
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MinPartHours != nil {
+		in, out := &in.MinPartHours, &out.MinPartHours
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Overload != nil {
+		in, out := &in.Overload, &out.Overload
+		*out = new(int32)
+		**out = **in
+	}
+	in.RingParameters.DeepCopyInto(&out.RingParameters)
+	in.Composite.DeepCopyInto(&out.Composite)
+	if in.StoragePolicies != nil {
+		in, out := &in.StoragePolicies, &out.StoragePolicies
+		*out = make([]SwiftRingStoragePolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RingHistoryLimit != nil {
+		in, out := &in.RingHistoryLimit, &out.RingHistoryLimit
+		*out = new(int32)
+		**out = **in
+	}
+	if in.RollbackToVersion != nil {
+		in, out := &in.RollbackToVersion, &out.RollbackToVersion
+		*out = new(int64)
+		**out = **in
+	}
+	if in.BuilderHistoryLimit != nil {
+		in, out := &in.BuilderHistoryLimit, &out.BuilderHistoryLimit
+		*out = new(int32)
+		**out = **in
+	}
+	out.AutoRebalance = in.AutoRebalance
+	out.Audit = in.Audit
+	out.ReplicaChange = in.ReplicaChange
+	if in.ImagePullSecrets != nil {
+		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
+		*out = make([]v1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]v1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.Resources.DeepCopyInto(&out.Resources)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwiftRingSpecCore.
+func (in *SwiftRingSpecCore) DeepCopy() *SwiftRingSpecCore {
+	if in == nil {
+		return nil
+	}
+	out := new(SwiftRingSpecCore)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SwiftRingStats) DeepCopyInto(out *SwiftRingStats) {
+	*out = *in
+	if in.PartitionsMovableTime != nil {
+		in, out := &in.PartitionsMovableTime, &out.PartitionsMovableTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwiftRingStats.
+func (in *SwiftRingStats) DeepCopy() *SwiftRingStats {
+	if in == nil {
+		return nil
+	}
+	out := new(SwiftRingStats)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SwiftRingStatus) DeepCopyInto(out *SwiftRingStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make(condition.Conditions, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Hash != nil {
+		in, out := &in.Hash, &out.Hash
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Rings != nil {
+		in, out := &in.Rings, &out.Rings
+		*out = make(map[string]SwiftRingStats, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.Preview != nil {
+		in, out := &in.Preview, &out.Preview
+		*out = make(map[string]SwiftRingStats, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.LastRebalanceTime != nil {
+		in, out := &in.LastRebalanceTime, &out.LastRebalanceTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastScheduledRebalanceTime != nil {
+		in, out := &in.LastScheduledRebalanceTime, &out.LastScheduledRebalanceTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Rollback != nil {
+		in, out := &in.Rollback, &out.Rollback
+		*out = new(SwiftRingRollbackStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PartPowerIncrease != nil {
+		in, out := &in.PartPowerIncrease, &out.PartPowerIncrease
+		*out = new(SwiftRingPartPowerIncreaseStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ReplicaChanges != nil {
+		in, out := &in.ReplicaChanges, &out.ReplicaChanges
+		*out = make(map[string]SwiftRingReplicaChangeStatus, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.Verification != nil {
+		in, out := &in.Verification, &out.Verification
+		*out = new(SwiftRingVerification)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Audit != nil {
+		in, out := &in.Audit, &out.Audit
+		*out = new(SwiftRingAudit)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwiftRingStatus.
+func (in *SwiftRingStatus) DeepCopy() *SwiftRingStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SwiftRingStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SwiftRingStoragePolicy) DeepCopyInto(out *SwiftRingStoragePolicy) {
+	*out = *in
+	in.Parameters.DeepCopyInto(&out.Parameters)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwiftRingStoragePolicy.
+func (in *SwiftRingStoragePolicy) DeepCopy() *SwiftRingStoragePolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(SwiftRingStoragePolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SwiftRingVerification) DeepCopyInto(out *SwiftRingVerification) {
+	*out = *in
+	if in.Mismatches != nil {
+		in, out := &in.Mismatches, &out.Mismatches
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Handoffs != nil {
+		in, out := &in.Handoffs, &out.Handoffs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastVerificationTime != nil {
+		in, out := &in.LastVerificationTime, &out.LastVerificationTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwiftRingVerification.
+func (in *SwiftRingVerification) DeepCopy() *SwiftRingVerification {
+	if in == nil {
+		return nil
+	}
+	out := new(SwiftRingVerification)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SwiftS3APISpec) DeepCopyInto(out *SwiftS3APISpec) {
+	*out = *in
+	if in.MaxUploadPartNum != nil {
+		in, out := &in.MaxUploadPartNum, &out.MaxUploadPartNum
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwiftS3APISpec.
+func (in *SwiftS3APISpec) DeepCopy() *SwiftS3APISpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SwiftS3APISpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SwiftServerTuning) DeepCopyInto(out *SwiftServerTuning) {
+	*out = *in
+	if in.Workers != nil {
+		in, out := &in.Workers, &out.Workers
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxClients != nil {
+		in, out := &in.MaxClients, &out.MaxClients
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwiftServerTuning.
+func (in *SwiftServerTuning) DeepCopy() *SwiftServerTuning {
+	if in == nil {
+		return nil
+	}
+	out := new(SwiftServerTuning)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SwiftSpec) DeepCopyInto(out *SwiftSpec) {
+	*out = *in
+	in.SwiftSpecBase.DeepCopyInto(&out.SwiftSpecBase)
+	in.SwiftRing.DeepCopyInto(&out.SwiftRing)
+	in.SwiftStorage.DeepCopyInto(&out.SwiftStorage)
+	in.SwiftProxy.DeepCopyInto(&out.SwiftProxy)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwiftSpec.
+func (in *SwiftSpec) DeepCopy() *SwiftSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SwiftSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SwiftSpecBase) DeepCopyInto(out *SwiftSpecBase) {
+	*out = *in
+	in.InternalProxy.DeepCopyInto(&out.InternalProxy)
+	out.Dispersion = in.Dispersion
+	if in.ImagePullSecrets != nil {
+		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
+		*out = make([]v1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwiftSpecBase.
+func (in *SwiftSpecBase) DeepCopy() *SwiftSpecBase {
+	if in == nil {
+		return nil
+	}
+	out := new(SwiftSpecBase)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SwiftSpecCore) DeepCopyInto(out *SwiftSpecCore) {
+	*out = *in
+	in.SwiftSpecBase.DeepCopyInto(&out.SwiftSpecBase)
+	in.SwiftRing.DeepCopyInto(&out.SwiftRing)
+	in.SwiftStorage.DeepCopyInto(&out.SwiftStorage)
+	in.SwiftProxy.DeepCopyInto(&out.SwiftProxy)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwiftSpecCore.
+func (in *SwiftSpecCore) DeepCopy() *SwiftSpecCore {
+	if in == nil {
+		return nil
+	}
+	out := new(SwiftSpecCore)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SwiftStaticWebSpec) DeepCopyInto(out *SwiftStaticWebSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwiftStaticWebSpec.
+func (in *SwiftStaticWebSpec) DeepCopy() *SwiftStaticWebSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SwiftStaticWebSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SwiftStatus) DeepCopyInto(out *SwiftStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make(condition.Conditions, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Versions != nil {
+		in, out := &in.Versions, &out.Versions
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Hash != nil {
+		in, out := &in.Hash, &out.Hash
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Dispersion != nil {
+		in, out := &in.Dispersion, &out.Dispersion
+		*out = new(SwiftDispersionStatus)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwiftStatus.
+func (in *SwiftStatus) DeepCopy() *SwiftStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SwiftStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SwiftStorage) DeepCopyInto(out *SwiftStorage) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwiftStorage.
+func (in *SwiftStorage) DeepCopy() *SwiftStorage {
+	if in == nil {
+		return nil
+	}
+	out := new(SwiftStorage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SwiftStorage) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SwiftStorageAlerts) DeepCopyInto(out *SwiftStorageAlerts) {
+	*out = *in
+	in.SwiftAlertThresholds.DeepCopyInto(&out.SwiftAlertThresholds)
+	if in.Account != nil {
+		in, out := &in.Account, &out.Account
+		*out = new(SwiftAlertThresholds)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Container != nil {
+		in, out := &in.Container, &out.Container
+		*out = new(SwiftAlertThresholds)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Object != nil {
+		in, out := &in.Object, &out.Object
+		*out = new(SwiftAlertThresholds)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Policies != nil {
+		in, out := &in.Policies, &out.Policies
+		*out = make(map[string]SwiftAlertThresholds, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwiftStorageAlerts.
+func (in *SwiftStorageAlerts) DeepCopy() *SwiftStorageAlerts {
+	if in == nil {
+		return nil
+	}
+	out := new(SwiftStorageAlerts)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SwiftStorageExtraMetadata) DeepCopyInto(out *SwiftStorageExtraMetadata) {
+	*out = *in
+	in.StatefulSet.DeepCopyInto(&out.StatefulSet)
+	in.Pod.DeepCopyInto(&out.Pod)
+	in.Service.DeepCopyInto(&out.Service)
+	in.PersistentVolumeClaim.DeepCopyInto(&out.PersistentVolumeClaim)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwiftStorageExtraMetadata.
+func (in *SwiftStorageExtraMetadata) DeepCopy() *SwiftStorageExtraMetadata {
+	if in == nil {
+		return nil
+	}
+	out := new(SwiftStorageExtraMetadata)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SwiftStorageFailedDevice) DeepCopyInto(out *SwiftStorageFailedDevice) {
+	*out = *in
+	in.Since.DeepCopyInto(&out.Since)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwiftStorageFailedDevice.
+func (in *SwiftStorageFailedDevice) DeepCopy() *SwiftStorageFailedDevice {
+	if in == nil {
+		return nil
+	}
+	out := new(SwiftStorageFailedDevice)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SwiftStorageFailedDevicesSpec) DeepCopyInto(out *SwiftStorageFailedDevicesSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwiftStorageFailedDevicesSpec.
+func (in *SwiftStorageFailedDevicesSpec) DeepCopy() *SwiftStorageFailedDevicesSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SwiftStorageFailedDevicesSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SwiftStorageList) DeepCopyInto(out *SwiftStorageList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]SwiftStorage, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwiftStorageList.
+func (in *SwiftStorageList) DeepCopy() *SwiftStorageList {
+	if in == nil {
+		return nil
+	}
+	out := new(SwiftStorageList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
 func (in *SwiftStorageList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
@@ -448,22 +2358,212 @@ func (in *SwiftStorageList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SwiftStoragePorts) DeepCopyInto(out *SwiftStoragePorts) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwiftStoragePorts.
+func (in *SwiftStoragePorts) DeepCopy() *SwiftStoragePorts {
+	if in == nil {
+		return nil
+	}
+	out := new(SwiftStoragePorts)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SwiftStorageRestoreClaim) DeepCopyInto(out *SwiftStorageRestoreClaim) {
+	*out = *in
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwiftStorageRestoreClaim.
+func (in *SwiftStorageRestoreClaim) DeepCopy() *SwiftStorageRestoreClaim {
+	if in == nil {
+		return nil
+	}
+	out := new(SwiftStorageRestoreClaim)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SwiftStorageRingTopologySpec) DeepCopyInto(out *SwiftStorageRingTopologySpec) {
+	*out = *in
+	if in.Regions != nil {
+		in, out := &in.Regions, &out.Regions
+		*out = make(map[string]int32, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Zones != nil {
+		in, out := &in.Zones, &out.Zones
+		*out = make(map[string]int32, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwiftStorageRingTopologySpec.
+func (in *SwiftStorageRingTopologySpec) DeepCopy() *SwiftStorageRingTopologySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SwiftStorageRingTopologySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SwiftStorageRollbackStatus) DeepCopyInto(out *SwiftStorageRollbackStatus) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwiftStorageRollbackStatus.
+func (in *SwiftStorageRollbackStatus) DeepCopy() *SwiftStorageRollbackStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SwiftStorageRollbackStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SwiftStorageSpec) DeepCopyInto(out *SwiftStorageSpec) {
+	*out = *in
+	in.SwiftStorageSpecCore.DeepCopyInto(&out.SwiftStorageSpecCore)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwiftStorageSpec.
+func (in *SwiftStorageSpec) DeepCopy() *SwiftStorageSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SwiftStorageSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SwiftStorageSpecCore) DeepCopyInto(out *SwiftStorageSpecCore) {
 	*out = *in
 	if in.Replicas != nil {
 		in, out := &in.Replicas, &out.Replicas
 		*out = new(int32)
 		**out = **in
 	}
+	if in.OrdinalStart != nil {
+		in, out := &in.OrdinalStart, &out.OrdinalStart
+		*out = new(int32)
+		**out = **in
+	}
+	out.LogForwarding = in.LogForwarding
+	if in.NetworkAttachments != nil {
+		in, out := &in.NetworkAttachments, &out.NetworkAttachments
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.IPFamilyPolicy != nil {
+		in, out := &in.IPFamilyPolicy, &out.IPFamilyPolicy
+		*out = new(v1.IPFamilyPolicy)
+		**out = **in
+	}
+	if in.IPFamilies != nil {
+		in, out := &in.IPFamilies, &out.IPFamilies
+		*out = make([]v1.IPFamily, len(*in))
+		copy(*out, *in)
+	}
+	if in.Affinity != nil {
+		in, out := &in.Affinity, &out.Affinity
+		*out = new(v1.Affinity)
+		(*in).DeepCopyInto(*out)
+	}
+	out.Ports = in.Ports
+	in.AccountServer.DeepCopyInto(&out.AccountServer)
+	in.ContainerServer.DeepCopyInto(&out.ContainerServer)
+	in.ObjectServer.DeepCopyInto(&out.ObjectServer)
+	if in.MountCheck != nil {
+		in, out := &in.MountCheck, &out.MountCheck
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DBPreallocation != nil {
+		in, out := &in.DBPreallocation, &out.DBPreallocation
+		*out = new(bool)
+		**out = **in
+	}
+	in.IONice.DeepCopyInto(&out.IONice)
+	in.AccountReaper.DeepCopyInto(&out.AccountReaper)
+	in.AccountReplicator.DeepCopyInto(&out.AccountReplicator)
+	in.ContainerReplicator.DeepCopyInto(&out.ContainerReplicator)
+	in.ObjectReplicator.DeepCopyInto(&out.ObjectReplicator)
+	in.Auditors.DeepCopyInto(&out.Auditors)
+	in.ContainerUpdater.DeepCopyInto(&out.ContainerUpdater)
+	in.ObjectUpdater.DeepCopyInto(&out.ObjectUpdater)
+	in.ObjectExpirer.DeepCopyInto(&out.ObjectExpirer)
+	in.RingTopology.DeepCopyInto(&out.RingTopology)
+	if in.DeviceWeights != nil {
+		in, out := &in.DeviceWeights, &out.DeviceWeights
+		*out = make(map[string]int32, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.DeviceLabels != nil {
+		in, out := &in.DeviceLabels, &out.DeviceLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	out.FailedDevices = in.FailedDevices
+	if in.RestoreClaims != nil {
+		in, out := &in.RestoreClaims, &out.RestoreClaims
+		*out = make([]SwiftStorageRestoreClaim, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RevisionHistoryLimit != nil {
+		in, out := &in.RevisionHistoryLimit, &out.RevisionHistoryLimit
+		*out = new(int32)
+		**out = **in
+	}
+	if in.RollbackToRevision != nil {
+		in, out := &in.RollbackToRevision, &out.RollbackToRevision
+		*out = new(int64)
+		**out = **in
+	}
+	in.ExtraMetadata.DeepCopyInto(&out.ExtraMetadata)
+	if in.ImagePullSecrets != nil {
+		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
+		*out = make([]v1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.TerminationGracePeriodSeconds != nil {
+		in, out := &in.TerminationGracePeriodSeconds, &out.TerminationGracePeriodSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	in.Alerts.DeepCopyInto(&out.Alerts)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwiftStorageSpec.
-func (in *SwiftStorageSpec) DeepCopy() *SwiftStorageSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwiftStorageSpecCore.
+func (in *SwiftStorageSpecCore) DeepCopy() *SwiftStorageSpecCore {
 	if in == nil {
 		return nil
 	}
-	out := new(SwiftStorageSpec)
+	out := new(SwiftStorageSpecCore)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -471,6 +2571,33 @@ func (in *SwiftStorageSpec) DeepCopy() *SwiftStorageSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SwiftStorageStatus) DeepCopyInto(out *SwiftStorageStatus) {
 	*out = *in
+	if in.NetworkAttachments != nil {
+		in, out := &in.NetworkAttachments, &out.NetworkAttachments
+		*out = make(map[string][]string, len(*in))
+		for key, val := range *in {
+			var outVal []string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = make([]string, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.Rollback != nil {
+		in, out := &in.Rollback, &out.Rollback
+		*out = new(SwiftStorageRollbackStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.FailedDevices != nil {
+		in, out := &in.FailedDevices, &out.FailedDevices
+		*out = make(map[string]SwiftStorageFailedDevice, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make(condition.Conditions, len(*in))
@@ -489,3 +2616,119 @@ func (in *SwiftStorageStatus) DeepCopy() *SwiftStorageStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SwiftSymlinkSpec) DeepCopyInto(out *SwiftSymlinkSpec) {
+	*out = *in
+	if in.SymloopMax != nil {
+		in, out := &in.SymloopMax, &out.SymloopMax
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwiftSymlinkSpec.
+func (in *SwiftSymlinkSpec) DeepCopy() *SwiftSymlinkSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SwiftSymlinkSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SwiftTempAuthSpec) DeepCopyInto(out *SwiftTempAuthSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwiftTempAuthSpec.
+func (in *SwiftTempAuthSpec) DeepCopy() *SwiftTempAuthSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SwiftTempAuthSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SwiftTempURLKeySelector) DeepCopyInto(out *SwiftTempURLKeySelector) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwiftTempURLKeySelector.
+func (in *SwiftTempURLKeySelector) DeepCopy() *SwiftTempURLKeySelector {
+	if in == nil {
+		return nil
+	}
+	out := new(SwiftTempURLKeySelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SwiftTempURLSpec) DeepCopyInto(out *SwiftTempURLSpec) {
+	*out = *in
+	if in.Methods != nil {
+		in, out := &in.Methods, &out.Methods
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowedDigests != nil {
+		in, out := &in.AllowedDigests, &out.AllowedDigests
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	out.KeySelectors = in.KeySelectors
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwiftTempURLSpec.
+func (in *SwiftTempURLSpec) DeepCopy() *SwiftTempURLSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SwiftTempURLSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SwiftUpdaterSpec) DeepCopyInto(out *SwiftUpdaterSpec) {
+	*out = *in
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Concurrency != nil {
+		in, out := &in.Concurrency, &out.Concurrency
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwiftUpdaterSpec.
+func (in *SwiftUpdaterSpec) DeepCopy() *SwiftUpdaterSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SwiftUpdaterSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SwiftVersionedWritesSpec) DeepCopyInto(out *SwiftVersionedWritesSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwiftVersionedWritesSpec.
+func (in *SwiftVersionedWritesSpec) DeepCopy() *SwiftVersionedWritesSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SwiftVersionedWritesSpec)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -19,9 +19,12 @@ package controllers
 import (
 	"context"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
 
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -31,13 +34,19 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
+	keystonev1 "github.com/openstack-k8s-operators/keystone-operator/api/v1beta1"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/endpoint"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/env"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/job"
 	common_rbac "github.com/openstack-k8s-operators/lib-common/modules/common/rbac"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/secret"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/util"
 	swiftv1 "github.com/openstack-k8s-operators/swift-operator/api/v1beta1"
 	swift "github.com/openstack-k8s-operators/swift-operator/pkg/swift"
+	swiftring "github.com/openstack-k8s-operators/swift-operator/pkg/swiftring"
+	swiftstorage "github.com/openstack-k8s-operators/swift-operator/pkg/swiftstorage"
 	"k8s.io/client-go/kubernetes"
 )
 
@@ -52,6 +61,7 @@ type SwiftReconciler struct {
 //+kubebuilder:rbac:groups=swift.openstack.org,resources=swifts,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=swift.openstack.org,resources=swifts/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=swift.openstack.org,resources=swifts/finalizers,verbs=update
+//+kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;update;patch;delete
 
 // service account, role, rolebinding
 // +kubebuilder:rbac:groups="",resources=serviceaccounts,verbs=get;list;watch;create;update
@@ -134,6 +144,7 @@ func (r *SwiftReconciler) Reconcile(ctx context.Context, req ctrl.Request) (resu
 			condition.UnknownCondition(swiftv1.SwiftProxyReadyCondition, condition.InitReason, swiftv1.SwiftProxyReadyInitMessage),
 			condition.UnknownCondition(swiftv1.SwiftRingReadyCondition, condition.InitReason, swiftv1.SwiftRingReadyInitMessage),
 			condition.UnknownCondition(swiftv1.SwiftStorageReadyCondition, condition.InitReason, swiftv1.SwiftStorageReadyInitMessage),
+			condition.UnknownCondition(swiftv1.SwiftVersionSkewCondition, condition.InitReason, swiftv1.SwiftVersionSkewInitMessage),
 			// service account, role, rolebinding conditions
 			condition.UnknownCondition(condition.ServiceAccountReadyCondition, condition.InitReason, condition.ServiceAccountReadyInitMessage),
 			condition.UnknownCondition(condition.RoleReadyCondition, condition.InitReason, condition.RoleReadyInitMessage),
@@ -145,6 +156,7 @@ func (r *SwiftReconciler) Reconcile(ctx context.Context, req ctrl.Request) (resu
 		// Register overall status immediately to have an early feedback e.g. in the cli
 		return ctrl.Result{}, err
 	}
+	instance.Status.ObservedGeneration = instance.Generation
 
 	// Handle service delete
 	if !instance.DeletionTimestamp.IsZero() {
@@ -176,6 +188,26 @@ func (r *SwiftReconciler) reconcileNormal(ctx context.Context, instance *swiftv1
 			Resources: []string{"configmaps"},
 			Verbs:     []string{"create", "get", "update", "delete"},
 		},
+		// The rebalance Job stores the ring builders, and the rings if they
+		// are distributed using a Secret
+		{
+			APIGroups: []string{""},
+			Resources: []string{"secrets"},
+			Verbs:     []string{"create"},
+		},
+		{
+			APIGroups:     []string{""},
+			Resources:     []string{"secrets"},
+			ResourceNames: []string{swiftv1.BuilderSecretName, swiftv1.RingSecretName},
+			Verbs:         []string{"get", "update"},
+		},
+		// Rings waiting for approval with the Manual RingUpdatePolicy
+		{
+			APIGroups:     []string{""},
+			Resources:     []string{"secrets"},
+			ResourceNames: []string{swiftv1.PendingRingSecretName},
+			Verbs:         []string{"get", "delete"},
+		},
 		{
 			APIGroups: []string{""},
 			Resources: []string{"persistentvolumeclaims"},
@@ -222,6 +254,19 @@ func (r *SwiftReconciler) reconcileNormal(ctx context.Context, instance *swiftv1
 
 	instance.Status.Conditions.MarkTrue(condition.ServiceConfigReadyCondition, condition.ServiceConfigReadyMessage)
 
+	// Detect the Swift versions of the requested images and validate them
+	// against the support matrix before any of them is rolled out
+	ctrlResult, err := r.reconcileVersions(ctx, instance, helper, serviceLabels)
+	if err != nil {
+		return ctrlResult, err
+	} else if (ctrlResult != ctrl.Result{}) {
+		return ctrlResult, nil
+	}
+	if !instance.Status.Conditions.IsTrue(swiftv1.SwiftVersionSkewCondition) {
+		r.Log.Info(fmt.Sprintf("Unsupported Swift versions requested, not updating '%s'", instance.Name))
+		return ctrl.Result{}, nil
+	}
+
 	// create or update Swift storage
 	swiftStorage, op, err := r.storageCreateOrUpdate(ctx, instance)
 	if err != nil {
@@ -285,7 +330,157 @@ func (r *SwiftReconciler) reconcileNormal(ctx context.Context, instance *swiftv1
 		instance.Status.Conditions.Set(c)
 	}
 
+	// create, update or delete the internal Swift proxy
+	if instance.Spec.InternalProxy.Enabled {
+		internalProxy, op, err := r.internalProxyCreateOrUpdate(ctx, instance, &swiftProxy.Spec)
+		if err != nil {
+			instance.Status.Conditions.Set(condition.FalseCondition(
+				swiftv1.SwiftProxyReadyCondition,
+				condition.ErrorReason,
+				condition.SeverityWarning,
+				swiftv1.SwiftProxyReadyErrorMessage,
+				err.Error()))
+			return ctrl.Result{}, err
+		}
+		if op != controllerutil.OperationResultNone {
+			r.Log.Info(fmt.Sprintf("Deployment %s successfully reconciled - operation: %s", internalProxy.Name, string(op)))
+		}
+
+		// The SwiftProxy condition is only ready if both proxies are
+		c = internalProxy.Status.Conditions.Mirror(swiftv1.SwiftProxyReadyCondition)
+		if c != nil && instance.Status.Conditions.IsTrue(swiftv1.SwiftProxyReadyCondition) {
+			instance.Status.Conditions.Set(c)
+		}
+	} else {
+		internalProxy := &swiftv1.SwiftProxy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      InternalProxyName(instance),
+				Namespace: instance.Namespace,
+			},
+		}
+		if err := r.Client.Delete(ctx, internalProxy); err != nil && !apierrors.IsNotFound(err) {
+			return ctrl.Result{}, err
+		}
+	}
+
+	// Dispersion populate and report Jobs, once the rings are published
+	// and the proxies serve requests
+	ctrlResult, err = r.reconcileDispersion(ctx, instance, helper, swiftRing, swiftProxy, serviceLabels)
+	if err != nil || (ctrlResult != ctrl.Result{}) {
+		return ctrlResult, err
+	}
+
 	r.Log.Info(fmt.Sprintf("Reconciled Service '%s' successfully", instance.Name))
+	if next := swift.NextDispersionReportTime(instance); next != nil && instance.Spec.Dispersion.Enabled {
+		return ctrl.Result{RequeueAfter: time.Until(*next)}, nil
+	}
+	return ctrl.Result{}, nil
+}
+
+// reconcileDispersion runs the dispersion populate Job once and the report
+// Job for every ring version and periodically. The results are stored in
+// the status
+func (r *SwiftReconciler) reconcileDispersion(
+	ctx context.Context,
+	instance *swiftv1.Swift,
+	helper *helper.Helper,
+	swiftRing *swiftv1.SwiftRing,
+	swiftProxy *swiftv1.SwiftProxy,
+	labels map[string]string,
+) (ctrl.Result, error) {
+	if !instance.Spec.Dispersion.Enabled {
+		instance.Status.Dispersion = nil
+		return ctrl.Result{}, nil
+	}
+	if instance.Spec.SwiftProxy.AuthMode == "TempAuth" {
+		r.Log.Info("Dispersion Jobs require the Keystone AuthMode, not running them")
+		return ctrl.Result{}, nil
+	}
+	if !swiftRing.Status.Conditions.IsTrue(condition.ReadyCondition) || !swiftProxy.Status.Conditions.IsTrue(condition.ReadyCondition) {
+		return ctrl.Result{}, nil
+	}
+	if instance.Status.Hash == nil {
+		instance.Status.Hash = map[string]string{}
+	}
+
+	// dispersion.conf with the credentials of the service user
+	keystoneAPI, err := keystonev1.GetKeystoneAPI(ctx, helper, instance.Namespace, map[string]string{})
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	keystoneInternalURL, err := keystoneAPI.GetEndpoint(endpoint.EndpointInternal)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	sps, _, err := secret.GetSecret(ctx, helper, instance.Spec.SwiftProxy.Secret, instance.Namespace)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	password, ok := sps.Data[instance.Spec.SwiftProxy.PasswordSelectors.Service]
+	if !ok {
+		return ctrl.Result{}, fmt.Errorf("key %s not found in Secret %s", instance.Spec.SwiftProxy.PasswordSelectors.Service, instance.Spec.SwiftProxy.Secret)
+	}
+	envVars := make(map[string]env.Setter)
+	tpl := swift.DispersionTemplates(instance, labels, keystoneInternalURL, string(password))
+	if err := secret.EnsureSecrets(ctx, helper, instance, tpl, &envVars); err != nil {
+		return ctrl.Result{}, err
+	}
+	configHash, err := util.ObjectHash(env.MergeEnvs([]corev1.EnvVar{}, envVars))
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// The report queries the storage devices directly
+	networks, err := swiftstorage.NetworksAnnotation(ctx, helper, instance.Namespace)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// The populate Job only runs again if the configuration changes
+	populateJob := job.NewJob(
+		swift.DispersionJob(instance, "populate", labels, networks, 0, configHash),
+		swift.DispersionPopulateHash, false, 5*time.Second, instance.Status.Hash[swift.DispersionPopulateHash])
+	ctrlResult, err := populateJob.DoJob(ctx, helper)
+	if err != nil || (ctrlResult != ctrl.Result{}) {
+		return ctrlResult, err
+	}
+	if populateJob.HasChanged() {
+		instance.Status.Hash[swift.DispersionPopulateHash] = populateJob.GetHash()
+	}
+
+	// The report Job runs again for new rings, and periodically by
+	// deleting the finished Job. The hash is reset until the new Job
+	// finished, thus it is deleted only once
+	next := swift.NextDispersionReportTime(instance)
+	if next != nil && !time.Now().Before(*next) && instance.Status.Hash[swift.DispersionReportHash] != "" {
+		if err := job.DeleteJob(ctx, helper, swift.DispersionJobName(instance, "report"), instance.Namespace); err != nil {
+			return ctrl.Result{}, err
+		}
+		instance.Status.Hash[swift.DispersionReportHash] = ""
+	}
+	reportJob := job.NewJob(
+		swift.DispersionJob(instance, "report", labels, networks, swiftring.ActiveRingVersion(swiftRing), configHash),
+		swift.DispersionReportHash, false, 5*time.Second, instance.Status.Hash[swift.DispersionReportHash])
+	ctrlResult, err = reportJob.DoJob(ctx, helper)
+	if err != nil || (ctrlResult != ctrl.Result{}) {
+		return ctrlResult, err
+	}
+	if reportJob.HasChanged() {
+		instance.Status.Hash[swift.DispersionReportHash] = reportJob.GetHash()
+		message, err := swift.GetJobTerminationMessage(ctx, helper, instance.Namespace, swift.DispersionJobName(instance, "report"))
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		dispersion, err := swift.ParseDispersionReport(message)
+		if err != nil {
+			r.Log.Info(fmt.Sprintf("No dispersion reported: %s", err))
+			dispersion = &swiftv1.SwiftDispersionStatus{}
+		}
+		now := metav1.Now()
+		dispersion.RingVersion = swiftring.ActiveRingVersion(swiftRing)
+		dispersion.LastReportTime = &now
+		instance.Status.Dispersion = dispersion
+	}
 	return ctrl.Result{}, nil
 }
 
@@ -296,6 +491,7 @@ func (r *SwiftReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		Owns(&swiftv1.SwiftRing{}).
 		Owns(&swiftv1.SwiftStorage{}).
 		Owns(&swiftv1.SwiftProxy{}).
+		Owns(&batchv1.Job{}).
 		Owns(&corev1.ServiceAccount{}).
 		Owns(&rbacv1.Role{}).
 		Owns(&rbacv1.RoleBinding{}).
@@ -312,12 +508,139 @@ func (r *SwiftReconciler) reconcileDelete(ctx context.Context, instance *swiftv1
 	return ctrl.Result{}, nil
 }
 
+// reconcileVersions runs a Job per component image to detect its Swift
+// version. Detected versions are stored in the status, thus the Jobs only
+// run again if an image changes
+func (r *SwiftReconciler) reconcileVersions(ctx context.Context, instance *swiftv1.Swift, helper *helper.Helper, labels map[string]string) (ctrl.Result, error) {
+	images := map[string]string{
+		"proxy":     instance.Spec.SwiftProxy.ContainerImageProxy,
+		"account":   instance.Spec.SwiftStorage.ContainerImageAccount,
+		"container": instance.Spec.SwiftStorage.ContainerImageContainer,
+		"object":    instance.Spec.SwiftStorage.ContainerImageObject,
+	}
+
+	// Only keep the versions of the images in use
+	versions := map[string]string{}
+	for _, image := range images {
+		if version, ok := instance.Status.Versions[image]; ok {
+			versions[image] = version
+		}
+	}
+	instance.Status.Versions = versions
+
+	for _, component := range []string{"proxy", "account", "container", "object"} {
+		image := images[component]
+		if _, ok := instance.Status.Versions[image]; ok {
+			continue
+		}
+
+		versionJob := job.NewJob(swift.VersionJob(instance, component, image, labels), "version-"+component, false, 5*time.Second, "")
+		ctrlResult, err := versionJob.DoJob(ctx, helper)
+		if (ctrlResult != ctrl.Result{}) {
+			instance.Status.Conditions.Set(condition.FalseCondition(
+				swiftv1.SwiftVersionSkewCondition,
+				condition.RequestedReason,
+				condition.SeverityInfo,
+				swiftv1.SwiftVersionSkewRunningMessage))
+			return ctrlResult, nil
+		}
+		if err == nil {
+			var version string
+			version, err = swift.GetJobTerminationMessage(ctx, helper, instance.Namespace, swift.VersionJobName(instance, component))
+			if err == nil && version == "" {
+				err = fmt.Errorf("no version reported for image %s", image)
+			}
+			instance.Status.Versions[image] = version
+		}
+		if err != nil {
+			delete(instance.Status.Versions, image)
+			instance.Status.Conditions.Set(condition.FalseCondition(
+				swiftv1.SwiftVersionSkewCondition,
+				condition.ErrorReason,
+				condition.SeverityWarning,
+				swiftv1.SwiftVersionSkewErrorMessage,
+				err.Error()))
+			return ctrl.Result{}, err
+		}
+	}
+
+	messages := []string{}
+	proxyVersion := instance.Status.Versions[images["proxy"]]
+	for _, component := range []string{"account", "container", "object"} {
+		err := swift.ValidateVersionSkew(proxyVersion, instance.Status.Versions[images[component]])
+		if err != nil {
+			messages = append(messages, fmt.Sprintf("%s: %s", component, err.Error()))
+		}
+	}
+
+	switch {
+	case len(messages) == 0:
+		instance.Status.Conditions.MarkTrue(swiftv1.SwiftVersionSkewCondition, swiftv1.SwiftVersionSkewReadyMessage)
+	case instance.Spec.VersionSkewPolicy == "Warn":
+		r.Log.Info(fmt.Sprintf("Unsupported Swift versions: %s", strings.Join(messages, ", ")))
+		instance.Status.Conditions.MarkTrue(swiftv1.SwiftVersionSkewCondition, swiftv1.SwiftVersionSkewWarningMessage, strings.Join(messages, ", "))
+	default:
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			swiftv1.SwiftVersionSkewCondition,
+			condition.ErrorReason,
+			condition.SeverityWarning,
+			swiftv1.SwiftVersionSkewErrorMessage,
+			strings.Join(messages, ", ")))
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// imagePullSecrets returns the image pull secrets of a sub resource, which
+// default to the ones of the Swift instance
+func imagePullSecrets(instance *swiftv1.Swift, secrets []corev1.LocalObjectReference) []corev1.LocalObjectReference {
+	if len(secrets) > 0 {
+		return secrets
+	}
+	return instance.Spec.ImagePullSecrets
+}
+
+// memcachedInstance returns the Memcached instance of a sub resource, which
+// defaults to the one of the Swift instance
+func memcachedInstance(instance *swiftv1.Swift, name string) string {
+	if name != "" {
+		return name
+	}
+	return instance.Spec.MemcachedInstance
+}
+
 func (r *SwiftReconciler) ringCreateOrUpdate(ctx context.Context, instance *swiftv1.Swift) (*swiftv1.SwiftRing, controllerutil.OperationResult, error) {
 
 	swiftRingSpec := swiftv1.SwiftRingSpec{
-		RingReplicas:    instance.Spec.SwiftRing.RingReplicas,
-		ContainerImage:  instance.Spec.SwiftRing.ContainerImage,
-		SwiftConfSecret: instance.Spec.SwiftConfSecret,
+		SwiftRingSpecCore: swiftv1.SwiftRingSpecCore{
+			RingReplicas:        instance.Spec.SwiftRing.RingReplicas,
+			PartPower:           instance.Spec.SwiftRing.PartPower,
+			MaxDevices:          instance.Spec.SwiftRing.MaxDevices,
+			MinPartHours:        instance.Spec.SwiftRing.MinPartHours,
+			Overload:            instance.Spec.SwiftRing.Overload,
+			RingParameters:      instance.Spec.SwiftRing.RingParameters,
+			Composite:           instance.Spec.SwiftRing.Composite,
+			StoragePolicies:     instance.Spec.SwiftRing.StoragePolicies,
+			SwiftConfSecret:     instance.Spec.SwiftConfSecret,
+			RingDistribution:    instance.Spec.RingDistribution,
+			RingSnapshotSecret:  instance.Spec.SwiftRing.RingSnapshotSecret,
+			RingImportSecret:    instance.Spec.SwiftRing.RingImportSecret,
+			RingHistoryLimit:    instance.Spec.SwiftRing.RingHistoryLimit,
+			RollbackToVersion:   instance.Spec.SwiftRing.RollbackToVersion,
+			BuilderHistoryLimit: instance.Spec.SwiftRing.BuilderHistoryLimit,
+			RecoverBuilders:     instance.Spec.SwiftRing.RecoverBuilders,
+			AutoRebalance:       instance.Spec.SwiftRing.AutoRebalance,
+			Audit:               instance.Spec.SwiftRing.Audit,
+			ReplicaChange:       instance.Spec.SwiftRing.ReplicaChange,
+			DryRun:              instance.Spec.SwiftRing.DryRun,
+			RingUpdatePolicy:    instance.Spec.SwiftRing.RingUpdatePolicy,
+			FailureDomainPolicy: instance.Spec.SwiftRing.FailureDomainPolicy,
+			ImagePullSecrets:    imagePullSecrets(instance, instance.Spec.SwiftRing.ImagePullSecrets),
+			NodeSelector:        instance.Spec.SwiftRing.NodeSelector,
+			Tolerations:         instance.Spec.SwiftRing.Tolerations,
+			Resources:           instance.Spec.SwiftRing.Resources,
+		},
+		ContainerImage: instance.Spec.SwiftRing.ContainerImage,
 	}
 
 	deployment := &swiftv1.SwiftRing{
@@ -343,15 +666,58 @@ func (r *SwiftReconciler) ringCreateOrUpdate(ctx context.Context, instance *swif
 func (r *SwiftReconciler) storageCreateOrUpdate(ctx context.Context, instance *swiftv1.Swift) (*swiftv1.SwiftStorage, controllerutil.OperationResult, error) {
 
 	swiftStorageSpec := swiftv1.SwiftStorageSpec{
-		Replicas:                instance.Spec.SwiftStorage.Replicas,
-		StorageClass:            instance.Spec.SwiftStorage.StorageClass,
-		StorageRequest:          instance.Spec.SwiftStorage.StorageRequest,
+		SwiftStorageSpecCore: swiftv1.SwiftStorageSpecCore{
+			Replicas:                      instance.Spec.SwiftStorage.Replicas,
+			OrdinalStart:                  instance.Spec.SwiftStorage.OrdinalStart,
+			StorageClass:                  instance.Spec.SwiftStorage.StorageClass,
+			StorageRequest:                instance.Spec.SwiftStorage.StorageRequest,
+			SwiftConfSecret:               instance.Spec.SwiftConfSecret,
+			RingDistribution:              instance.Spec.RingDistribution,
+			LogForwarding:                 instance.Spec.SwiftStorage.LogForwarding,
+			NetworkAttachments:            instance.Spec.SwiftStorage.NetworkAttachments,
+			IPFamilyPolicy:                instance.Spec.SwiftStorage.IPFamilyPolicy,
+			IPFamilies:                    instance.Spec.SwiftStorage.IPFamilies,
+			AntiAffinity:                  instance.Spec.SwiftStorage.AntiAffinity,
+			Affinity:                      instance.Spec.SwiftStorage.Affinity,
+			MemcachedInstance:             memcachedInstance(instance, instance.Spec.SwiftStorage.MemcachedInstance),
+			CABundleSecretName:            instance.Spec.SwiftStorage.CABundleSecretName,
+			Ports:                         instance.Spec.SwiftStorage.Ports,
+			ReplicationServers:            instance.Spec.SwiftStorage.ReplicationServers,
+			AccountServer:                 instance.Spec.SwiftStorage.AccountServer,
+			ContainerServer:               instance.Spec.SwiftStorage.ContainerServer,
+			AllowVersions:                 instance.Spec.SwiftStorage.AllowVersions || instance.Spec.SwiftProxy.VersionedWrites.AllowVersionedWrites,
+			ObjectServer:                  instance.Spec.SwiftStorage.ObjectServer,
+			DevicesRoot:                   instance.Spec.SwiftStorage.DevicesRoot,
+			MountCheck:                    instance.Spec.SwiftStorage.MountCheck,
+			FallocateReserve:              instance.Spec.SwiftStorage.FallocateReserve,
+			RingTopology:                  instance.Spec.SwiftStorage.RingTopology,
+			DeviceWeights:                 instance.Spec.SwiftStorage.DeviceWeights,
+			DeviceLabels:                  instance.Spec.SwiftStorage.DeviceLabels,
+			FailedDevices:                 instance.Spec.SwiftStorage.FailedDevices,
+			RestoreClaims:                 instance.Spec.SwiftStorage.RestoreClaims,
+			RevisionHistoryLimit:          instance.Spec.SwiftStorage.RevisionHistoryLimit,
+			RollbackToRevision:            instance.Spec.SwiftStorage.RollbackToRevision,
+			ExtraMetadata:                 instance.Spec.SwiftStorage.ExtraMetadata,
+			ImagePullSecrets:              imagePullSecrets(instance, instance.Spec.SwiftStorage.ImagePullSecrets),
+			PriorityClassName:             instance.Spec.SwiftStorage.PriorityClassName,
+			TerminationGracePeriodSeconds: instance.Spec.SwiftStorage.TerminationGracePeriodSeconds,
+			DBPreallocation:               instance.Spec.SwiftStorage.DBPreallocation,
+			IONice:                        instance.Spec.SwiftStorage.IONice,
+			AccountReaper:                 instance.Spec.SwiftStorage.AccountReaper,
+			AccountReplicator:             instance.Spec.SwiftStorage.AccountReplicator,
+			ContainerReplicator:           instance.Spec.SwiftStorage.ContainerReplicator,
+			ObjectReplicator:              instance.Spec.SwiftStorage.ObjectReplicator,
+			Auditors:                      instance.Spec.SwiftStorage.Auditors,
+			ContainerUpdater:              instance.Spec.SwiftStorage.ContainerUpdater,
+			ObjectUpdater:                 instance.Spec.SwiftStorage.ObjectUpdater,
+			ObjectExpirer:                 instance.Spec.SwiftStorage.ObjectExpirer,
+			Alerts:                        instance.Spec.SwiftStorage.Alerts,
+		},
 		ContainerImageAccount:   instance.Spec.SwiftStorage.ContainerImageAccount,
 		ContainerImageContainer: instance.Spec.SwiftStorage.ContainerImageContainer,
 		ContainerImageObject:    instance.Spec.SwiftStorage.ContainerImageObject,
 		ContainerImageProxy:     instance.Spec.SwiftStorage.ContainerImageProxy,
 		ContainerImageMemcached: instance.Spec.SwiftStorage.ContainerImageMemcached,
-		SwiftConfSecret:         instance.Spec.SwiftConfSecret,
 	}
 
 	deployment := &swiftv1.SwiftStorage{
@@ -377,19 +743,103 @@ func (r *SwiftReconciler) storageCreateOrUpdate(ctx context.Context, instance *s
 func (r *SwiftReconciler) proxyCreateOrUpdate(ctx context.Context, instance *swiftv1.Swift) (*swiftv1.SwiftProxy, controllerutil.OperationResult, error) {
 
 	swiftProxySpec := swiftv1.SwiftProxySpec{
-		Replicas:                instance.Spec.SwiftProxy.Replicas,
+		SwiftProxySpecCore: swiftv1.SwiftProxySpecCore{
+			Replicas:            instance.Spec.SwiftProxy.Replicas,
+			Secret:              instance.Spec.SwiftProxy.Secret,
+			ServiceUser:         instance.Spec.SwiftProxy.ServiceUser,
+			PasswordSelectors:   instance.Spec.SwiftProxy.PasswordSelectors,
+			AuthMode:            instance.Spec.SwiftProxy.AuthMode,
+			TempAuth:            instance.Spec.SwiftProxy.TempAuth,
+			KeystoneAuth:        instance.Spec.SwiftProxy.KeystoneAuth,
+			Endpoints:           instance.Spec.SwiftProxy.Endpoints,
+			SwiftConfSecret:     instance.Spec.SwiftConfSecret,
+			RingDistribution:    instance.Spec.RingDistribution,
+			Override:            instance.Spec.SwiftProxy.Override,
+			LogForwarding:       instance.Spec.SwiftProxy.LogForwarding,
+			NetworkAttachments:  instance.Spec.SwiftProxy.NetworkAttachments,
+			IPFamilyPolicy:      instance.Spec.SwiftProxy.IPFamilyPolicy,
+			IPFamilies:          instance.Spec.SwiftProxy.IPFamilies,
+			MemcachedInstance:   memcachedInstance(instance, instance.Spec.SwiftProxy.MemcachedInstance),
+			MemcachePool:        instance.Spec.SwiftProxy.MemcachePool,
+			CABundleSecretName:  instance.Spec.SwiftProxy.CABundleSecretName,
+			S3API:               instance.Spec.SwiftProxy.S3API,
+			Ports:               instance.Spec.SwiftProxy.Ports,
+			ProxyServer:         instance.Spec.SwiftProxy.ProxyServer,
+			ReverseProxy:        instance.Spec.SwiftProxy.ReverseProxy,
+			ForwardedHeaders:    instance.Spec.SwiftProxy.ForwardedHeaders,
+			TLS:                 instance.Spec.SwiftProxy.TLS,
+			Ingress:             instance.Spec.SwiftProxy.Ingress,
+			Autoscaling:         instance.Spec.SwiftProxy.Autoscaling,
+			Drain:               instance.Spec.SwiftProxy.Drain,
+			Metrics:             instance.Spec.SwiftProxy.Metrics,
+			TempURL:             instance.Spec.SwiftProxy.TempURL,
+			StaticWeb:           instance.Spec.SwiftProxy.StaticWeb,
+			DomainRemap:         instance.Spec.SwiftProxy.DomainRemap,
+			Ratelimit:           instance.Spec.SwiftProxy.Ratelimit,
+			Quotas:              instance.Spec.SwiftProxy.Quotas,
+			Bulk:                instance.Spec.SwiftProxy.Bulk,
+			LargeObjects:        instance.Spec.SwiftProxy.LargeObjects,
+			CORS:                instance.Spec.SwiftProxy.CORS,
+			VersionedWrites:     instance.Spec.SwiftProxy.VersionedWrites,
+			Symlink:             instance.Spec.SwiftProxy.Symlink,
+			Encryption:          instance.Spec.SwiftProxy.Encryption,
+			Ceilometer:          instance.Spec.SwiftProxy.Ceilometer,
+			AccessLog:           instance.Spec.SwiftProxy.AccessLog,
+			ReplicaAffinity:     instance.Spec.SwiftProxy.ReplicaAffinity,
+			Pipeline:            instance.Spec.SwiftProxy.Pipeline,
+			Filters:             instance.Spec.SwiftProxy.Filters,
+			ImagePullSecrets:    imagePullSecrets(instance, instance.Spec.SwiftProxy.ImagePullSecrets),
+			PriorityClassName:   instance.Spec.SwiftProxy.PriorityClassName,
+			NodeSelector:        instance.Spec.SwiftProxy.NodeSelector,
+			Tolerations:         instance.Spec.SwiftProxy.Tolerations,
+			Affinity:            instance.Spec.SwiftProxy.Affinity,
+			Resources:           instance.Spec.SwiftProxy.Resources,
+			PodDisruptionBudget: instance.Spec.SwiftProxy.PodDisruptionBudget,
+			Rollout:             instance.Spec.SwiftProxy.Rollout,
+		},
 		ContainerImageProxy:     instance.Spec.SwiftProxy.ContainerImageProxy,
 		ContainerImageMemcached: instance.Spec.SwiftProxy.ContainerImageMemcached,
-		Secret:                  instance.Spec.SwiftProxy.Secret,
-		ServiceUser:             instance.Spec.SwiftProxy.ServiceUser,
-		PasswordSelectors:       instance.Spec.SwiftProxy.PasswordSelectors,
-		SwiftConfSecret:         instance.Spec.SwiftConfSecret,
-		Override:                instance.Spec.SwiftProxy.Override,
 	}
+	// The internal proxies serve the internal endpoint instead
+	if instance.Spec.InternalProxy.Enabled {
+		swiftProxySpec.Endpoints = swiftv1.EndpointsPublic
+	}
+
+	return r.swiftProxyCreateOrUpdate(ctx, instance, fmt.Sprintf("%s-proxy", instance.Name), swiftProxySpec)
+}
+
+// internalProxyCreateOrUpdate creates the SwiftProxy of the internal
+// endpoint from the spec of the public one
+func (r *SwiftReconciler) internalProxyCreateOrUpdate(ctx context.Context, instance *swiftv1.Swift, publicSpec *swiftv1.SwiftProxySpec) (*swiftv1.SwiftProxy, controllerutil.OperationResult, error) {
+	internal := instance.Spec.InternalProxy
+	swiftProxySpec := *publicSpec.DeepCopy()
+	swiftProxySpec.Endpoints = swiftv1.EndpointsInternal
+	swiftProxySpec.Replicas = internal.Replicas
+	swiftProxySpec.Ratelimit.Enabled = publicSpec.Ratelimit.Enabled && internal.Ratelimit
+	if internal.KeystoneAuth != nil {
+		swiftProxySpec.KeystoneAuth = *internal.KeystoneAuth
+	}
+	if internal.NodeSelector != nil {
+		swiftProxySpec.NodeSelector = internal.NodeSelector
+	}
+	// The Ingress and the autoscaling queries are specific to the public
+	// endpoint
+	swiftProxySpec.Ingress = swiftv1.SwiftProxyIngressSpec{}
+	swiftProxySpec.Autoscaling = swiftv1.SwiftProxyAutoscalingSpec{}
+
+	return r.swiftProxyCreateOrUpdate(ctx, instance, InternalProxyName(instance), swiftProxySpec)
+}
+
+// InternalProxyName returns the name of the SwiftProxy of the internal
+// endpoint
+func InternalProxyName(instance *swiftv1.Swift) string {
+	return fmt.Sprintf("%s-proxy-internal", instance.Name)
+}
 
+func (r *SwiftReconciler) swiftProxyCreateOrUpdate(ctx context.Context, instance *swiftv1.Swift, name string, swiftProxySpec swiftv1.SwiftProxySpec) (*swiftv1.SwiftProxy, controllerutil.OperationResult, error) {
 	deployment := &swiftv1.SwiftProxy{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      fmt.Sprintf("%s-proxy", instance.Name),
+			Name:      name,
 			Namespace: instance.Namespace,
 		},
 	}
@@ -19,6 +19,7 @@ package controllers
 import (
 	"context"
 	"fmt"
+	"net/url"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -27,10 +28,18 @@ import (
 	"k8s.io/client-go/kubernetes"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 
 	"github.com/openstack-k8s-operators/lib-common/modules/common/condition"
@@ -38,6 +47,8 @@ import (
 	"github.com/openstack-k8s-operators/lib-common/modules/common/endpoint"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/env"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/job"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/networkattachment"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/secret"
 	service "github.com/openstack-k8s-operators/lib-common/modules/common/service"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/util"
@@ -64,6 +75,7 @@ type SwiftProxyReconciler struct {
 //+kubebuilder:rbac:groups=keystone.openstack.org,resources=keystoneendpoints,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=keystone.openstack.org,resources=keystoneservices,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch;create;update;patch;delete;
+//+kubebuilder:rbac:groups=k8s.cni.cncf.io,resources=network-attachment-definitions,verbs=get;list;watch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -96,6 +108,9 @@ func (r *SwiftProxyReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		cl := condition.CreateList(
 			condition.UnknownCondition(condition.ReadyCondition, condition.InitReason, condition.ReadyInitMessage),
 			condition.UnknownCondition(swiftv1beta1.SwiftProxyReadyCondition, condition.InitReason, swiftv1beta1.SwiftProxyReadyInitMessage),
+			condition.UnknownCondition(condition.NetworkAttachmentsReadyCondition, condition.InitReason, condition.NetworkAttachmentsReadyInitMessage),
+			condition.UnknownCondition(condition.KeystoneServiceReadyCondition, condition.InitReason, swiftv1beta1.SwiftProxyKeystoneServiceInitMessage),
+			condition.UnknownCondition(condition.KeystoneEndpointReadyCondition, condition.InitReason, swiftv1beta1.SwiftProxyKeystoneEndpointInitMessage),
 		)
 
 		instance.Status.Conditions.Init(&cl)
@@ -119,23 +134,40 @@ func (r *SwiftProxyReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		return r.reconcileDelete(ctx, instance, helper)
 	}
 
-	serviceLabels := swiftproxy.Labels()
+	serviceLabels := swiftproxy.ServiceLabels(instance)
+
+	// TLS is terminated by the reverse proxy sidecar
+	var protocol *service.Protocol
+	if swiftproxy.TLSSecretName(instance) != "" {
+		https := service.ProtocolHTTPS
+		protocol = &https
+	}
 
 	// Create a Service and endpoints for the proxy
 	var swiftPorts = map[service.Endpoint]endpoint.Data{
 		service.EndpointPublic: {
-			Port: swift.ProxyPort,
-			Path: "/v1/AUTH_%(tenant_id)s",
+			Port:     swift.ProxyPort,
+			Path:     "/v1/AUTH_%(tenant_id)s",
+			Protocol: protocol,
 		},
 		service.EndpointInternal: {
-			Port: swift.ProxyPort,
-			Path: "/v1/AUTH_%(tenant_id)s",
+			Port:     swift.ProxyPort,
+			Path:     "/v1/AUTH_%(tenant_id)s",
+			Protocol: protocol,
 		},
 	}
 
 	apiEndpoints := make(map[string]string)
+	publicURL := ""
+	// Hostnames and load balancer IPs of the endpoints, used as subject
+	// alternative names
+	altNames := []string{}
+	loadBalancerIPs := map[string][]string{}
 
 	for endpointType, data := range swiftPorts {
+		if !swiftproxy.ServesEndpoint(instance, endpointType) {
+			continue
+		}
 		endpointTypeStr := string(endpointType)
 		endpointName := swift.ServiceName + "-" + endpointTypeStr
 		svcOverride := instance.Spec.Override.Service[endpointType]
@@ -151,18 +183,21 @@ func (r *SwiftProxyReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		)
 
 		// Create the service
+		svcDef := service.GenericService(&service.GenericServiceDetails{
+			Name:      endpointName,
+			Namespace: instance.Namespace,
+			Labels:    exportLabels,
+			Selector:  serviceLabels,
+			Port: service.GenericServicePort{
+				Name:     endpointName,
+				Port:     data.Port,
+				Protocol: corev1.ProtocolTCP,
+			},
+		})
+		svcDef.Spec.IPFamilyPolicy = instance.Spec.IPFamilyPolicy
+		svcDef.Spec.IPFamilies = instance.Spec.IPFamilies
 		svc, err := service.NewService(
-			service.GenericService(&service.GenericServiceDetails{
-				Name:      endpointName,
-				Namespace: instance.Namespace,
-				Labels:    exportLabels,
-				Selector:  serviceLabels,
-				Port: service.GenericServicePort{
-					Name:     endpointName,
-					Port:     data.Port,
-					Protocol: corev1.ProtocolTCP,
-				},
-			}),
+			svcDef,
 			5,
 			&svcOverride.OverrideSpec,
 		)
@@ -181,8 +216,17 @@ func (r *SwiftProxyReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 			service.AnnotationEndpointKey: endpointTypeStr,
 		})
 
+		// The public endpoint is exposed by the Ingress of the SwiftProxy
+		// if enabled
+		exposedByIngress := endpointType == service.EndpointPublic && instance.Spec.Ingress.Enabled
+		endpointURL := svcOverride.EndpointURL
+		if exposedByIngress && endpointURL == nil && swiftproxy.IngressEndpointURL(instance) != "" {
+			ingressURL := swiftproxy.IngressEndpointURL(instance)
+			endpointURL = &ingressURL
+		}
+
 		// add Annotation to whether creating an ingress is required or not
-		if endpointType == service.EndpointPublic && svc.GetServiceType() == corev1.ServiceTypeClusterIP {
+		if endpointType == service.EndpointPublic && svc.GetServiceType() == corev1.ServiceTypeClusterIP && !exposedByIngress {
 			svc.AddAnnotation(map[string]string{
 				service.AnnotationIngressCreateKey: "true",
 			})
@@ -217,67 +261,399 @@ func (r *SwiftProxyReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		}
 		// create service - end
 
-		// TODO: TLS, pass in https as protocol, create TLS cert
 		apiEndpoints[string(endpointType)], err = svc.GetAPIEndpoint(
-			svcOverride.EndpointURL, data.Protocol, data.Path)
+			endpointURL, data.Protocol, data.Path)
 		if err != nil {
 			return ctrl.Result{}, err
 		}
+		if endpointType == service.EndpointPublic {
+			publicURL, err = svc.GetAPIEndpoint(endpointURL, data.Protocol, "")
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+		}
+
+		altNames = append(altNames, svc.GetServiceHostname())
+		if endpointURL != nil {
+			parsedURL, err := url.Parse(*endpointURL)
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+			altNames = append(altNames, parsedURL.Hostname())
+		}
+		// Load balancers might provide a hostname instead of an IP
+		for _, ip := range svc.GetExternalIPs() {
+			if ip != "" {
+				altNames = append(altNames, ip)
+				loadBalancerIPs[endpointTypeStr] = append(loadBalancerIPs[endpointTypeStr], ip)
+			}
+		}
+
+		if endpointType == service.EndpointPublic {
+			if instance.Spec.Ingress.Enabled {
+				err = swiftproxy.EnsureIngress(ctx, helper, instance, exportLabels, endpointName)
+			} else {
+				err = swiftproxy.DeleteIngress(ctx, helper, instance)
+			}
+			if err != nil {
+				instance.Status.Conditions.Set(condition.FalseCondition(
+					condition.ExposeServiceReadyCondition,
+					condition.ErrorReason,
+					condition.SeverityWarning,
+					condition.ExposeServiceReadyErrorMessage,
+					err.Error()))
+				return ctrl.Result{}, err
+			}
+		}
 	}
+	instance.Status.LoadBalancerIPs = loadBalancerIPs
 	instance.Status.Conditions.MarkTrue(condition.ExposeServiceReadyCondition, condition.ExposeServiceReadyMessage)
 
-	// Create Keystone Service
-	serviceSpec := keystonev1.KeystoneServiceSpec{
-		ServiceType:        swift.ServiceType,
-		ServiceName:        swift.ServiceName,
-		ServiceDescription: swift.ServiceDescription,
-		Enabled:            true,
-		ServiceUser:        instance.Spec.ServiceUser,
-		Secret:             instance.Spec.Secret,
-		PasswordSelector:   instance.Spec.PasswordSelectors.Service,
-	}
-	keystoneService := keystonev1.NewKeystoneService(serviceSpec, instance.Namespace, serviceLabels, 10*time.Second)
-	ctrlResult, err := keystoneService.CreateOrPatch(ctx, helper)
-	if err != nil {
-		return ctrlResult, err
+	// Keystone is not used in the TempAuth mode
+	if err := swiftproxy.ValidateTempAuth(instance); err != nil {
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			swiftv1beta1.SwiftProxyReadyCondition,
+			condition.ErrorReason,
+			condition.SeverityWarning,
+			swiftv1beta1.SwiftProxyReadyErrorMessage,
+			err.Error()))
+		if err := r.Status().Update(ctx, instance); err != nil {
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{}, err
 	}
+	var ctrlResult ctrl.Result
+	keystonePublicURL := ""
+	keystoneInternalURL := ""
+	password := ""
+	tempAuthUsers := []string{}
+	if swiftproxy.TempAuth(instance) {
+		instance.Status.Conditions.Remove(condition.KeystoneServiceReadyCondition)
+		instance.Status.Conditions.Remove(condition.KeystoneEndpointReadyCondition)
 
-	// Create Keystone endpoints
-	endpointSpec := keystonev1.KeystoneEndpointSpec{
-		ServiceName: swift.ServiceName,
-		Endpoints:   apiEndpoints,
+		usersSecret, _, err := secret.GetSecret(ctx, helper, instance.Spec.TempAuth.UsersSecret, instance.Namespace)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				r.Log.Info(fmt.Sprintf("Secret %s not found", instance.Spec.TempAuth.UsersSecret))
+				return ctrl.Result{RequeueAfter: 10 * time.Second}, nil
+			}
+			return ctrl.Result{}, err
+		}
+		tempAuthUsers, err = swiftproxy.TempAuthUsers(usersSecret)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+	} else {
+		if swiftproxy.OwnsKeystoneService(instance) {
+			// Create Keystone Service
+			serviceSpec := keystonev1.KeystoneServiceSpec{
+				ServiceType:        swift.ServiceType,
+				ServiceName:        swift.ServiceName,
+				ServiceDescription: swift.ServiceDescription,
+				Enabled:            true,
+				ServiceUser:        instance.Spec.ServiceUser,
+				Secret:             instance.Spec.Secret,
+				PasswordSelector:   instance.Spec.PasswordSelectors.Service,
+			}
+			keystoneService := keystonev1.NewKeystoneService(serviceSpec, instance.Namespace, serviceLabels, 10*time.Second)
+			ctrlResult, err = keystoneService.CreateOrPatch(ctx, helper)
+			if err != nil {
+				instance.Status.Conditions.Set(condition.FalseCondition(
+					condition.KeystoneServiceReadyCondition,
+					condition.ErrorReason,
+					condition.SeverityWarning,
+					swiftv1beta1.SwiftProxyKeystoneServiceErrorMessage,
+					err.Error()))
+				if err := r.Status().Update(ctx, instance); err != nil {
+					return ctrl.Result{}, err
+				}
+				return ctrlResult, err
+			}
+			// The service user is created by the KeystoneService, wait until it
+			// is usable before rendering the configuration
+			if c := keystoneService.GetConditions().Mirror(condition.KeystoneServiceReadyCondition); c != nil {
+				instance.Status.Conditions.Set(c)
+			}
+			if (ctrlResult != ctrl.Result{}) {
+				if err := r.Status().Update(ctx, instance); err != nil {
+					return ctrl.Result{}, err
+				}
+				return ctrlResult, nil
+			}
+		} else {
+			// The service user is created by the KeystoneService of the
+			// public proxies
+			keystoneService, err := keystonev1.GetKeystoneServiceWithName(ctx, helper, swift.ServiceName, instance.Namespace)
+			if err != nil && !apierrors.IsNotFound(err) {
+				return ctrl.Result{}, err
+			}
+			if err != nil || !keystoneService.IsReady() {
+				instance.Status.Conditions.Set(condition.FalseCondition(
+					condition.KeystoneServiceReadyCondition,
+					condition.RequestedReason,
+					condition.SeverityInfo,
+					swiftv1beta1.SwiftProxyKeystoneServiceWaitingMessage,
+					swift.ServiceName))
+				if err := r.Status().Update(ctx, instance); err != nil {
+					return ctrl.Result{}, err
+				}
+				return ctrl.Result{RequeueAfter: 10 * time.Second}, nil
+			}
+			instance.Status.Conditions.MarkTrue(condition.KeystoneServiceReadyCondition, swiftv1beta1.SwiftProxyKeystoneServiceReadyMessage, swift.ServiceName)
+		}
+
+		// Create Keystone endpoints
+		endpointSpec := keystonev1.KeystoneEndpointSpec{
+			ServiceName: swift.ServiceName,
+			Endpoints:   apiEndpoints,
+		}
+		keystoneEndpoint := keystonev1.NewKeystoneEndpoint(
+			swiftproxy.KeystoneEndpointName(instance),
+			instance.Namespace,
+			endpointSpec,
+			serviceLabels,
+			10)
+		ctrlResult, err = keystoneEndpoint.CreateOrPatch(ctx, helper)
+		if err != nil {
+			instance.Status.Conditions.Set(condition.FalseCondition(
+				condition.KeystoneEndpointReadyCondition,
+				condition.ErrorReason,
+				condition.SeverityWarning,
+				swiftv1beta1.SwiftProxyKeystoneEndpointErrorMessage,
+				err.Error()))
+			if err := r.Status().Update(ctx, instance); err != nil {
+				return ctrl.Result{}, err
+			}
+			return ctrlResult, err
+		}
+		if c := keystoneEndpoint.GetConditions().Mirror(condition.KeystoneEndpointReadyCondition); c != nil {
+			instance.Status.Conditions.Set(c)
+		}
+		if (ctrlResult != ctrl.Result{}) {
+			if err := r.Status().Update(ctx, instance); err != nil {
+				return ctrl.Result{}, err
+			}
+			return ctrlResult, nil
+		}
+
+		// Get the Keystone endpoint URLs
+		keystoneAPI, err := keystonev1.GetKeystoneAPI(ctx, helper, instance.Namespace, map[string]string{})
+		if err != nil {
+			return ctrlResult, err
+		}
+		keystonePublicURL, err = keystoneAPI.GetEndpoint(endpoint.EndpointPublic)
+		if err != nil {
+			return ctrlResult, err
+		}
+		keystoneInternalURL, err = keystoneAPI.GetEndpoint(endpoint.EndpointInternal)
+		if err != nil {
+			return ctrlResult, err
+		}
+
+		// Get the service password
+		sps, _, err := secret.GetSecret(ctx, helper, instance.Spec.Secret, instance.Namespace)
+		if err != nil {
+			return ctrlResult, err
+		}
+		passwordData, ok := sps.Data[instance.Spec.PasswordSelectors.Service]
+		if !ok {
+			return ctrl.Result{}, fmt.Errorf("key %s not found in Secret %s", instance.Spec.PasswordSelectors.Service, instance.Spec.Secret)
+		}
+		password = string(passwordData)
+	}
+
+	// Reject pipelines that can't be loaded by the proxy server,
+	// affinities of regions that are not in the rings and invalid
+	// forwarded headers settings
+	deviceConfigMap := &corev1.ConfigMap{}
+	err = helper.GetClient().Get(ctx, types.NamespacedName{Name: swiftv1beta1.DeviceConfigMapName, Namespace: instance.Namespace}, deviceConfigMap)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return ctrl.Result{}, err
+	}
+	devices := swift.DeviceLists(deviceConfigMap.Data)
+	err = swiftproxy.ValidatePipeline(instance)
+	if err == nil {
+		err = swiftproxy.ValidateReplicaAffinity(instance, swiftproxy.RingLocations(devices))
+	}
+	if err == nil {
+		err = swiftproxy.ValidateForwardedHeaders(instance)
 	}
-	keystoneEndpoint := keystonev1.NewKeystoneEndpoint(
-		swift.ServiceName,
-		instance.Namespace,
-		endpointSpec,
-		serviceLabels,
-		10)
-	ctrlResult, err = keystoneEndpoint.CreateOrPatch(ctx, helper)
 	if err != nil {
-		return ctrlResult, err
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			swiftv1beta1.SwiftProxyReadyCondition,
+			condition.ErrorReason,
+			condition.SeverityWarning,
+			swiftv1beta1.SwiftProxyReadyErrorMessage,
+			err.Error()))
+		if err := r.Status().Update(ctx, instance); err != nil {
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{}, err
 	}
 
-	// Get the Keystone endpoint URLs
-	keystoneAPI, err := keystonev1.GetKeystoneAPI(ctx, helper, instance.Namespace, map[string]string{})
-	if err != nil {
-		return ctrlResult, err
+	// Get the IDs of the Barbican secrets or KMIP keys used as encryption
+	// root secrets
+	var rootSecrets map[string]string
+	if instance.Spec.Encryption.Enabled {
+		if instance.Spec.Encryption.KeySecret == "" {
+			return ctrl.Result{}, fmt.Errorf("encryption requires keySecret")
+		}
+		if instance.Spec.Encryption.Keymaster == "KMIP" && instance.Spec.Encryption.KMIP.Host == "" {
+			return ctrl.Result{}, fmt.Errorf("the KMIP keymaster requires kmip.host")
+		}
+		keySecret, _, err := secret.GetSecret(ctx, helper, instance.Spec.Encryption.KeySecret, instance.Namespace)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				r.Log.Info(fmt.Sprintf("Secret %s not found", instance.Spec.Encryption.KeySecret))
+				return ctrl.Result{RequeueAfter: 10 * time.Second}, nil
+			}
+			return ctrl.Result{}, err
+		}
+		rootSecrets, err = swiftproxy.RootSecrets(instance, keySecret)
+		if err != nil {
+			instance.Status.Conditions.Set(condition.FalseCondition(
+				swiftv1beta1.SwiftProxyEncryptionCondition,
+				condition.ErrorReason,
+				condition.SeverityWarning,
+				swiftv1beta1.SwiftProxyEncryptionErrorMessage,
+				err.Error()))
+			if err := r.Status().Update(ctx, instance); err != nil {
+				return ctrl.Result{}, err
+			}
+			return ctrl.Result{}, err
+		}
 	}
-	keystonePublicURL, err := keystoneAPI.GetEndpoint(endpoint.EndpointPublic)
-	if err != nil {
-		return ctrlResult, err
+
+	// Get the server list and TLS setting of the shared Memcached instance
+	var memcached *swift.Memcached
+	if instance.Spec.MemcachedInstance != "" {
+		memcached, err = swift.GetMemcached(ctx, helper, instance.Spec.MemcachedInstance, instance.Namespace)
+		if err != nil && !apierrors.IsNotFound(err) {
+			instance.Status.Conditions.Set(condition.FalseCondition(
+				condition.MemcachedReadyCondition,
+				condition.ErrorReason,
+				condition.SeverityWarning,
+				condition.MemcachedReadyErrorMessage,
+				err.Error()))
+			if err := r.Status().Update(ctx, instance); err != nil {
+				return ctrl.Result{}, err
+			}
+			return ctrl.Result{}, err
+		}
+		if memcached == nil || len(memcached.Servers) == 0 {
+			r.Log.Info(fmt.Sprintf("Memcached %s not ready yet", instance.Spec.MemcachedInstance))
+			instance.Status.Conditions.Set(condition.FalseCondition(
+				condition.MemcachedReadyCondition,
+				condition.RequestedReason,
+				condition.SeverityInfo,
+				condition.MemcachedReadyWaitingMessage))
+			if err := r.Status().Update(ctx, instance); err != nil {
+				return ctrl.Result{}, err
+			}
+			return ctrl.Result{RequeueAfter: 10 * time.Second}, nil
+		}
+		instance.Status.Conditions.MarkTrue(condition.MemcachedReadyCondition, condition.MemcachedReadyMessage)
+	} else {
+		instance.Status.Conditions.Remove(condition.MemcachedReadyCondition)
 	}
-	keystoneInternalURL, err := keystoneAPI.GetEndpoint(endpoint.EndpointInternal)
-	if err != nil {
-		return ctrlResult, err
+
+	// Get the transport URL of the RabbitMQ cluster receiving the
+	// notifications of the ceilometer middleware
+	transportURL := ""
+	if instance.Spec.Ceilometer.Enabled {
+		transportSecretName, err := swiftproxy.EnsureTransportURL(ctx, helper, instance, serviceLabels)
+		if err != nil {
+			instance.Status.Conditions.Set(condition.FalseCondition(
+				condition.RabbitMqTransportURLReadyCondition,
+				condition.ErrorReason,
+				condition.SeverityWarning,
+				condition.RabbitMqTransportURLReadyErrorMessage,
+				err.Error()))
+			if err := r.Status().Update(ctx, instance); err != nil {
+				return ctrl.Result{}, err
+			}
+			return ctrl.Result{}, err
+		}
+		if transportSecretName == "" {
+			r.Log.Info(fmt.Sprintf("TransportURL of %s not ready yet", instance.Name))
+			instance.Status.Conditions.Set(condition.FalseCondition(
+				condition.RabbitMqTransportURLReadyCondition,
+				condition.RequestedReason,
+				condition.SeverityInfo,
+				condition.RabbitMqTransportURLReadyRunningMessage))
+			if err := r.Status().Update(ctx, instance); err != nil {
+				return ctrl.Result{}, err
+			}
+			return ctrl.Result{RequeueAfter: 10 * time.Second}, nil
+		}
+		transportSecret, _, err := secret.GetSecret(ctx, helper, transportSecretName, instance.Namespace)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		transportURL, err = swiftproxy.TransportURL(transportSecret)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		instance.Status.Conditions.MarkTrue(condition.RabbitMqTransportURLReadyCondition, condition.RabbitMqTransportURLReadyMessage)
+	} else {
+		if err := swiftproxy.DeleteTransportURL(ctx, helper, instance); err != nil {
+			return ctrl.Result{}, err
+		}
+		instance.Status.Conditions.Remove(condition.RabbitMqTransportURLReadyCondition)
 	}
 
-	// Get the service password
-	sps, _, err := secret.GetSecret(ctx, helper, instance.Spec.Secret, instance.Namespace)
-	if err != nil {
-		return ctrlResult, err
+	// Request the certificate used to terminate TLS and wait until it is
+	// available
+	tlsSecretHash := ""
+	if tlsSecret := swiftproxy.TLSSecretName(instance); tlsSecret != "" {
+		if instance.Spec.TLS.IssuerRef != nil {
+			issued, err := swiftproxy.EnsureCertificate(ctx, helper, instance, serviceLabels, altNames)
+			if err != nil {
+				instance.Status.Conditions.Set(condition.FalseCondition(
+					condition.TLSInputReadyCondition,
+					condition.ErrorReason,
+					condition.SeverityWarning,
+					condition.TLSInputErrorMessage,
+					err.Error()))
+				if err := r.Status().Update(ctx, instance); err != nil {
+					return ctrl.Result{}, err
+				}
+				return ctrl.Result{}, err
+			}
+			if !issued {
+				instance.Status.Conditions.Set(condition.FalseCondition(
+					condition.TLSInputReadyCondition,
+					condition.RequestedReason,
+					condition.SeverityInfo,
+					swiftv1beta1.SwiftProxyTLSInputRunningMessage,
+					instance.Name))
+				if err := r.Status().Update(ctx, instance); err != nil {
+					return ctrl.Result{}, err
+				}
+				return ctrl.Result{RequeueAfter: time.Second * 10}, nil
+			}
+		}
+
+		_, tlsSecretHash, err = secret.GetSecret(ctx, helper, tlsSecret, instance.Namespace)
+		if err != nil {
+			instance.Status.Conditions.Set(condition.FalseCondition(
+				condition.TLSInputReadyCondition,
+				condition.ErrorReason,
+				condition.SeverityWarning,
+				condition.TLSInputErrorMessage,
+				err.Error()))
+			if err := r.Status().Update(ctx, instance); err != nil {
+				return ctrl.Result{}, err
+			}
+			if apierrors.IsNotFound(err) {
+				return ctrl.Result{RequeueAfter: time.Second * 10}, nil
+			}
+			return ctrl.Result{}, err
+		}
+		instance.Status.Conditions.MarkTrue(condition.TLSInputReadyCondition, swiftv1beta1.SwiftProxyTLSInputReadyMessage)
+	} else {
+		instance.Status.Conditions.Remove(condition.TLSInputReadyCondition)
 	}
-	password := string(sps.Data[instance.Spec.PasswordSelectors.Service])
 
 	// Create a Secret populated with content from templates/
 	envVars := make(map[string]env.Setter)
@@ -286,15 +662,168 @@ func (r *SwiftProxyReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		serviceLabels,
 		keystonePublicURL,
 		keystoneInternalURL,
+		publicURL,
 		password,
+		rootSecrets,
+		transportURL,
+		tempAuthUsers,
+		memcached,
 	)
 	err = secret.EnsureSecrets(ctx, helper, instance, tpl, &envVars)
 	if err != nil {
 		return ctrl.Result{}, err
 	}
 
+	// Check that all NetworkAttachmentDefinitions exist before the pods are
+	// attached to them
+	for _, netAtt := range instance.Spec.NetworkAttachments {
+		_, err = networkattachment.GetNADWithName(ctx, helper, netAtt, instance.Namespace)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				instance.Status.Conditions.Set(condition.FalseCondition(
+					condition.NetworkAttachmentsReadyCondition,
+					condition.RequestedReason,
+					condition.SeverityInfo,
+					condition.NetworkAttachmentsReadyWaitingMessage,
+					netAtt))
+				if err := r.Status().Update(ctx, instance); err != nil {
+					return ctrl.Result{}, err
+				}
+				return ctrl.Result{RequeueAfter: time.Second * 10}, fmt.Errorf("network-attachment-definition %s not found", netAtt)
+			}
+			instance.Status.Conditions.Set(condition.FalseCondition(
+				condition.NetworkAttachmentsReadyCondition,
+				condition.ErrorReason,
+				condition.SeverityWarning,
+				condition.NetworkAttachmentsReadyErrorMessage,
+				err.Error()))
+			return ctrl.Result{}, err
+		}
+	}
+
+	serviceAnnotations, err := networkattachment.CreateNetworksAnnotation(instance.Namespace, instance.Spec.NetworkAttachments)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed create network annotation from %s: %w",
+			instance.Spec.NetworkAttachments, err)
+	}
+
+	// Restart the proxies if the configuration changes, eg. when the
+	// service password or the certificate is rotated
+	if tlsSecretHash != "" {
+		envVars["tls"] = env.SetValue(tlsSecretHash)
+	}
+	if kmipCertSecret := swiftproxy.KMIPCertSecretName(instance); kmipCertSecret != "" {
+		_, kmipCertHash, err := secret.GetSecret(ctx, helper, kmipCertSecret, instance.Namespace)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				r.Log.Info(fmt.Sprintf("Secret %s not found", kmipCertSecret))
+				return ctrl.Result{RequeueAfter: 10 * time.Second}, nil
+			}
+			return ctrl.Result{}, err
+		}
+		envVars["kmip"] = env.SetValue(kmipCertHash)
+	}
+	if instance.Spec.CABundleSecretName != "" {
+		_, caBundleHash, err := secret.GetSecret(ctx, helper, instance.Spec.CABundleSecretName, instance.Namespace)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				r.Log.Info(fmt.Sprintf("Secret %s not found", instance.Spec.CABundleSecretName))
+				return ctrl.Result{RequeueAfter: 10 * time.Second}, nil
+			}
+			return ctrl.Result{}, err
+		}
+		envVars["cabundle"] = env.SetValue(caBundleHash)
+	}
+	configHash, err := util.ObjectHash(env.MergeEnvs([]corev1.EnvVar{}, envVars))
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	serviceAnnotations = util.MergeStringMaps(serviceAnnotations, map[string]string{swift.ConfigHashAnnotation: configHash})
+
+	// Report deprecated options, this does not affect the Ready condition
+	if instance.Spec.S3API.Enabled && instance.Spec.S3API.LegacyClients {
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			swiftv1beta1.SwiftProxyDeprecationCondition,
+			swiftv1beta1.DeprecatedReason,
+			condition.SeverityWarning,
+			swiftv1beta1.SwiftProxyS3LegacyClientsMessage))
+	} else {
+		instance.Status.Conditions.MarkTrue(swiftv1beta1.SwiftProxyDeprecationCondition, swiftv1beta1.SwiftProxyDeprecationReadyMessage)
+	}
+
+	// Roll out a new proxy image to the canaries first, the other proxies
+	// keep the stable image until the canaries passed the analysis
+	previousRollout := instance.Status.Rollout.DeepCopy()
+	canaryResult, canaryErr := swiftproxy.ReconcileCanary(ctx, helper, instance, serviceLabels, serviceAnnotations)
+	switch rollout := instance.Status.Rollout; {
+	case !swiftproxy.Canary(instance):
+		instance.Status.Conditions.Remove(swiftv1beta1.SwiftProxyRolloutCondition)
+	case rollout.StableImage == instance.Spec.ContainerImageProxy:
+		instance.Status.Conditions.MarkTrue(
+			swiftv1beta1.SwiftProxyRolloutCondition, swiftv1beta1.SwiftProxyRolloutReadyMessage, rollout.StableImage)
+	case rollout.Phase == swiftv1beta1.RolloutPhaseRolledBack:
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			swiftv1beta1.SwiftProxyRolloutCondition,
+			condition.ErrorReason,
+			condition.SeverityWarning,
+			swiftv1beta1.SwiftProxyRolloutErrorMessage,
+			rollout.CanaryImage, rollout.Message))
+	default:
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			swiftv1beta1.SwiftProxyRolloutCondition,
+			condition.RequestedReason,
+			condition.SeverityInfo,
+			swiftv1beta1.SwiftProxyRolloutRunningMessage,
+			rollout.CanaryImage, rollout.Phase))
+	}
+	// The rollout status is stored right away, later steps may return early
+	// before the final status update, eg. while the stable Deployment rolls
+	// out
+	if !equality.Semantic.DeepEqual(*previousRollout, instance.Status.Rollout) {
+		if err := r.Status().Update(ctx, instance); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+	if canaryErr != nil {
+		return ctrl.Result{}, canaryErr
+	}
+
 	// Create Deployment
-	depl := deployment.NewDeployment(swiftproxy.Deployment(instance, serviceLabels), 5*time.Second)
+	if err := swiftproxy.DeleteStaleDeployment(ctx, helper, instance, swiftproxy.StableLabels(serviceLabels)); err != nil {
+		return ctrl.Result{}, err
+	}
+	deploymentDef := swiftproxy.StableDeployment(instance, serviceLabels, serviceAnnotations)
+
+	// The replicas are managed by KEDA if autoscaling is enabled
+	if instance.Spec.Autoscaling.Enabled {
+		err = swiftproxy.EnsureScaledObject(ctx, helper, instance, serviceLabels)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		current := &appsv1.Deployment{}
+		err = r.Client.Get(ctx, types.NamespacedName{Name: deploymentDef.Name, Namespace: deploymentDef.Namespace}, current)
+		if err == nil {
+			deploymentDef.Spec.Replicas = current.Spec.Replicas
+		} else if !apierrors.IsNotFound(err) {
+			return ctrl.Result{}, err
+		}
+	} else {
+		err = swiftproxy.DeleteScaledObject(ctx, helper, instance)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	if instance.Spec.PodDisruptionBudget.Enabled && deploymentDef.Spec.Replicas != nil && *deploymentDef.Spec.Replicas > 1 {
+		err = swiftproxy.EnsurePodDisruptionBudget(ctx, helper, instance, serviceLabels)
+	} else {
+		err = swiftproxy.DeletePodDisruptionBudget(ctx, helper, instance)
+	}
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	depl := deployment.NewDeployment(deploymentDef, 5*time.Second)
 	ctrlResult, err = depl.CreateOrPatch(ctx, helper)
 	if err != nil {
 		return ctrlResult, err
@@ -303,7 +832,109 @@ func (r *SwiftProxyReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 	}
 
 	instance.Status.ReadyCount = depl.GetDeployment().Status.ReadyReplicas
+
+	// Report the progress of a root secret rotation. The root secrets are
+	// part of the configuration, thus all proxies use the active one once
+	// the Deployment is rolled out
+	if instance.Spec.Encryption.Enabled {
+		active := swiftproxy.ActiveRootSecretID(instance)
+		current := depl.GetDeployment()
+		replicas := int32(1)
+		if current.Spec.Replicas != nil {
+			replicas = *current.Spec.Replicas
+		}
+		if current.Status.ObservedGeneration >= current.Generation &&
+			current.Status.UpdatedReplicas == replicas && current.Status.Replicas == replicas {
+			instance.Status.ActiveRootSecretID = active
+			instance.Status.Conditions.MarkTrue(
+				swiftv1beta1.SwiftProxyEncryptionCondition, swiftv1beta1.SwiftProxyEncryptionReadyMessage, active)
+		} else if instance.Status.ActiveRootSecretID != active {
+			instance.Status.Conditions.Set(condition.FalseCondition(
+				swiftv1beta1.SwiftProxyEncryptionCondition,
+				condition.RequestedReason,
+				condition.SeverityInfo,
+				swiftv1beta1.SwiftProxyEncryptionRunningMessage,
+				active, current.Status.UpdatedReplicas, replicas))
+		}
+	} else {
+		instance.Status.ActiveRootSecretID = ""
+		instance.Status.Conditions.Remove(swiftv1beta1.SwiftProxyEncryptionCondition)
+	}
 	if instance.Status.ReadyCount > 0 {
+		// Verify that all pods are attached to the NetworkAttachments
+		networkReady, networkAttachmentStatus, err := networkattachment.VerifyNetworkStatusFromAnnotation(
+			ctx, helper, instance.Spec.NetworkAttachments, serviceLabels, instance.Status.ReadyCount)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		instance.Status.NetworkAttachments = networkAttachmentStatus
+		if networkReady {
+			instance.Status.Conditions.MarkTrue(condition.NetworkAttachmentsReadyCondition, condition.NetworkAttachmentsReadyMessage)
+		} else {
+			err := fmt.Errorf("not all pods have interfaces with ips as configured in NetworkAttachments: %s", instance.Spec.NetworkAttachments)
+			instance.Status.Conditions.Set(condition.FalseCondition(
+				condition.NetworkAttachmentsReadyCondition,
+				condition.ErrorReason,
+				condition.SeverityWarning,
+				condition.NetworkAttachmentsReadyErrorMessage,
+				err.Error()))
+			if err := r.Status().Update(ctx, instance); err != nil {
+				return ctrl.Result{}, err
+			}
+			return ctrl.Result{RequeueAfter: time.Second * 10}, nil
+		}
+
+		// Set the temp URL keys of the service account once the proxies
+		// are available
+		if instance.Spec.TempURL.KeySecret != "" {
+			_, keySecretHash, err := secret.GetSecret(ctx, helper, instance.Spec.TempURL.KeySecret, instance.Namespace)
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+			if instance.Status.Hash == nil {
+				instance.Status.Hash = map[string]string{}
+			}
+			keyJob := job.NewJob(
+				swiftproxy.TempURLKeyJob(instance, serviceLabels, keystoneInternalURL, keySecretHash),
+				swiftv1beta1.TempURLKeyHash, false, 5*time.Second, instance.Status.Hash[swiftv1beta1.TempURLKeyHash])
+			ctrlResult, err := keyJob.DoJob(ctx, helper)
+			if (ctrlResult != ctrl.Result{}) {
+				if err := r.Status().Update(ctx, instance); err != nil {
+					return ctrl.Result{}, err
+				}
+				return ctrlResult, nil
+			}
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+			if keyJob.HasChanged() {
+				instance.Status.Hash[swiftv1beta1.TempURLKeyHash] = keyJob.GetHash()
+			}
+		}
+
+		// Set the default quota on the accounts without a quota
+		if instance.Spec.Quotas.AccountQuotas && instance.Spec.Quotas.DefaultAccountQuota != nil {
+			if instance.Status.Hash == nil {
+				instance.Status.Hash = map[string]string{}
+			}
+			quotaJob := job.NewJob(
+				swiftproxy.AccountQuotaJob(instance, serviceLabels, keystoneInternalURL),
+				swiftv1beta1.AccountQuotaHash, false, 5*time.Second, instance.Status.Hash[swiftv1beta1.AccountQuotaHash])
+			ctrlResult, err := quotaJob.DoJob(ctx, helper)
+			if (ctrlResult != ctrl.Result{}) {
+				if err := r.Status().Update(ctx, instance); err != nil {
+					return ctrl.Result{}, err
+				}
+				return ctrlResult, nil
+			}
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+			if quotaJob.HasChanged() {
+				instance.Status.Hash[swiftv1beta1.AccountQuotaHash] = quotaJob.GetHash()
+			}
+		}
+
 		instance.Status.Conditions.MarkTrue(condition.ReadyCondition, condition.ReadyMessage)
 		instance.Status.Conditions.MarkTrue(swiftv1beta1.SwiftProxyReadyCondition, condition.ReadyMessage)
 		if err := r.Status().Update(ctx, instance); err != nil {
@@ -312,19 +943,50 @@ func (r *SwiftProxyReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 	}
 
 	r.Log.Info(fmt.Sprintf("Reconciled SwiftProxy '%s' successfully", instance.Name))
-	return ctrl.Result{}, nil
+	return canaryResult, nil
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *SwiftProxyReconciler) SetupWithManager(mgr ctrl.Manager) error {
+
+	// The Secrets with the service password, the certificate and the temp
+	// URL keys are not owned by the SwiftProxy, reconcile all instances
+	// referencing them to pick up a rotation
+	secretFilter := func(o client.Object) []reconcile.Request {
+		result := []reconcile.Request{}
+		swiftProxies := &swiftv1beta1.SwiftProxyList{}
+		listOpts := []client.ListOption{client.InNamespace(o.GetNamespace())}
+		err := r.Client.List(context.Background(), swiftProxies, listOpts...)
+		if err != nil {
+			return nil
+		}
+		for _, cr := range swiftProxies.Items {
+			if cr.Spec.Secret != o.GetName() && swiftproxy.TLSSecretName(&cr) != o.GetName() && cr.Spec.TempURL.KeySecret != o.GetName() &&
+				cr.Spec.Encryption.KeySecret != o.GetName() && swiftproxy.KMIPCertSecretName(&cr) != o.GetName() &&
+				cr.Spec.TempAuth.UsersSecret != o.GetName() && cr.Spec.CABundleSecretName != o.GetName() {
+				continue
+			}
+			name := client.ObjectKey{
+				Namespace: o.GetNamespace(),
+				Name:      cr.Name,
+			}
+			result = append(result, reconcile.Request{NamespacedName: name})
+		}
+		return result
+	}
+
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&swiftv1beta1.SwiftProxy{}).
 		Owns(&corev1.Secret{}).
 		Owns(&keystonev1.KeystoneService{}).
 		Owns(&keystonev1.KeystoneEndpoint{}).
 		Owns(&appsv1.Deployment{}).
+		Owns(&batchv1.Job{}).
 		Owns(&corev1.Service{}).
 		Owns(&corev1.ConfigMap{}).
+		Owns(&networkingv1.Ingress{}).
+		Owns(&policyv1.PodDisruptionBudget{}).
+		Watches(&source.Kind{Type: &corev1.Secret{}}, handler.EnqueueRequestsFromMapFunc(secretFilter)).
 		Complete(r)
 }
 
@@ -332,7 +994,7 @@ func (r *SwiftProxyReconciler) reconcileDelete(ctx context.Context, instance *sw
 	r.Log.Info(fmt.Sprintf("Reconciling Service '%s' delete", instance.Name))
 
 	// Remove the finalizer from our KeystoneEndpoint CR
-	keystoneEndpoint, err := keystonev1.GetKeystoneEndpointWithName(ctx, helper, swift.ServiceName, instance.Namespace)
+	keystoneEndpoint, err := keystonev1.GetKeystoneEndpointWithName(ctx, helper, swiftproxy.KeystoneEndpointName(instance), instance.Namespace)
 	if err != nil && !apierrors.IsNotFound(err) {
 		return ctrl.Result{}, err
 	}
@@ -351,7 +1013,7 @@ func (r *SwiftProxyReconciler) reconcileDelete(ctx context.Context, instance *sw
 		return ctrl.Result{}, err
 	}
 
-	if err == nil {
+	if err == nil && swiftproxy.OwnsKeystoneService(instance) {
 		controllerutil.RemoveFinalizer(keystoneService, helper.GetFinalizer())
 		if err = helper.GetClient().Update(ctx, keystoneService); err != nil && !apierrors.IsNotFound(err) {
 			return ctrl.Result{}, err
@@ -19,6 +19,8 @@ package controllers
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -35,13 +37,18 @@ import (
 	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/job"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/secret"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/util"
 
 	swiftv1beta1 "github.com/openstack-k8s-operators/swift-operator/api/v1beta1"
+	"github.com/openstack-k8s-operators/swift-operator/pkg/swift"
 	"github.com/openstack-k8s-operators/swift-operator/pkg/swiftring"
+	"github.com/openstack-k8s-operators/swift-operator/pkg/swiftstorage"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
 )
 
@@ -57,6 +64,8 @@ type SwiftRingReconciler struct {
 //+kubebuilder:rbac:groups=swift.openstack.org,resources=swiftrings/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=swift.openstack.org,resources=swiftrings/finalizers,verbs=update
 //+kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=swift.openstack.org,resources=swiftstorages,verbs=get;list;watch
+//+kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch
 //+kubebuilder:rbac:groups=*,resources=configmaps,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=clusterroles,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=clusterrolebindings,verbs=get;list;watch;create;update;patch;delete
@@ -80,6 +89,7 @@ func (r *SwiftRingReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 			// If the custom resource is not found then, it usually means that it was deleted or not created
 			// In this way, we will stop the reconciliation
 			r.Log.Info("SwiftRing resource not found. Ignoring since object must be deleted")
+			swiftring.DeleteMetrics(req.Namespace, req.Name)
 			return ctrl.Result{}, nil
 		}
 		// Error reading the object - requeue the request.
@@ -130,34 +140,767 @@ func (r *SwiftRingReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 	if instance.Status.Hash == nil {
 		instance.Status.Hash = map[string]string{}
 	}
-	ringCreateHash := instance.Status.Hash[swiftv1beta1.RingCreateHash]
 
-	// Check if the device list ConfigMap did change and if so, delete the
-	// rebalance Job. This will result in a new Job that rebalances with
-	// the updated device list
-	_, deviceListHash, err := configmap.GetConfigMapAndHashWithName(ctx, helper, swiftv1beta1.DeviceConfigMapName, instance.Namespace)
+	// The rings are not rebalanced with an invalid part power, composite
+	// ring, storage policy or import
+	err = swiftring.ValidatePartPower(instance)
+	if err == nil {
+		err = swiftring.ValidateComposite(instance)
+	}
+	if err == nil {
+		err = swiftring.ValidateStoragePolicies(instance)
+	}
+	if err == nil {
+		err = swiftring.ValidateImport(instance)
+	}
 	if err != nil {
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			swiftv1beta1.SwiftRingReadyCondition,
+			condition.ErrorReason,
+			condition.SeverityWarning,
+			swiftv1beta1.SwiftRingReadyErrorMessage,
+			err.Error()))
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			condition.ReadyCondition,
+			condition.ErrorReason,
+			condition.SeverityWarning,
+			swiftv1beta1.SwiftRingReadyErrorMessage,
+			err.Error()))
+		if err := r.Status().Update(ctx, instance); err != nil {
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{}, nil
+	}
+
+	// A saved ring version is published as long as rollbackToVersion is
+	// set, the rings are not rebalanced meanwhile
+	if instance.Spec.RollbackToVersion != nil {
+		ctrlResult, err := r.rollbackRings(ctx, helper, instance)
+		if err != nil || (ctrlResult != ctrl.Result{}) {
+			return ctrlResult, err
+		}
+	} else {
+		instance.Status.Rollback = nil
+
+		// A partition power increase publishes the rings of its steps and
+		// relinks the data of all storage pods in between, the rings are not
+		// rebalanced meanwhile
+		if ctrlResult, err := r.reconcilePartPowerIncrease(ctx, helper, instance, serviceLabels); err != nil || (ctrlResult != ctrl.Result{}) {
+			return ctrlResult, err
+		}
+		increasing := instance.Status.PartPowerIncrease != nil
+
+		// A changed replica count is applied in steps, the rebalance Job
+		// publishes the current step
+		if !increasing {
+			if err := r.reconcileReplicaChanges(ctx, helper, instance); err != nil {
+				return ctrl.Result{}, err
+			}
+		}
+
+		// Check if the device list ConfigMap did change and if so, delete the
+		// rebalance Job. This will result in a new Job that rebalances with
+		// the updated device list
+		devices, deviceListHash, err := configmap.GetConfigMapAndHashWithName(ctx, helper, swiftv1beta1.DeviceConfigMapName, instance.Namespace)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if !increasing && instance.Status.Hash[swiftv1beta1.DeviceListHash] != deviceListHash {
+			restarted, err := r.restartRebalance(ctx, helper, instance)
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+			if !restarted {
+				r.Log.Info(fmt.Sprintf("Rebalance Job %s-rebalance still running, waiting before applying the updated device list", instance.Name))
+				return ctrl.Result{RequeueAfter: 5 * time.Second}, nil
+			}
+			instance.Status.Hash[swiftv1beta1.DeviceListHash] = deviceListHash
+			if err := r.Status().Update(ctx, instance); err != nil {
+				return ctrl.Result{}, err
+			}
+		}
+
+		// The scheduled rebalance runs the rebalance Job again with the same
+		// device list. Its rings are only published if the balance improves,
+		// unless the Job was already restarted for another change
+		if next := swiftring.NextRebalanceTime(instance); next != nil && !increasing && !time.Now().Before(*next) {
+			scheduled := instance.Status.Hash[swiftv1beta1.RingCreateHash] != ""
+			restarted, err := r.restartRebalance(ctx, helper, instance)
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+			if !restarted {
+				return ctrl.Result{RequeueAfter: 5 * time.Second}, nil
+			}
+			now := metav1.Now()
+			instance.Status.LastScheduledRebalanceTime = &now
+			instance.Status.ScheduledRebalance = scheduled
+			if err := r.Status().Update(ctx, instance); err != nil {
+				return ctrl.Result{}, err
+			}
+		}
+
+		// Rings with fewer devices than replicas store several replicas of a
+		// partition on the same device, thus losing a single device loses
+		// data
+		lowDevices, lowZones := swiftring.ValidateFailureDomains(instance, devices.Data)
+		if findings := append(lowDevices, lowZones...); len(findings) > 0 {
+			r.Log.Info(fmt.Sprintf("Rings with fewer failure domains than replicas: %s", strings.Join(findings, "; ")))
+			instance.Status.Conditions.MarkFalse(
+				swiftv1beta1.SwiftRingFailureDomainsCondition,
+				condition.ErrorReason,
+				condition.SeverityWarning,
+				swiftv1beta1.SwiftRingFailureDomainsLowMessage,
+				strings.Join(findings, ", "))
+		} else {
+			instance.Status.Conditions.MarkTrue(swiftv1beta1.SwiftRingFailureDomainsCondition, swiftv1beta1.SwiftRingFailureDomainsReadyMessage)
+		}
+		if len(lowDevices) > 0 && !increasing && instance.Spec.FailureDomainPolicy == swiftv1beta1.FailureDomainPolicyEnforce {
+			err := fmt.Errorf("refusing to rebalance: %s", strings.Join(lowDevices, ", "))
+			instance.Status.Conditions.Set(condition.FalseCondition(
+				condition.ReadyCondition,
+				condition.ErrorReason,
+				condition.SeverityWarning,
+				swiftv1beta1.SwiftRingReadyErrorMessage,
+				err.Error()))
+			instance.Status.Conditions.Set(condition.FalseCondition(
+				swiftv1beta1.SwiftRingReadyCondition,
+				condition.ErrorReason,
+				condition.SeverityWarning,
+				swiftv1beta1.SwiftRingReadyErrorMessage,
+				err.Error()))
+			if err := r.Status().Update(ctx, instance); err != nil {
+				return ctrl.Result{}, err
+			}
+			return ctrl.Result{}, nil
+		}
+
+		// Each step of a partition power increase changing the builder is
+		// run by a new rebalance Job
+		applied, err := r.restartRebalanceOnChange(ctx, helper, instance, swiftv1beta1.PartPowerHash, swiftring.PartPowerIncreaseEnv(instance))
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if !applied {
+			r.Log.Info(fmt.Sprintf("Rebalance Job %s-rebalance still running, waiting before running the next step of the part power increase", instance.Name))
+			return ctrl.Result{RequeueAfter: 5 * time.Second}, nil
+		}
+		// Changed ring parameters are applied by a new rebalance Job
+		applied, err = r.restartRebalanceOnChange(ctx, helper, instance, swiftv1beta1.RingParametersHash, swiftring.RingParametersEnv(instance))
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if !applied {
+			r.Log.Info(fmt.Sprintf("Rebalance Job %s-rebalance still running, waiting before applying the ring parameters", instance.Name))
+			return ctrl.Result{RequeueAfter: 5 * time.Second}, nil
+		}
+		// Ending a dry run publishes the rings of a new rebalance, and
+		// starting one only reports them
+		applied, err = r.restartRebalanceOnChange(ctx, helper, instance, swiftv1beta1.DryRunHash, swiftring.DryRun(instance))
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if !applied {
+			r.Log.Info(fmt.Sprintf("Rebalance Job %s-rebalance still running, waiting before changing the dry run", instance.Name))
+			return ctrl.Result{RequeueAfter: 5 * time.Second}, nil
+		}
+		// Approved rings are published by a new rebalance Job
+		applied, err = r.restartRebalanceOnChange(ctx, helper, instance, swiftv1beta1.RingApprovalHash, swiftring.RingsApproved(instance))
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if !applied {
+			r.Log.Info(fmt.Sprintf("Rebalance Job %s-rebalance still running, waiting before publishing the approved rings", instance.Name))
+			return ctrl.Result{RequeueAfter: 5 * time.Second}, nil
+		}
+
+		ringCreateHash := instance.Status.Hash[swiftv1beta1.RingCreateHash]
+		ringCreateJob := job.NewJob(swiftring.GetRingJob(instance, serviceLabels), swiftv1beta1.RingCreateHash, false, 5*time.Second, ringCreateHash)
+		ctrlResult, err := ringCreateJob.DoJob(ctx, helper)
+		if (ctrlResult != ctrl.Result{}) {
+			instance.Status.Conditions.Set(condition.FalseCondition(
+				condition.ReadyCondition,
+				condition.RequestedReason,
+				condition.SeverityInfo,
+				condition.ReadyInitMessage))
+			if err := r.Status().Update(ctx, instance); err != nil {
+				return ctrl.Result{}, err
+			}
+			return ctrlResult, nil
+		}
+		if err != nil {
+			instance.Status.Conditions.Set(condition.FalseCondition(
+				condition.ReadyCondition,
+				condition.ErrorReason,
+				condition.SeverityWarning,
+				err.Error()))
+			if err := r.Status().Update(ctx, instance); err != nil {
+				return ctrl.Result{}, err
+			}
+			return ctrl.Result{}, err
+		}
+
+		if ringCreateJob.HasChanged() {
+			// The Job published the pending rings if they were approved when it
+			// was created
+			approved := swiftring.RingsApproved(instance)
+			scheduled := instance.Status.ScheduledRebalance
+			instance.Status.Hash[swiftv1beta1.RingCreateHash] = ringCreateJob.GetHash()
+			// The device list is applied once the part power is increased
+			if !increasing {
+				instance.Status.Hash[swiftv1beta1.DeviceListHash] = deviceListHash
+			}
+
+			// The rebalance Job stores the ring stats in a ConfigMap. Missing
+			// stats are not an error, the rings are published
+			message, err := swiftring.GetRingStats(ctx, helper, instance)
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+			// The rebalance Job does not publish rings that did not change
+			ringData, ringFilesHash, err := swiftring.GetRingData(ctx, helper, instance)
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+			instance.Status.RingDataSize = int64(len(ringData))
+			published := ringFilesHash != instance.Status.Hash[swiftv1beta1.RingFilesHash]
+			if published {
+				// Keep a copy of the published rings for each version
+				version := instance.Status.RingVersion + 1
+				if err := swiftring.SaveRingVersion(ctx, helper, instance, version); err != nil {
+					return ctrl.Result{}, err
+				}
+				instance.Status.RingVersion = version
+				instance.Status.ActiveRingVersion = version
+				instance.Status.Hash[swiftv1beta1.RingFilesHash] = ringFilesHash
+			} else {
+				r.Log.Info("Rings unchanged by the rebalance")
+			}
+
+			// A dry run and rings waiting for approval report the changes of
+			// the rebalance as preview, the stats of the published rings are
+			// kept
+			pendingRings := ""
+			if swiftring.ManualUpdate(instance) {
+				pendingRings, err = swiftring.GetPendingRings(ctx, helper, instance)
+				if err != nil {
+					return ctrl.Result{}, err
+				}
+			}
+			instance.Status.PendingRings = pendingRings
+
+			// The stats of approved rings are the preview of the rebalance that
+			// created them
+			if stats, err := swiftring.ParseRingStats(message); err != nil {
+				r.Log.Info(fmt.Sprintf("No ring stats reported by rebalance Job: %s", err))
+			} else if swiftring.DryRun(instance) || pendingRings != "" {
+				instance.Status.Preview = stats
+			} else {
+				if approved && instance.Status.Preview != nil {
+					stats = instance.Status.Preview
+				}
+				instance.Status.Rings = stats
+				instance.Status.Preview = nil
+				if published {
+					now := metav1.Now()
+					instance.Status.LastRebalanceTime = &now
+				}
+			}
+
+			// The rebalance Job ran the current step of the partition power
+			// increase
+			if increase := instance.Status.PartPowerIncrease; increase != nil {
+				ring, partPower := increase.Ring, increase.PartPower
+				swiftring.AdvancePartPowerIncrease(instance)
+				if instance.Status.PartPowerIncrease == nil {
+					r.Log.Info(fmt.Sprintf("Part power of the %s ring increased to %d", ring, partPower))
+					instance.Status.Conditions.MarkTrue(swiftv1beta1.SwiftRingPartPowerCondition, swiftv1beta1.SwiftRingPartPowerReadyMessage)
+				}
+			}
+
+			// The Job without PUBLISH_PENDING or SCHEDULED_REBALANCE would
+			// rebalance the published rings again, thus its hash is stored as
+			// the hash of the Job that published them
+			instance.Status.ScheduledRebalance = false
+			if approved || scheduled {
+				hash, err := util.ObjectHash(swiftring.GetRingJob(instance, serviceLabels).Spec.Template)
+				if err != nil {
+					return ctrl.Result{}, err
+				}
+				instance.Status.Hash[swiftv1beta1.RingCreateHash] = hash
+				approvalHash, err := util.ObjectHash(swiftring.RingsApproved(instance))
+				if err != nil {
+					return ctrl.Result{}, err
+				}
+				instance.Status.Hash[swiftv1beta1.RingApprovalHash] = approvalHash
+			}
+			if err := r.Status().Update(ctx, instance); err != nil {
+				return ctrl.Result{}, err
+			}
+		}
+	}
+
+	// Rebalanced rings are only published once approved with the Manual
+	// RingUpdatePolicy
+	if instance.Spec.RingUpdatePolicy == swiftv1beta1.RingUpdatePolicyManual {
+		if instance.Status.PendingRings != "" {
+			instance.Status.Conditions.MarkFalse(
+				swiftv1beta1.SwiftRingApprovalCondition,
+				condition.RequestedReason,
+				condition.SeverityInfo,
+				swiftv1beta1.SwiftRingApprovalPendingMessage,
+				instance.Status.PendingRings)
+		} else {
+			instance.Status.Conditions.MarkTrue(swiftv1beta1.SwiftRingApprovalCondition, swiftv1beta1.SwiftRingApprovalReadyMessage)
+		}
+	} else if instance.Status.PendingRings != "" || instance.Status.Conditions.Has(swiftv1beta1.SwiftRingApprovalCondition) {
+		if err := swiftring.DeletePendingRings(ctx, helper, instance); err != nil {
+			return ctrl.Result{}, err
+		}
+		instance.Status.PendingRings = ""
+		if !instance.Spec.DryRun {
+			instance.Status.Preview = nil
+		}
+		instance.Status.Conditions.Remove(swiftv1beta1.SwiftRingApprovalCondition)
+	}
+
+	swiftring.UpdateMetrics(instance)
+
+	// The rebalance Job fails once the rings exceed the size limit, warn
+	// before that happens
+	if usage := swiftring.RingDataUsage(instance.Status.RingDataSize); usage >= swiftring.RingDataSizeWarning {
+		instance.Status.Conditions.MarkFalse(
+			swiftv1beta1.SwiftRingSizeCondition,
+			condition.ErrorReason,
+			condition.SeverityWarning,
+			swiftv1beta1.SwiftRingSizeExceededMessage,
+			usage,
+			swiftring.RingDistribution(instance))
+	} else {
+		instance.Status.Conditions.MarkTrue(
+			swiftv1beta1.SwiftRingSizeCondition,
+			swiftv1beta1.SwiftRingSizeReadyMessage,
+			usage,
+			swiftring.RingDistribution(instance))
+	}
+
+	if err := swiftring.PruneRingVersions(ctx, helper, instance); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// Rings restored from a snapshot must match the existing data
+	if instance.Spec.RingSnapshotSecret != "" {
+		ctrlResult, err := r.verifyRings(ctx, helper, instance, serviceLabels)
+		if err != nil || (ctrlResult != ctrl.Result{}) {
+			return ctrlResult, err
+		}
+	} else if instance.Status.Verification != nil {
+		instance.Status.Verification = nil
+		instance.Status.Conditions.Remove(swiftv1beta1.SwiftRingVerifiedCondition)
+	}
+
+	// The ring devices are compared periodically with the storage pods,
+	// mismatches don't affect the readiness of the rings
+	if instance.Spec.Audit.Enabled {
+		if err := r.auditRings(ctx, helper, instance); err != nil {
+			return ctrl.Result{}, err
+		}
+	} else if instance.Status.Audit != nil {
+		instance.Status.Audit = nil
+		instance.Status.Conditions.Remove(swiftv1beta1.SwiftRingAuditCondition)
+	}
+
+	instance.Status.Conditions.MarkTrue(condition.ReadyCondition, condition.ReadyMessage)
+	instance.Status.Conditions.MarkTrue(swiftv1beta1.SwiftRingReadyCondition, condition.ReadyMessage)
+	if err := r.Status().Update(ctx, instance); err != nil {
 		return ctrl.Result{}, err
 	}
+	// Swift ring init job - end
+
+	r.Log.Info(fmt.Sprintf("Reconciled SwiftRing '%s' successfully", instance.Name))
+	var requeue time.Duration
+	for _, next := range []*time.Time{swiftring.NextRebalanceTime(instance), swiftring.NextAuditTime(instance), swiftring.NextReplicationCheck(instance)} {
+		if next != nil && (requeue == 0 || time.Until(*next) < requeue) {
+			requeue = time.Until(*next)
+		}
+	}
+	return ctrl.Result{RequeueAfter: requeue}, nil
+}
+
+// auditRings compares the devices of the published rings with the device
+// lists of the storage pods once the audit interval passed. The rings are
+// only audited once the current device list was applied by a rebalance
+func (r *SwiftRingReconciler) auditRings(ctx context.Context, h *helper.Helper, instance *swiftv1beta1.SwiftRing) error {
+	if next := swiftring.NextAuditTime(instance); next == nil || time.Now().Before(*next) {
+		return nil
+	}
+	devices, deviceListHash, err := configmap.GetConfigMapAndHashWithName(ctx, h, swiftv1beta1.DeviceConfigMapName, instance.Namespace)
+	if err != nil {
+		return err
+	}
 	if instance.Status.Hash[swiftv1beta1.DeviceListHash] != deviceListHash {
-		if err := job.DeleteJob(ctx, helper, instance.Name+"-rebalance", instance.Namespace); err != nil {
+		return nil
+	}
+	ringData, _, err := swiftring.GetRingData(ctx, h, instance)
+	if err != nil {
+		return err
+	}
+
+	now := metav1.Now()
+	count, mismatches, err := swiftring.AuditRings(instance, ringData, devices.Data)
+	if err != nil {
+		instance.Status.Audit = &swiftv1beta1.SwiftRingAudit{LastAuditTime: &now}
+		instance.Status.Conditions.MarkUnknown(
+			swiftv1beta1.SwiftRingAuditCondition,
+			condition.ErrorReason,
+			swiftv1beta1.SwiftRingAuditErrorMessage,
+			err.Error())
+		return nil
+	}
+	instance.Status.Audit = swiftring.AuditStatus(count, mismatches)
+	instance.Status.Audit.LastAuditTime = &now
+	if len(mismatches) > 0 {
+		r.Log.Info(fmt.Sprintf("Ring devices do not match the storage pods: %s", strings.Join(mismatches, "; ")))
+		instance.Status.Conditions.MarkFalse(
+			swiftv1beta1.SwiftRingAuditCondition,
+			condition.ErrorReason,
+			condition.SeverityWarning,
+			swiftv1beta1.SwiftRingAuditMismatchMessage,
+			len(mismatches),
+			mismatches[0])
+	} else {
+		instance.Status.Conditions.MarkTrue(swiftv1beta1.SwiftRingAuditCondition, swiftv1beta1.SwiftRingAuditReadyMessage)
+	}
+	swiftring.UpdateMetrics(instance)
+	return nil
+}
+
+// restartRebalance deletes the rebalance Job, which results in a new Job
+// rebalancing the rings. It returns false if the Job is still running
+func (r *SwiftRingReconciler) restartRebalance(ctx context.Context, h *helper.Helper, instance *swiftv1beta1.SwiftRing) (bool, error) {
+	// Never delete a rebalance Job that is still running, it might be
+	// in the middle of publishing the rings. This is also true if the
+	// operator was restarted while the Job was running.
+	rebalanceJob, err := job.GetJobWithName(ctx, h, instance.Name+"-rebalance", instance.Namespace)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return false, err
+	}
+	if err == nil && rebalanceJob.Status.Active > 0 {
+		return false, nil
+	}
+	if err := job.DeleteJob(ctx, h, instance.Name+"-rebalance", instance.Namespace); err != nil {
+		return false, err
+	}
+	instance.Status.Hash[swiftv1beta1.RingCreateHash] = ""
+	instance.Status.ScheduledRebalance = false
+	return true, nil
+}
+
+// restartRebalanceOnChange restarts the rebalance Job once the given input
+// of the Job changed since it was last applied, and stores its hash. The hash
+// of an input without a stored hash is only stored. It returns false while a
+// running Job delays the restart
+func (r *SwiftRingReconciler) restartRebalanceOnChange(ctx context.Context, h *helper.Helper, instance *swiftv1beta1.SwiftRing, hashKey string, input interface{}) (bool, error) {
+	hash, err := util.ObjectHash(input)
+	if err != nil {
+		return false, err
+	}
+	previous, ok := instance.Status.Hash[hashKey]
+	if previous == hash {
+		return true, nil
+	}
+	if ok {
+		restarted, err := r.restartRebalance(ctx, h, instance)
+		if err != nil || !restarted {
+			return false, err
+		}
+	}
+	instance.Status.Hash[hashKey] = hash
+	return true, r.Status().Update(ctx, instance)
+}
+
+// reconcilePartPowerIncrease starts the partition power increase of an
+// object ring and runs the relinker on all storage pods in the Relink and
+// Cleanup phase, one pod at a time. The other phases are steps of the
+// rebalance Job, which is run by the caller
+func (r *SwiftRingReconciler) reconcilePartPowerIncrease(ctx context.Context, h *helper.Helper, instance *swiftv1beta1.SwiftRing, labels map[string]string) (ctrl.Result, error) {
+	if instance.Status.PartPowerIncrease == nil {
+		ring, partPower := swiftring.PartPowerIncreaseRing(instance)
+		if ring == "" {
+			if instance.Status.Conditions.Has(swiftv1beta1.SwiftRingPartPowerCondition) {
+				instance.Status.Conditions.MarkTrue(swiftv1beta1.SwiftRingPartPowerCondition, swiftv1beta1.SwiftRingPartPowerReadyMessage)
+			}
+			return ctrl.Result{}, nil
+		}
+
+		restarted, err := r.restartRebalance(ctx, h, instance)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if !restarted {
+			r.Log.Info(fmt.Sprintf("Rebalance Job %s-rebalance still running, waiting before increasing the part power of the %s ring", instance.Name, ring))
+			return ctrl.Result{RequeueAfter: 5 * time.Second}, nil
+		}
+		// Pending rings still use the current part power
+		if err := swiftring.DeletePendingRings(ctx, h, instance); err != nil {
 			return ctrl.Result{}, err
 		}
-		instance.Status.Hash[swiftv1beta1.RingCreateHash] = ""
-		instance.Status.Hash[swiftv1beta1.DeviceListHash] = deviceListHash
+		instance.Status.PendingRings = ""
+		instance.Status.PartPowerIncrease = &swiftv1beta1.SwiftRingPartPowerIncreaseStatus{
+			Ring:      ring,
+			PartPower: partPower,
+			Phase:     swiftv1beta1.PartPowerPhasePrepare,
+			PhaseTime: metav1.Now(),
+		}
+		r.Log.Info(fmt.Sprintf("Increasing the part power of the %s ring to %d", ring, partPower))
+	}
+
+	increase := instance.Status.PartPowerIncrease
+	instance.Status.Conditions.Set(condition.FalseCondition(
+		swiftv1beta1.SwiftRingPartPowerCondition,
+		condition.RequestedReason,
+		condition.SeverityInfo,
+		swiftv1beta1.SwiftRingPartPowerRunningMessage,
+		increase.Ring,
+		increase.PartPower,
+		increase.Phase))
+	if err := r.Status().Update(ctx, instance); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	mode, state := swiftring.RelinkerMode(instance)
+	if mode == "" {
+		return ctrl.Result{}, nil
+	}
+
+	// All object servers must use the rings of the previous step before
+	// the data is relinked or cleaned up
+	if wait := time.Until(increase.PhaseTime.Add(swiftring.RingDistributionDelay)); wait > 0 {
+		r.Log.Info(fmt.Sprintf("Waiting for the storage pods to use the rings before running the relinker %s", mode))
+		return ctrl.Result{RequeueAfter: wait}, nil
+	}
+
+	storages := &swiftv1beta1.SwiftStorageList{}
+	if err := r.Client.List(ctx, storages, client.InNamespace(instance.Namespace)); err != nil {
+		return ctrl.Result{}, err
+	}
+	sort.Slice(storages.Items, func(i, j int) bool {
+		return storages.Items[i].Name < storages.Items[j].Name
+	})
+	for i := range storages.Items {
+		storage := &storages.Items[i]
+		start := swiftstorage.OrdinalStart(storage)
+		for ordinal := start; ordinal < start+int(*storage.Spec.Replicas); ordinal++ {
+			podName := fmt.Sprintf("%s-%d", storage.Name, ordinal)
+			if increase.Devices[podName] == state {
+				continue
+			}
+			// The volume of a failed device is lost, there is nothing to
+			// relink
+			if _, failed := storage.Status.FailedDevices[podName]; failed {
+				r.Log.Info(fmt.Sprintf("Skipping the relinker %s of the failed device of %s", mode, podName))
+				continue
+			}
+
+			pod := &corev1.Pod{}
+			err := r.Client.Get(ctx, types.NamespacedName{Name: podName, Namespace: instance.Namespace}, pod)
+			if (err != nil && apierrors.IsNotFound(err)) || (err == nil && pod.Spec.NodeName == "") {
+				r.Log.Info(fmt.Sprintf("Storage pod %s not scheduled, waiting before running the relinker %s", podName, mode))
+				return ctrl.Result{RequeueAfter: 10 * time.Second}, nil
+			} else if err != nil {
+				return ctrl.Result{}, err
+			}
+
+			relinkerJobDef, err := swiftstorage.RelinkerJob(storage, pod, mode, swiftring.PolicyIndex(increase.Ring), increase.PartPower, labels)
+			if err == nil {
+				relinkerJob := job.NewJob(relinkerJobDef, "relinker", false, 10*time.Second, "")
+				var ctrlResult ctrl.Result
+				ctrlResult, err = relinkerJob.DoJob(ctx, h)
+				if (ctrlResult != ctrl.Result{}) {
+					return ctrlResult, nil
+				}
+			}
+			if err != nil {
+				instance.Status.Conditions.Set(condition.FalseCondition(
+					swiftv1beta1.SwiftRingPartPowerCondition,
+					condition.ErrorReason,
+					condition.SeverityWarning,
+					swiftv1beta1.SwiftRingPartPowerErrorMessage,
+					fmt.Sprintf("relinker %s of %s: %s", mode, podName, err)))
+				if err := r.Status().Update(ctx, instance); err != nil {
+					return ctrl.Result{}, err
+				}
+				return ctrl.Result{}, err
+			}
+
+			if increase.Devices == nil {
+				increase.Devices = map[string]string{}
+			}
+			increase.Devices[podName] = state
+			if err := r.Status().Update(ctx, instance); err != nil {
+				return ctrl.Result{}, err
+			}
+		}
+	}
+
+	r.Log.Info(fmt.Sprintf("Relinker %s of the %s ring finished on all storage pods", mode, increase.Ring))
+	swiftring.AdvancePartPowerIncrease(instance)
+	if err := r.Status().Update(ctx, instance); err != nil {
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{Requeue: true}, nil
+}
+
+// reconcileReplicaChanges starts and advances the gradual replica count
+// changes. The next step of a ring is started once its current step is
+// published and all storage pods finished a replication pass using it. The
+// replication is checked every ReplicationCheckInterval only, querying the
+// recon of all storage pods takes a while
+func (r *SwiftRingReconciler) reconcileReplicaChanges(ctx context.Context, h *helper.Helper, instance *swiftv1beta1.SwiftRing) error {
+	due := []string{}
+	for _, ring := range swiftring.UpdateReplicaChanges(instance) {
+		if swiftring.ReplicationCheckDue(instance, ring) {
+			due = append(due, ring)
+		}
+	}
+	if len(due) > 0 {
+		checkTime := metav1.Now()
+		for _, ring := range due {
+			change := instance.Status.ReplicaChanges[ring]
+			change.LastCheckTime = &checkTime
+			instance.Status.ReplicaChanges[ring] = change
+		}
+
+		storages := &swiftv1beta1.SwiftStorageList{}
+		if err := r.Client.List(ctx, storages, client.InNamespace(instance.Namespace)); err != nil {
+			return err
+		}
+		stats := []swiftstorage.ReconStats{}
+		var reconErr error
+		for i := range storages.Items {
+			storageStats, err := swiftstorage.GetReconStats(ctx, &storages.Items[i])
+			if err != nil {
+				reconErr = fmt.Errorf("recon of %s: %w", storages.Items[i].Name, err)
+				break
+			}
+			stats = append(stats, storageStats...)
+		}
+
+		// Unreachable storage pods delay the next step until the replication
+		// can be checked again
+		if reconErr != nil {
+			r.Log.Info(fmt.Sprintf("Replication of the storage pods can't be checked: %s", reconErr))
+			instance.Status.Conditions.Set(condition.FalseCondition(
+				swiftv1beta1.SwiftRingReplicaChangeCondition,
+				condition.ErrorReason,
+				condition.SeverityWarning,
+				swiftv1beta1.SwiftRingReplicaChangeErrorMessage,
+				reconErr.Error()))
+			return r.Status().Update(ctx, instance)
+		}
+
+		advanced := false
+		for _, ring := range due {
+			if !swiftstorage.ReplicationFinishedSince(stats, swiftring.RingService(ring), swiftring.ReplicationCheckTime(instance, ring)) {
+				r.Log.Info(fmt.Sprintf("Waiting for a replication pass of all storage pods before the next replica count step of the %s ring", ring))
+				continue
+			}
+			swiftring.AdvanceReplicaChange(instance, ring)
+			advanced = true
+			r.Log.Info(fmt.Sprintf("Changing the replica count of the %s ring to %s", ring, instance.Status.ReplicaChanges[ring].Replicas))
+		}
+
+		// The next step changes the RING_PARAMETERS of the rebalance Job. A
+		// still running Job delays the restart to the check of the ring
+		// parameters
+		if advanced {
+			if _, err := r.restartRebalanceOnChange(ctx, h, instance, swiftv1beta1.RingParametersHash, swiftring.RingParametersEnv(instance)); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(instance.Status.ReplicaChanges) > 0 {
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			swiftv1beta1.SwiftRingReplicaChangeCondition,
+			condition.RequestedReason,
+			condition.SeverityInfo,
+			swiftv1beta1.SwiftRingReplicaChangeRunningMessage,
+			swiftring.ReplicaChangesSummary(instance)))
+	} else if instance.Status.Conditions.Has(swiftv1beta1.SwiftRingReplicaChangeCondition) {
+		instance.Status.Conditions.MarkTrue(swiftv1beta1.SwiftRingReplicaChangeCondition, swiftv1beta1.SwiftRingReplicaChangeReadyMessage)
+	}
+	return r.Status().Update(ctx, instance)
+}
+
+// rollbackRings publishes the rings and builders saved for the version given
+// by rollbackToVersion. The rebalance Job is deleted first, thus the rings
+// are rebalanced again once the rollback is removed
+func (r *SwiftRingReconciler) rollbackRings(ctx context.Context, h *helper.Helper, instance *swiftv1beta1.SwiftRing) (ctrl.Result, error) {
+	version := *instance.Spec.RollbackToVersion
+	if instance.Status.Rollback != nil && instance.Status.Rollback.Version == version {
+		return ctrl.Result{}, nil
+	}
+
+	restarted, err := r.restartRebalance(ctx, h, instance)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if !restarted {
+		r.Log.Info(fmt.Sprintf("Rebalance Job %s-rebalance still running, waiting before rolling back to ring version %d", instance.Name, version))
+		return ctrl.Result{RequeueAfter: 5 * time.Second}, nil
+	}
+
+	if err := swiftring.RestoreRingVersion(ctx, h, instance, version); err != nil {
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			condition.ReadyCondition,
+			condition.ErrorReason,
+			condition.SeverityWarning,
+			swiftv1beta1.SwiftRingReadyErrorMessage,
+			err.Error()))
 		if err := r.Status().Update(ctx, instance); err != nil {
 			return ctrl.Result{}, err
 		}
+		return ctrl.Result{}, err
+	}
+	ringData, ringFilesHash, err := swiftring.GetRingData(ctx, h, instance)
+	if err != nil {
+		return ctrl.Result{}, err
 	}
+	instance.Status.RingDataSize = int64(len(ringData))
+	instance.Status.Hash[swiftv1beta1.RingFilesHash] = ringFilesHash
+	instance.Status.ActiveRingVersion = version
+	instance.Status.Rollback = &swiftv1beta1.SwiftRingRollbackStatus{
+		Version: version,
+		Time:    metav1.Now(),
+	}
+	r.Log.Info(fmt.Sprintf("Rings rolled back to version %d", version))
+	if err := r.Status().Update(ctx, instance); err != nil {
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{}, nil
+}
 
-	ringCreateJob := job.NewJob(swiftring.GetRingJob(instance, serviceLabels), swiftv1beta1.RingCreateHash, false, 5*time.Second, ringCreateHash)
-	ctrlResult, err := ringCreateJob.DoJob(ctx, helper)
+// verifyRings runs the verification Job of the restored rings. Mismatches
+// are retried periodically, e.g. until all storage pods are reachable
+func (r *SwiftRingReconciler) verifyRings(ctx context.Context, h *helper.Helper, instance *swiftv1beta1.SwiftRing, labels map[string]string) (ctrl.Result, error) {
+	annotations, err := swiftstorage.NetworksAnnotation(ctx, h, instance.Namespace)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	verifyJob := job.NewJob(swiftring.GetVerifyJob(instance, labels, annotations), swiftv1beta1.RingVerifyHash, false, 5*time.Second, instance.Status.Hash[swiftv1beta1.RingVerifyHash])
+	ctrlResult, err := verifyJob.DoJob(ctx, h)
 	if (ctrlResult != ctrl.Result{}) {
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			swiftv1beta1.SwiftRingVerifiedCondition,
+			condition.RequestedReason,
+			condition.SeverityInfo,
+			swiftv1beta1.SwiftRingVerifiedRunningMessage))
 		instance.Status.Conditions.Set(condition.FalseCondition(
 			condition.ReadyCondition,
 			condition.RequestedReason,
 			condition.SeverityInfo,
-			condition.ReadyInitMessage))
+			swiftv1beta1.SwiftRingVerifiedRunningMessage))
 		if err := r.Status().Update(ctx, instance); err != nil {
 			return ctrl.Result{}, err
 		}
@@ -165,9 +908,10 @@ func (r *SwiftRingReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 	}
 	if err != nil {
 		instance.Status.Conditions.Set(condition.FalseCondition(
-			condition.ReadyCondition,
+			swiftv1beta1.SwiftRingVerifiedCondition,
 			condition.ErrorReason,
 			condition.SeverityWarning,
+			swiftv1beta1.SwiftRingVerifiedErrorMessage,
 			err.Error()))
 		if err := r.Status().Update(ctx, instance); err != nil {
 			return ctrl.Result{}, err
@@ -175,22 +919,83 @@ func (r *SwiftRingReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		return ctrl.Result{}, err
 	}
 
-	if ringCreateJob.HasChanged() {
-		instance.Status.Hash[swiftv1beta1.RingCreateHash] = ringCreateJob.GetHash()
-		instance.Status.Hash[swiftv1beta1.DeviceListHash] = deviceListHash
+	if verifyJob.HasChanged() {
+		message, err := swift.GetJobTerminationMessage(ctx, h, instance.Namespace, swiftring.VerifyJobName(instance))
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		verification, err := swiftring.ParseVerification(message)
+		if err != nil {
+			err = fmt.Errorf("invalid result reported by ring verification Job: %w", err)
+			instance.Status.Conditions.Set(condition.FalseCondition(
+				swiftv1beta1.SwiftRingVerifiedCondition,
+				condition.ErrorReason,
+				condition.SeverityWarning,
+				swiftv1beta1.SwiftRingVerifiedErrorMessage,
+				err.Error()))
+			if err := r.Status().Update(ctx, instance); err != nil {
+				return ctrl.Result{}, err
+			}
+			return ctrl.Result{}, err
+		}
+		now := metav1.Now()
+		verification.LastVerificationTime = &now
+		instance.Status.Verification = verification
+		instance.Status.Hash[swiftv1beta1.RingVerifyHash] = verifyJob.GetHash()
 		if err := r.Status().Update(ctx, instance); err != nil {
 			return ctrl.Result{}, err
 		}
 	}
 
-	instance.Status.Conditions.MarkTrue(condition.ReadyCondition, condition.ReadyMessage)
-	instance.Status.Conditions.MarkTrue(swiftv1beta1.SwiftRingReadyCondition, condition.ReadyMessage)
-	if err := r.Status().Update(ctx, instance); err != nil {
-		return ctrl.Result{}, err
+	if instance.Status.Verification != nil && !instance.Status.Verification.Verified {
+		verification := instance.Status.Verification
+		r.Log.Info(fmt.Sprintf("Restored rings do not match the storage devices: %s", strings.Join(verification.Mismatches, "; ")))
+		first := ""
+		if len(verification.Mismatches) > 0 {
+			first = verification.Mismatches[0]
+		}
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			swiftv1beta1.SwiftRingVerifiedCondition,
+			condition.ErrorReason,
+			condition.SeverityWarning,
+			swiftv1beta1.SwiftRingVerifiedMismatchMessage,
+			verification.TotalMismatches,
+			first))
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			condition.ReadyCondition,
+			condition.ErrorReason,
+			condition.SeverityWarning,
+			swiftv1beta1.SwiftRingVerifiedMismatchMessage,
+			verification.TotalMismatches,
+			first))
+
+		// Verify again later, the result is kept until then
+		if err := job.DeleteJob(ctx, h, swiftring.VerifyJobName(instance), instance.Namespace); err != nil {
+			return ctrl.Result{}, err
+		}
+		instance.Status.Hash[swiftv1beta1.RingVerifyHash] = ""
+		if err := r.Status().Update(ctx, instance); err != nil {
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{RequeueAfter: time.Minute}, nil
 	}
-	// Swift ring init job - end
 
-	r.Log.Info(fmt.Sprintf("Reconciled SwiftRing '%s' successfully", instance.Name))
+	// Partitions on handoff devices are moved by the replicators, they
+	// don't affect the readiness of the rings
+	if verification := instance.Status.Verification; verification != nil && verification.TotalHandoffs > 0 {
+		r.Log.Info(fmt.Sprintf("Partitions of the restored rings found on handoff devices: %s", strings.Join(verification.Handoffs, "; ")))
+		first := ""
+		if len(verification.Handoffs) > 0 {
+			first = verification.Handoffs[0]
+		}
+		instance.Status.Conditions.MarkTrue(
+			swiftv1beta1.SwiftRingVerifiedCondition,
+			swiftv1beta1.SwiftRingVerifiedHandoffsMessage,
+			verification.TotalHandoffs,
+			first)
+		return ctrl.Result{}, nil
+	}
+	instance.Status.Conditions.MarkTrue(swiftv1beta1.SwiftRingVerifiedCondition, swiftv1beta1.SwiftRingVerifiedReadyMessage)
 	return ctrl.Result{}, nil
 }
 
@@ -0,0 +1,287 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	swiftv1beta1 "github.com/openstack-k8s-operators/swift-operator/api/v1beta1"
+)
+
+// finishedPhaseAnnotation marks the rebalance Jobs finished by the test
+const finishedPhaseAnnotation = "test/finished-phase"
+
+// rebalanceJob returns the rebalance Job
+func rebalanceJob(g *WithT, c client.Client, name types.NamespacedName) *batchv1.Job {
+	rebalance := &batchv1.Job{}
+	g.Expect(c.Get(context.TODO(), types.NamespacedName{Name: name.Name + "-rebalance", Namespace: name.Namespace}, rebalance)).To(Succeed())
+	return rebalance
+}
+
+// rebalanceEnv returns the value of the environment variable of the Job
+func rebalanceEnv(rebalance *batchv1.Job, name string) string {
+	for _, envVar := range rebalance.Spec.Template.Spec.Containers[0].Env {
+		if envVar.Name == name {
+			return envVar.Value
+		}
+	}
+	return ""
+}
+
+// finishRebalanceJob marks the rebalance Job as succeeded in the given phase
+func finishRebalanceJob(g *WithT, c client.Client, rebalance *batchv1.Job, phase string) {
+	rebalance.Annotations[finishedPhaseAnnotation] = phase
+	g.Expect(c.Update(context.TODO(), rebalance)).To(Succeed())
+	rebalance.Status.Succeeded = 1
+	g.Expect(c.Status().Update(context.TODO(), rebalance)).To(Succeed())
+}
+
+// skipRingDistribution moves the start of the current phase of the partition
+// power increase before the ring distribution delay
+func skipRingDistribution(g *WithT, c client.Client, name types.NamespacedName) {
+	instance := &swiftv1beta1.SwiftRing{}
+	g.Expect(c.Get(context.TODO(), name, instance)).To(Succeed())
+	instance.Status.PartPowerIncrease.PhaseTime = metav1.NewTime(time.Now().Add(-time.Hour))
+	g.Expect(c.Status().Update(context.TODO(), instance)).To(Succeed())
+}
+
+// partPowerPhase returns the current phase of the partition power increase
+func partPowerPhase(g *WithT, c client.Client, name types.NamespacedName) string {
+	instance := &swiftv1beta1.SwiftRing{}
+	g.Expect(c.Get(context.TODO(), name, instance)).To(Succeed())
+	if instance.Status.PartPowerIncrease == nil {
+		return ""
+	}
+	return instance.Status.PartPowerIncrease.Phase
+}
+
+func TestPartPowerIncreaseJobs(t *testing.T) {
+	g := NewWithT(t)
+	t.Setenv("OPERATOR_TEMPLATES", "../templates")
+
+	scheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+	g.Expect(swiftv1beta1.AddToScheme(scheme)).To(Succeed())
+
+	name := types.NamespacedName{Name: "swift-ring", Namespace: "openstack"}
+	partPower := int32(11)
+	replicas := int64(1)
+	instance := &swiftv1beta1.SwiftRing{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name.Name,
+			Namespace: name.Namespace,
+		},
+		Spec: swiftv1beta1.SwiftRingSpec{
+			SwiftRingSpecCore: swiftv1beta1.SwiftRingSpecCore{
+				RingReplicas: &replicas,
+				PartPower:    &partPower,
+			},
+		},
+		Status: swiftv1beta1.SwiftRingStatus{
+			Rings: map[string]swiftv1beta1.SwiftRingStats{
+				"object": {Partitions: 1024},
+			},
+		},
+	}
+	devices := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      swiftv1beta1.DeviceConfigMapName,
+			Namespace: name.Namespace,
+		},
+		Data: map[string]string{
+			"swift-storage" + swiftv1beta1.DeviceListKeySuffix: "1,1,swift-storage-0.swift-storage,d1,10,6202,6201,6200,873,6202,6201,6200,,swift-storage-0\n",
+		},
+	}
+	rings := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      swiftv1beta1.RingConfigMapName,
+			Namespace: name.Namespace,
+		},
+		BinaryData: map[string][]byte{"swiftrings.tar.gz": []byte("rings")},
+	}
+	builders := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      swiftv1beta1.BuilderSecretName,
+			Namespace: name.Namespace,
+		},
+		Data: map[string][]byte{"swiftbuilders.tar.gz": []byte("builders")},
+	}
+
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(instance, devices, rings, builders).
+		Build()
+	r := &SwiftRingReconciler{
+		Client:  c,
+		Scheme:  scheme,
+		Log:     ctrl.Log.WithName("controllers").WithName("SwiftRing"),
+		Kclient: kubefake.NewSimpleClientset(),
+	}
+	reconcile := func() {
+		_, err := r.Reconcile(context.TODO(), ctrl.Request{NamespacedName: name})
+		g.Expect(err).NotTo(HaveOccurred())
+	}
+
+	// Every step changing the builder is run by a new rebalance Job
+	jobs := 0
+	expectJob := func(phase string, partPowerIncrease string) {
+		g.Expect(partPowerPhase(g, c, name)).To(Equal(phase))
+		rebalance := rebalanceJob(g, c, name)
+		g.Expect(rebalance.Annotations).NotTo(HaveKey(finishedPhaseAnnotation), "no new rebalance Job for the %s phase", phase)
+		g.Expect(rebalanceEnv(rebalance, "PART_POWER_INCREASE")).To(Equal(partPowerIncrease))
+		finishRebalanceJob(g, c, rebalance, phase)
+		jobs++
+	}
+
+	reconcile()
+	expectJob(swiftv1beta1.PartPowerPhasePrepare, "object:prepare:11")
+
+	// The relinker phases advance once the rings are distributed, there
+	// are no storage pods to relink
+	reconcile()
+	g.Expect(partPowerPhase(g, c, name)).To(Equal(swiftv1beta1.PartPowerPhaseRelink))
+	skipRingDistribution(g, c, name)
+	reconcile()
+	reconcile()
+	expectJob(swiftv1beta1.PartPowerPhaseIncrease, "object:increase:11")
+
+	reconcile()
+	g.Expect(partPowerPhase(g, c, name)).To(Equal(swiftv1beta1.PartPowerPhaseCleanup))
+	skipRingDistribution(g, c, name)
+	reconcile()
+	reconcile()
+	expectJob(swiftv1beta1.PartPowerPhaseFinish, "object:finish:11")
+
+	reconcile()
+	g.Expect(partPowerPhase(g, c, name)).To(BeEmpty())
+	g.Expect(jobs).To(Equal(3))
+}
+
+func TestScheduledRebalanceJob(t *testing.T) {
+	g := NewWithT(t)
+	t.Setenv("OPERATOR_TEMPLATES", "../templates")
+
+	scheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+	g.Expect(swiftv1beta1.AddToScheme(scheme)).To(Succeed())
+
+	name := types.NamespacedName{Name: "swift-ring", Namespace: "openstack"}
+	replicas := int64(1)
+	instance := &swiftv1beta1.SwiftRing{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name.Name,
+			Namespace: name.Namespace,
+		},
+		Spec: swiftv1beta1.SwiftRingSpec{
+			SwiftRingSpecCore: swiftv1beta1.SwiftRingSpecCore{
+				RingReplicas: &replicas,
+				AutoRebalance: swiftv1beta1.SwiftRingAutoRebalanceSpec{
+					Enabled:         true,
+					IntervalSeconds: 3600,
+				},
+			},
+		},
+	}
+	devices := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      swiftv1beta1.DeviceConfigMapName,
+			Namespace: name.Namespace,
+		},
+		Data: map[string]string{
+			"swift-storage" + swiftv1beta1.DeviceListKeySuffix: "1,1,swift-storage-0.swift-storage,d1,10,6202,6201,6200,873,6202,6201,6200,,swift-storage-0\n",
+		},
+	}
+	rings := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      swiftv1beta1.RingConfigMapName,
+			Namespace: name.Namespace,
+		},
+		BinaryData: map[string][]byte{"swiftrings.tar.gz": []byte("rings")},
+	}
+	builders := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      swiftv1beta1.BuilderSecretName,
+			Namespace: name.Namespace,
+		},
+		Data: map[string][]byte{"swiftbuilders.tar.gz": []byte("builders")},
+	}
+
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(instance, devices, rings, builders).
+		Build()
+	r := &SwiftRingReconciler{
+		Client:  c,
+		Scheme:  scheme,
+		Log:     ctrl.Log.WithName("controllers").WithName("SwiftRing"),
+		Kclient: kubefake.NewSimpleClientset(),
+	}
+	reconcile := func() {
+		_, err := r.Reconcile(context.TODO(), ctrl.Request{NamespacedName: name})
+		g.Expect(err).NotTo(HaveOccurred())
+	}
+	// The next scheduled rebalance is due
+	rebalanceDue := func() {
+		instance := &swiftv1beta1.SwiftRing{}
+		g.Expect(c.Get(context.TODO(), name, instance)).To(Succeed())
+		due := metav1.NewTime(time.Now().Add(-2 * time.Hour))
+		instance.Status.LastRebalanceTime = &due
+		instance.Status.LastScheduledRebalanceTime = &due
+		g.Expect(c.Status().Update(context.TODO(), instance)).To(Succeed())
+	}
+	expectJob := func(phase string, scheduled string) {
+		rebalance := rebalanceJob(g, c, name)
+		g.Expect(rebalance.Annotations).NotTo(HaveKey(finishedPhaseAnnotation), "no new rebalance Job for the %s rebalance", phase)
+		g.Expect(rebalanceEnv(rebalance, "SCHEDULED_REBALANCE")).To(Equal(scheduled))
+		finishRebalanceJob(g, c, rebalance, phase)
+		reconcile()
+	}
+
+	reconcile()
+	expectJob("initial", "")
+
+	// Only the rings of a scheduled rebalance without other changes are
+	// checked for an improved balance by the Job
+	rebalanceDue()
+	reconcile()
+	expectJob("scheduled", "true")
+	reconcile()
+	g.Expect(rebalanceJob(g, c, name).Annotations).To(HaveKeyWithValue(finishedPhaseAnnotation, "scheduled"))
+	g.Expect(c.Get(context.TODO(), name, instance)).To(Succeed())
+	g.Expect(instance.Status.ScheduledRebalance).To(BeFalse())
+
+	devices.Data["swift-storage"+swiftv1beta1.DeviceListKeySuffix] = "1,1,swift-storage-0.swift-storage,d1,20,6202,6201,6200,873,6202,6201,6200,,swift-storage-0\n"
+	g.Expect(c.Update(context.TODO(), devices)).To(Succeed())
+	rebalanceDue()
+	reconcile()
+	expectJob("device change", "")
+}
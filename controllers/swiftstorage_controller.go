@@ -19,6 +19,7 @@ package controllers
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -26,8 +27,12 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	helper "github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	networkattachment "github.com/openstack-k8s-operators/lib-common/modules/common/networkattachment"
 	service "github.com/openstack-k8s-operators/lib-common/modules/common/service"
 	statefulset "github.com/openstack-k8s-operators/lib-common/modules/common/statefulset"
 
@@ -35,14 +40,18 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 
 	swiftv1beta1 "github.com/openstack-k8s-operators/swift-operator/api/v1beta1"
+	"github.com/openstack-k8s-operators/swift-operator/pkg/swift"
 	"github.com/openstack-k8s-operators/swift-operator/pkg/swiftstorage"
 
 	"github.com/openstack-k8s-operators/lib-common/modules/common/condition"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/configmap"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/env"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/secret"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/util"
 )
 
 // SwiftStorageReconciler reconciles a SwiftStorage object
@@ -56,9 +65,11 @@ type SwiftStorageReconciler struct {
 //+kubebuilder:rbac:groups=swift.openstack.org,resources=swiftstorages,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=swift.openstack.org,resources=swiftstorages/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=swift.openstack.org,resources=swiftstorages/finalizers,verbs=update
+//+kubebuilder:rbac:groups=swift.openstack.org,resources=swiftrings,verbs=get;list;watch
 //+kubebuilder:rbac:groups=apps,resources=statefulsets,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=core,resources=services,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=k8s.cni.cncf.io,resources=network-attachment-definitions,verbs=get;list;watch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -91,6 +102,7 @@ func (r *SwiftStorageReconciler) Reconcile(ctx context.Context, req ctrl.Request
 		cl := condition.CreateList(
 			condition.UnknownCondition(condition.ReadyCondition, condition.InitReason, condition.ReadyInitMessage),
 			condition.UnknownCondition(swiftv1beta1.SwiftStorageReadyCondition, condition.InitReason, condition.ReadyInitMessage),
+			condition.UnknownCondition(condition.NetworkAttachmentsReadyCondition, condition.InitReason, condition.NetworkAttachmentsReadyInitMessage),
 		)
 
 		instance.Status.Conditions.Init(&cl)
@@ -118,35 +130,138 @@ func (r *SwiftStorageReconciler) Reconcile(ctx context.Context, req ctrl.Request
 	serviceLabels := swiftstorage.Labels()
 	envVars := make(map[string]env.Setter)
 
-	// Check if there is already an existing ConfigMap and device list. If
-	// not, create an initial device list to bootstrap the cluster with The
-	// weights are simply set to the requested size, this will be changed
-	// once all StatefulSets are running
-	_, ctrlResult, err := configmap.GetConfigMap(ctx, helper, instance, swiftv1beta1.DeviceConfigMapName, 5*time.Second)
+	// Check that all NetworkAttachmentDefinitions exist before the pods are
+	// attached to them
+	for _, netAtt := range instance.Spec.NetworkAttachments {
+		_, err = networkattachment.GetNADWithName(ctx, helper, netAtt, instance.Namespace)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				instance.Status.Conditions.Set(condition.FalseCondition(
+					condition.NetworkAttachmentsReadyCondition,
+					condition.RequestedReason,
+					condition.SeverityInfo,
+					condition.NetworkAttachmentsReadyWaitingMessage,
+					netAtt))
+				if err := r.Status().Update(ctx, instance); err != nil {
+					return ctrl.Result{}, err
+				}
+				return ctrl.Result{RequeueAfter: time.Second * 10}, fmt.Errorf("network-attachment-definition %s not found", netAtt)
+			}
+			instance.Status.Conditions.Set(condition.FalseCondition(
+				condition.NetworkAttachmentsReadyCondition,
+				condition.ErrorReason,
+				condition.SeverityWarning,
+				condition.NetworkAttachmentsReadyErrorMessage,
+				err.Error()))
+			return ctrl.Result{}, err
+		}
+	}
+
+	serviceAnnotations, err := networkattachment.CreateNetworksAnnotation(instance.Namespace, instance.Spec.NetworkAttachments)
 	if err != nil {
-		return ctrlResult, err
-	} else if (ctrlResult != ctrl.Result{}) {
-		devices := swiftstorage.DeviceList(ctx, helper, instance)
-		tpl := swiftstorage.DeviceConfigMapTemplates(instance, devices)
-		err = configmap.EnsureConfigMaps(ctx, helper, instance, tpl, &envVars)
+		return ctrl.Result{}, fmt.Errorf("failed create network annotation from %s: %w",
+			instance.Spec.NetworkAttachments, err)
+	}
+
+	// Check if there is already an existing device list of this
+	// SwiftStorage. If not, create an initial device list to bootstrap the
+	// cluster with The weights are simply set to the requested size, this
+	// will be changed once all StatefulSets are running. The IPs on a
+	// NetworkAttachment are only known once the pods are running, thus
+	// there is no initial device list in this case. The same is true for
+	// the regions and zones from the node labels
+	hasDeviceList, err := swiftstorage.HasDeviceList(ctx, helper, instance)
+	if err != nil {
+		return ctrl.Result{}, err
+	} else if !hasDeviceList && len(instance.Spec.NetworkAttachments) == 0 && !instance.Spec.RingTopology.Enabled {
+		devices, _, err := swiftstorage.DeviceList(ctx, helper, instance)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		err = swiftstorage.EnsureDeviceList(ctx, helper, instance, devices, "")
 		if err != nil {
 			return ctrl.Result{}, err
 		}
 	}
 
+	// Get the server list and TLS setting of the shared Memcached instance
+	var memcached *swift.Memcached
+	if instance.Spec.MemcachedInstance != "" {
+		memcached, err = swift.GetMemcached(ctx, helper, instance.Spec.MemcachedInstance, instance.Namespace)
+		if err != nil && !apierrors.IsNotFound(err) {
+			instance.Status.Conditions.Set(condition.FalseCondition(
+				condition.MemcachedReadyCondition,
+				condition.ErrorReason,
+				condition.SeverityWarning,
+				condition.MemcachedReadyErrorMessage,
+				err.Error()))
+			if err := r.Status().Update(ctx, instance); err != nil {
+				return ctrl.Result{}, err
+			}
+			return ctrl.Result{}, err
+		}
+		if memcached == nil || len(memcached.Servers) == 0 {
+			r.Log.Info(fmt.Sprintf("Memcached %s not ready yet", instance.Spec.MemcachedInstance))
+			instance.Status.Conditions.Set(condition.FalseCondition(
+				condition.MemcachedReadyCondition,
+				condition.RequestedReason,
+				condition.SeverityInfo,
+				condition.MemcachedReadyWaitingMessage))
+			if err := r.Status().Update(ctx, instance); err != nil {
+				return ctrl.Result{}, err
+			}
+			return ctrl.Result{RequeueAfter: 10 * time.Second}, nil
+		}
+		instance.Status.Conditions.MarkTrue(condition.MemcachedReadyCondition, condition.MemcachedReadyMessage)
+	} else {
+		instance.Status.Conditions.Remove(condition.MemcachedReadyCondition)
+	}
+
+	// A rollback uses the pod template and the configuration of a previous
+	// StatefulSet revision
+	var rollbackRevision *appsv1.ControllerRevision
+	if instance.Spec.RollbackToRevision != nil {
+		rollbackRevision, err = swiftstorage.GetRollbackRevision(ctx, helper, instance)
+		if err != nil {
+			instance.Status.Conditions.Set(condition.FalseCondition(
+				swiftv1beta1.SwiftStorageReadyCondition,
+				condition.ErrorReason,
+				condition.SeverityWarning,
+				swiftv1beta1.SwiftStorageReadyErrorMessage,
+				err.Error()))
+			if err := r.Status().Update(ctx, instance); err != nil {
+				return ctrl.Result{}, err
+			}
+			return ctrl.Result{}, err
+		}
+	} else {
+		instance.Status.Rollback = nil
+	}
+
 	// Create a ConfigMap populated with content from templates/
-	tpl := swiftstorage.ConfigMapTemplates(instance, serviceLabels)
+	tpl := []util.Template{}
+	for _, t := range swiftstorage.ConfigMapTemplates(instance, serviceLabels, memcached) {
+		if rollbackRevision != nil && t.Name == swiftstorage.ConfigMapName(instance) {
+			continue
+		}
+		tpl = append(tpl, t)
+	}
 	err = configmap.EnsureConfigMaps(ctx, helper, instance, tpl, &envVars)
 	if err != nil {
 		return ctrl.Result{}, err
 	}
+	if rollbackRevision != nil {
+		if err := swiftstorage.RestoreRevisionConfig(ctx, helper, instance, rollbackRevision.Name); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
 
 	// Headless Service
 	svc, err := service.NewService(swiftstorage.Service(instance), 5*time.Second, nil)
 	if err != nil {
 		return ctrl.Result{}, err
 	}
-	ctrlResult, err = svc.CreateOrPatch(ctx, helper)
+	ctrlResult, err := svc.CreateOrPatch(ctx, helper)
 	if err != nil {
 		return ctrlResult, err
 	} else if (ctrlResult != ctrl.Result{}) {
@@ -162,8 +277,40 @@ func (r *SwiftStorageReconciler) Reconcile(ctx context.Context, req ctrl.Request
 		return ctrlResult, nil
 	}
 
+	// PVCs for existing volumes must be created before the StatefulSet
+	ctrlResult, err = swiftstorage.EnsureRestoreClaims(ctx, helper, instance, serviceLabels)
+	if err != nil {
+		return ctrlResult, err
+	} else if (ctrlResult != ctrl.Result{}) {
+		return ctrlResult, nil
+	}
+
+	// Restart the storage pods if the CA bundle is rotated
+	if instance.Spec.CABundleSecretName != "" {
+		_, caBundleHash, err := secret.GetSecret(ctx, helper, instance.Spec.CABundleSecretName, instance.Namespace)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				r.Log.Info(fmt.Sprintf("Secret %s not found", instance.Spec.CABundleSecretName))
+				return ctrl.Result{RequeueAfter: 10 * time.Second}, nil
+			}
+			return ctrl.Result{}, err
+		}
+		serviceAnnotations = util.MergeStringMaps(serviceAnnotations, map[string]string{swift.CABundleHashAnnotation: caBundleHash})
+	}
+
 	// Statefulset with all backend containers
-	sset := statefulset.NewStatefulSet(swiftstorage.StatefulSet(instance, serviceLabels), 5*time.Second)
+	ssetDef := swiftstorage.StatefulSet(instance, serviceLabels, serviceAnnotations)
+	if rollbackRevision != nil {
+		template, err := swiftstorage.RevisionTemplate(rollbackRevision)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		ssetDef.Spec.Template = *template
+	}
+	if err := swiftstorage.EnsureOrdinals(ctx, helper, ssetDef); err != nil {
+		return ctrl.Result{}, err
+	}
+	sset := statefulset.NewStatefulSet(ssetDef, 5*time.Second)
 	ctrlResult, err = sset.CreateOrPatch(ctx, helper)
 	if err != nil {
 		return ctrlResult, err
@@ -171,33 +318,247 @@ func (r *SwiftStorageReconciler) Reconcile(ctx context.Context, req ctrl.Request
 		return ctrlResult, nil
 	}
 
+	// The ordinals are silently dropped if the cluster does not support
+	// them, the device list would not match the pod names then
+	if instance.Spec.OrdinalStart != nil && sset.GetStatefulSet().Spec.Ordinals == nil {
+		err = fmt.Errorf("StatefulSet %s ignored ordinalStart, the StatefulSetStartOrdinal feature is not enabled", instance.Name)
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			swiftv1beta1.SwiftStorageReadyCondition,
+			condition.ErrorReason,
+			condition.SeverityWarning,
+			swiftv1beta1.SwiftStorageReadyErrorMessage,
+			err.Error()))
+		if err := r.Status().Update(ctx, instance); err != nil {
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{}, err
+	}
+
+	// The volumeClaimTemplates can't be updated, the extra metadata is
+	// added to the PVCs created by the StatefulSet instead
+	if err := swiftstorage.EnsureClaimMetadata(ctx, helper, instance); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// Keep the configuration of each revision for rollbacks. The revision
+	// in the status is only up to date once the StatefulSet was observed
+	current := sset.GetStatefulSet()
+	if current.Status.ObservedGeneration == current.Generation && current.Status.UpdateRevision != "" {
+		if rollbackRevision == nil {
+			if err := swiftstorage.SaveRevisionConfig(ctx, helper, instance, current.Status.UpdateRevision); err != nil {
+				return ctrl.Result{}, err
+			}
+		}
+		if err := swiftstorage.PruneRevisionConfigs(ctx, helper, instance); err != nil {
+			return ctrl.Result{}, err
+		}
+		instance.Status.UpdateRevision = current.Status.UpdateRevision
+	}
+	if rollbackRevision != nil && (instance.Status.Rollback == nil || instance.Status.Rollback.ControllerRevision != rollbackRevision.Name) {
+		r.Log.Info(fmt.Sprintf("StatefulSet %s rolled back to revision %d (%s)", instance.Name, *instance.Spec.RollbackToRevision, rollbackRevision.Name))
+		instance.Status.Rollback = &swiftv1beta1.SwiftStorageRollbackStatus{
+			Revision:           *instance.Spec.RollbackToRevision,
+			ControllerRevision: rollbackRevision.Name,
+			Time:               metav1.Now(),
+		}
+	}
+
+	// Storage policies of the devices, used to label the metrics
+	policies := []string{}
+	if instance.Spec.Alerts.Enabled || instance.Spec.Alerts.PrometheusRule {
+		policies, err = swiftstorage.StoragePolicies(ctx, helper, instance)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	// PrometheusRule using the alert thresholds. The condition is stored
+	// before the PrometheusRule is created, thus it's only deleted if it
+	// might exist
+	if instance.Spec.Alerts.PrometheusRule {
+		if !instance.Status.Conditions.Has(swiftv1beta1.SwiftStoragePrometheusRuleCondition) {
+			instance.Status.Conditions.Set(condition.UnknownCondition(
+				swiftv1beta1.SwiftStoragePrometheusRuleCondition,
+				condition.InitReason,
+				swiftv1beta1.SwiftStoragePrometheusRuleInitMessage))
+			if err := r.Status().Update(ctx, instance); err != nil {
+				return ctrl.Result{}, err
+			}
+		}
+		err = swiftstorage.EnsurePrometheusRule(ctx, helper, instance, serviceLabels, policies)
+		if err != nil {
+			instance.Status.Conditions.Set(condition.FalseCondition(
+				swiftv1beta1.SwiftStoragePrometheusRuleCondition,
+				condition.ErrorReason,
+				condition.SeverityWarning,
+				swiftv1beta1.SwiftStoragePrometheusRuleErrorMessage,
+				err.Error()))
+			if err := r.Status().Update(ctx, instance); err != nil {
+				return ctrl.Result{}, err
+			}
+			return ctrl.Result{}, err
+		}
+		instance.Status.Conditions.MarkTrue(swiftv1beta1.SwiftStoragePrometheusRuleCondition, swiftv1beta1.SwiftStoragePrometheusRuleReadyMessage)
+	} else if instance.Status.Conditions.Has(swiftv1beta1.SwiftStoragePrometheusRuleCondition) {
+		err = swiftstorage.DeletePrometheusRule(ctx, helper, instance)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		instance.Status.Conditions.Remove(swiftv1beta1.SwiftStoragePrometheusRuleCondition)
+	}
+
+	// Devices with a lost volume. The volumes are not watched, thus requeue
+	// to check them again and to apply the action once the grace period
+	// expired
+	result := ctrl.Result{}
+	requeue, err := swiftstorage.UpdateFailedDevices(ctx, helper, instance)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if messages := swiftstorage.FailedDeviceMessages(instance); len(messages) > 0 {
+		instance.Status.Conditions.MarkFalse(
+			swiftv1beta1.SwiftStorageDevicesCondition,
+			condition.ErrorReason,
+			condition.SeverityWarning,
+			swiftv1beta1.SwiftStorageDevicesFailedMessage,
+			strings.Join(messages, ", "))
+		if requeue == 0 || requeue > swiftstorage.FailedDevicesInterval {
+			requeue = swiftstorage.FailedDevicesInterval
+		}
+	} else {
+		instance.Status.Conditions.MarkTrue(swiftv1beta1.SwiftStorageDevicesCondition, swiftv1beta1.SwiftStorageDevicesReadyMessage)
+	}
+	if instance.Spec.FailedDevices.Action != "" && instance.Spec.FailedDevices.Action != swiftv1beta1.FailedDeviceNone {
+		result = ctrl.Result{RequeueAfter: swiftstorage.FailedDevicesInterval}
+	}
+	if requeue > 0 {
+		result = ctrl.Result{RequeueAfter: requeue}
+	}
+
+	// The pods of failed devices with an applied action might never become
+	// ready again, the device list is updated without them
 	instance.Status.ReadyCount = sset.GetStatefulSet().Status.ReadyReplicas
-	if instance.Status.ReadyCount == *instance.Spec.Replicas {
-		envVars := make(map[string]env.Setter)
-		devices := swiftstorage.DeviceList(ctx, helper, instance)
-		tpl = swiftstorage.DeviceConfigMapTemplates(instance, devices)
-		err = configmap.EnsureConfigMaps(ctx, helper, instance, tpl, &envVars)
+	if instance.Status.ReadyCount+swiftstorage.FailedDeviceCount(instance) >= *instance.Spec.Replicas {
+		// Verify that all pods are attached to the NetworkAttachments
+		networkReady, networkAttachmentStatus, err := networkattachment.VerifyNetworkStatusFromAnnotation(
+			ctx, helper, instance.Spec.NetworkAttachments, serviceLabels, instance.Status.ReadyCount)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		instance.Status.NetworkAttachments = networkAttachmentStatus
+		if networkReady {
+			instance.Status.Conditions.MarkTrue(condition.NetworkAttachmentsReadyCondition, condition.NetworkAttachmentsReadyMessage)
+		} else {
+			err := fmt.Errorf("not all pods have interfaces with ips as configured in NetworkAttachments: %s", instance.Spec.NetworkAttachments)
+			instance.Status.Conditions.Set(condition.FalseCondition(
+				condition.NetworkAttachmentsReadyCondition,
+				condition.ErrorReason,
+				condition.SeverityWarning,
+				condition.NetworkAttachmentsReadyErrorMessage,
+				err.Error()))
+			if err := r.Status().Update(ctx, instance); err != nil {
+				return ctrl.Result{}, err
+			}
+			return ctrl.Result{RequeueAfter: time.Second * 10}, nil
+		}
+
+		devices, unscheduled, err := swiftstorage.DeviceList(ctx, helper, instance)
+		if err != nil {
+			instance.Status.Conditions.Set(condition.FalseCondition(
+				condition.ReadyCondition,
+				condition.ErrorReason,
+				condition.SeverityWarning,
+				swiftv1beta1.SwiftStorageReadyErrorMessage,
+				err.Error()))
+			if err := r.Status().Update(ctx, instance); err != nil {
+				return ctrl.Result{}, err
+			}
+			return ctrl.Result{}, err
+		}
+		err = swiftstorage.EnsureDeviceList(ctx, helper, instance, devices, swiftstorage.FailedDeviceList(instance))
 		if err != nil {
 			return ctrl.Result{}, err
 		}
+		// The devices of pods that are not scheduled are updated once they
+		// are scheduled again
+		if len(unscheduled) > 0 {
+			r.Log.Info(fmt.Sprintf("Storage pods not scheduled, keeping their devices: %s", strings.Join(unscheduled, ", ")))
+			if result.RequeueAfter == 0 || result.RequeueAfter > 10*time.Second {
+				result = ctrl.Result{RequeueAfter: 10 * time.Second}
+			}
+		}
 		instance.Status.Conditions.MarkTrue(condition.ReadyCondition, condition.ReadyMessage)
 		instance.Status.Conditions.MarkTrue(swiftv1beta1.SwiftStorageReadyCondition, condition.ReadyMessage)
-		if err := r.Status().Update(ctx, instance); err != nil {
-			return ctrl.Result{}, err
+
+		// Check the recon data of all pods against the alert thresholds.
+		// The recon data is not watched, thus requeue to check it again
+		if instance.Spec.Alerts.Enabled {
+			stats, err := swiftstorage.GetReconStats(ctx, instance)
+			if err != nil {
+				swiftstorage.DeleteMetrics(instance)
+				instance.Status.Conditions.MarkUnknown(
+					swiftv1beta1.SwiftStorageAlertsCondition,
+					condition.ErrorReason,
+					swiftv1beta1.SwiftStorageAlertsErrorMessage,
+					err.Error())
+			} else if messages := swiftstorage.EvaluateAlerts(instance.Spec.Alerts, stats, policies); len(messages) > 0 {
+				swiftstorage.UpdateMetrics(instance, stats, policies)
+				instance.Status.Conditions.MarkFalse(
+					swiftv1beta1.SwiftStorageAlertsCondition,
+					condition.ErrorReason,
+					condition.SeverityWarning,
+					swiftv1beta1.SwiftStorageAlertsExceededMessage,
+					strings.Join(messages, ", "))
+			} else {
+				swiftstorage.UpdateMetrics(instance, stats, policies)
+				instance.Status.Conditions.MarkTrue(swiftv1beta1.SwiftStorageAlertsCondition, swiftv1beta1.SwiftStorageAlertsReadyMessage)
+			}
+			if result.RequeueAfter == 0 || result.RequeueAfter > swiftstorage.AlertsInterval {
+				result = ctrl.Result{RequeueAfter: swiftstorage.AlertsInterval}
+			}
+		} else {
+			swiftstorage.DeleteMetrics(instance)
+			instance.Status.Conditions.Remove(swiftv1beta1.SwiftStorageAlertsCondition)
 		}
 	}
 
+	if err := r.Status().Update(ctx, instance); err != nil {
+		return ctrl.Result{}, err
+	}
+
 	r.Log.Info(fmt.Sprintf("Reconciled SwiftStorage '%s' successfully", instance.Name))
-	return ctrl.Result{}, nil
+	return result, nil
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *SwiftStorageReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	// Reconcile the SwiftStorages using a changed CA bundle Secret
+	secretFilter := func(o client.Object) []reconcile.Request {
+		result := []reconcile.Request{}
+		swiftStorages := &swiftv1beta1.SwiftStorageList{}
+		err := r.Client.List(context.Background(), swiftStorages, client.InNamespace(o.GetNamespace()))
+		if err != nil {
+			return nil
+		}
+		for _, cr := range swiftStorages.Items {
+			if cr.Spec.CABundleSecretName != o.GetName() {
+				continue
+			}
+			name := client.ObjectKey{
+				Namespace: o.GetNamespace(),
+				Name:      cr.Name,
+			}
+			result = append(result, reconcile.Request{NamespacedName: name})
+		}
+		return result
+	}
+
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&swiftv1beta1.SwiftStorage{}).
 		Owns(&corev1.ConfigMap{}).
 		Owns(&appsv1.StatefulSet{}).
 		Owns(&corev1.Service{}).
 		Owns(&networkingv1.NetworkPolicy{}).
+		Watches(&source.Kind{Type: &corev1.Secret{}}, handler.EnqueueRequestsFromMapFunc(secretFilter)).
 		Complete(r)
 }
@@ -20,15 +20,52 @@ const (
 	ProxyPort     int32 = 8080
 	MemcachedPort int32 = 11211
 
+	// Port of the proxy server if the reverse proxy sidecar is enabled
+	ProxyBackendPort int32 = 8081
+
 	AccountServerPort   int32 = 6202
 	ContainerServerPort int32 = 6201
 	ObjectServerPort    int32 = 6200
 	RsyncPort           int32 = 873
 
+	// Ports of the dedicated replication servers
+	AccountReplicationPort   int32 = 6212
+	ContainerReplicationPort int32 = 6211
+	ObjectReplicationPort    int32 = 6210
+
 	ServiceName        = "swift"
 	ServiceType        = "object-store"
 	ServiceAccount     = "swift-swift"
 	ServiceDescription = "Swift Object Storage"
 
 	ClaimName = "srv"
+
+	// Default parent directory of all devices
+	DevicesRoot = "/srv/node"
+
+	// Directory of the recon cache. It is shared between all containers
+	// of a storage pod, so that the recon middleware of the servers
+	// reports the stats of the replicators, auditors and updaters
+	ReconCachePath = "/var/cache/swift"
+
+	// Region and zone of all ring devices
+	RingRegion = 1
+	RingZone   = 1
+
+	// Seconds given to the ring rebalance Job to publish the rings when
+	// being terminated
+	RingJobTerminationGracePeriod = 120
+
+	// Directory shared between the Swift services and the log forwarding
+	// sidecar, containing the syslog socket
+	LogSocketDir = "/var/run/swift-syslog"
+
+	// ConfigHashAnnotation of the proxy pods contains the hash of the
+	// rendered configuration including the service password, so that
+	// the pods are restarted on a configuration change
+	ConfigHashAnnotation = "swift.openstack.org/config-hash"
+
+	// CABundleHashAnnotation of the storage pods contains the hash of the
+	// CA bundle, so that the pods are restarted when it is rotated
+	CABundleHashAnnotation = "swift.openstack.org/ca-bundle-hash"
 )
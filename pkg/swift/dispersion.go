@@ -0,0 +1,234 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package swift
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/env"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/util"
+
+	swiftv1beta1 "github.com/openstack-k8s-operators/swift-operator/api/v1beta1"
+)
+
+const (
+	// DispersionPopulateHash is the status hash of the populate Job
+	DispersionPopulateHash = "dispersion-populate"
+	// DispersionReportHash is the status hash of the report Job
+	DispersionReportHash = "dispersion-report"
+)
+
+// DispersionConfigName returns the name of the Secret with dispersion.conf
+func DispersionConfigName(instance *swiftv1beta1.Swift) string {
+	return instance.Name + "-dispersion-config"
+}
+
+// DispersionJobName returns the name of the populate or report Job
+func DispersionJobName(instance *swiftv1beta1.Swift, mode string) string {
+	return fmt.Sprintf("%s-dispersion-%s", instance.Name, mode)
+}
+
+// DispersionTemplates returns the Secret with dispersion.conf. It contains
+// the password of the service user, thus it is a Secret
+func DispersionTemplates(instance *swiftv1beta1.Swift, labels map[string]string, keystoneInternalURL string, password string) []util.Template {
+	templateParameters := make(map[string]interface{})
+	templateParameters["KeystoneInternalURL"] = keystoneInternalURL
+	templateParameters["ServiceUser"] = instance.Spec.SwiftProxy.ServiceUser
+	templateParameters["ServicePassword"] = password
+	templateParameters["Coverage"] = instance.Spec.Dispersion.Coverage
+
+	return []util.Template{
+		{
+			Name:               DispersionConfigName(instance),
+			Namespace:          instance.Namespace,
+			Type:               util.TemplateTypeNone,
+			InstanceType:       instance.Kind,
+			ConfigOptions:      templateParameters,
+			Labels:             labels,
+			AdditionalTemplate: map[string]string{"dispersion.conf": "/swift/dispersion/dispersion.conf"},
+		},
+	}
+}
+
+// DispersionLabels returns the labels of the dispersion Job pods, the
+// storage pods admit them to query the devices directly
+func DispersionLabels() map[string]string {
+	return map[string]string{"app.kubernetes.io/name": "SwiftDispersion"}
+}
+
+// DispersionJob returns the Job running swift-dispersion-populate or
+// swift-dispersion-report using the published rings. The report is written
+// to the termination message of its container. The ring version and the
+// configuration hash are part of the pod template, thus the Job runs again
+// if they change. The annotations attach the pod to the storage networks
+func DispersionJob(instance *swiftv1beta1.Swift, mode string, labels map[string]string, annotations map[string]string, ringVersion int64, configHash string) *batchv1.Job {
+	securityContext := GetSecurityContext()
+	backoffLimit := int32(2)
+
+	command := "swift-dispersion-populate /var/lib/config-data/dispersion/dispersion.conf"
+	if mode == "report" {
+		command = "swift-dispersion-report -j /var/lib/config-data/dispersion/dispersion.conf > /dev/termination-log"
+	}
+
+	envVars := map[string]env.Setter{}
+	envVars["RING_VERSION"] = env.SetValue(fmt.Sprint(ringVersion))
+	envVars["CONFIG_HASH"] = env.SetValue(configHash)
+
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      DispersionJobName(instance, mode),
+			Namespace: instance.Namespace,
+			Labels:    labels,
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: &backoffLimit,
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels:      util.MergeStringMaps(labels, DispersionLabels()),
+					Annotations: annotations,
+				},
+				Spec: corev1.PodSpec{
+					RestartPolicy:      corev1.RestartPolicyNever,
+					ServiceAccountName: ServiceAccount,
+					ImagePullSecrets:   instance.Spec.ImagePullSecrets,
+					SecurityContext: &corev1.PodSecurityContext{
+						SeccompProfile: &corev1.SeccompProfile{
+							Type: corev1.SeccompProfileTypeRuntimeDefault,
+						},
+					},
+					Containers: []corev1.Container{
+						{
+							Name:            "dispersion-" + mode,
+							Image:           instance.Spec.SwiftProxy.ContainerImageProxy,
+							ImagePullPolicy: corev1.PullIfNotPresent,
+							SecurityContext: &securityContext,
+							Env:             env.MergeEnvs([]corev1.EnvVar{}, envVars),
+							Command: []string{
+								"/bin/sh", "-c",
+								"cp -t /etc/swift/ /var/lib/config-data/swiftconf/* && " +
+									"tar -xzf /var/lib/config-data/rings/swiftrings.tar.gz -C /etc/swift/ && " +
+									"{ [ ! -e /etc/swift/storage-policies.conf ] || cat /etc/swift/storage-policies.conf >> /etc/swift/swift.conf; } && " +
+									command,
+							},
+							VolumeMounts: []corev1.VolumeMount{
+								{
+									Name:      "swiftconf",
+									MountPath: "/var/lib/config-data/swiftconf",
+									ReadOnly:  true,
+								},
+								{
+									Name:      "ring-data",
+									MountPath: "/var/lib/config-data/rings",
+									ReadOnly:  true,
+								},
+								{
+									Name:      "dispersion-config",
+									MountPath: "/var/lib/config-data/dispersion",
+									ReadOnly:  true,
+								},
+								{
+									Name:      "etc-swift",
+									MountPath: "/etc/swift",
+								},
+							},
+						},
+					},
+					Volumes: []corev1.Volume{
+						{
+							Name: "swiftconf",
+							VolumeSource: corev1.VolumeSource{
+								Secret: &corev1.SecretVolumeSource{
+									SecretName: instance.Spec.SwiftConfSecret,
+								},
+							},
+						},
+						RingDataVolume(instance.Spec.RingDistribution, false),
+						{
+							Name: "dispersion-config",
+							VolumeSource: corev1.VolumeSource{
+								Secret: &corev1.SecretVolumeSource{
+									SecretName: DispersionConfigName(instance),
+								},
+							},
+						},
+						{
+							Name: "etc-swift",
+							VolumeSource: corev1.VolumeSource{
+								EmptyDir: &corev1.EmptyDirVolumeSource{},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// NextDispersionReportTime returns the time of the next periodic report, or
+// nil if there was no report yet
+func NextDispersionReportTime(instance *swiftv1beta1.Swift) *time.Time {
+	if instance.Status.Dispersion == nil || instance.Status.Dispersion.LastReportTime == nil {
+		return nil
+	}
+	next := instance.Status.Dispersion.LastReportTime.Add(time.Duration(instance.Spec.Dispersion.ReportIntervalSeconds) * time.Second)
+	return &next
+}
+
+// parseDispersionResult converts the report of one ring
+func parseDispersionResult(report map[string]float64) *swiftv1beta1.SwiftDispersionResult {
+	result := &swiftv1beta1.SwiftDispersionResult{
+		PercentFound:   fmt.Sprintf("%.2f", report["pct_found"]),
+		CopiesFound:    int64(report["copies_found"]),
+		CopiesExpected: int64(report["copies_expected"]),
+	}
+	for key, value := range report {
+		if strings.HasPrefix(key, "missing_") {
+			if result.Missing == nil {
+				result.Missing = map[string]int64{}
+			}
+			result.Missing[strings.TrimPrefix(key, "missing_")] = int64(value)
+		}
+	}
+	return result
+}
+
+// ParseDispersionReport parses the JSON output of swift-dispersion-report
+func ParseDispersionReport(message string) (*swiftv1beta1.SwiftDispersionStatus, error) {
+	report := map[string]map[string]float64{}
+	if err := json.Unmarshal([]byte(message), &report); err != nil {
+		return nil, fmt.Errorf("invalid dispersion report: %w", err)
+	}
+
+	status := &swiftv1beta1.SwiftDispersionStatus{}
+	if object, ok := report["object"]; ok {
+		status.Object = parseDispersionResult(object)
+	}
+	if container, ok := report["container"]; ok {
+		status.Container = parseDispersionResult(container)
+	}
+	if status.Object == nil && status.Container == nil {
+		return nil, fmt.Errorf("dispersion report without results")
+	}
+	return status, nil
+}
@@ -0,0 +1,86 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package swift
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	swiftv1beta1 "github.com/openstack-k8s-operators/swift-operator/api/v1beta1"
+)
+
+func TestParseDispersionReport(t *testing.T) {
+	tests := []struct {
+		name     string
+		message  string
+		expected *swiftv1beta1.SwiftDispersionStatus
+		err      string
+	}{
+		{
+			name:    "object and container",
+			message: `{"object": {"retries": 0, "missing_two": 0, "copies_found": 7863, "missing_one": 3, "copies_expected": 7866, "pct_found": 99.96, "overlapping": 0, "missing_all": 0}, "container": {"retries": 0, "copies_found": 12534, "copies_expected": 12534, "pct_found": 100.0, "overlapping": 15, "missing_all": 0}}`,
+			expected: &swiftv1beta1.SwiftDispersionStatus{
+				Object: &swiftv1beta1.SwiftDispersionResult{
+					PercentFound:   "99.96",
+					CopiesFound:    7863,
+					CopiesExpected: 7866,
+					Missing:        map[string]int64{"one": 3, "two": 0, "all": 0},
+				},
+				Container: &swiftv1beta1.SwiftDispersionResult{
+					PercentFound:   "100.00",
+					CopiesFound:    12534,
+					CopiesExpected: 12534,
+					Missing:        map[string]int64{"all": 0},
+				},
+			},
+		},
+		{
+			name:    "object only",
+			message: `{"object": {"copies_found": 2, "copies_expected": 3, "pct_found": 66.666}}`,
+			expected: &swiftv1beta1.SwiftDispersionStatus{
+				Object: &swiftv1beta1.SwiftDispersionResult{
+					PercentFound:   "66.67",
+					CopiesFound:    2,
+					CopiesExpected: 3,
+				},
+			},
+		},
+		{
+			name:    "no results",
+			message: `{}`,
+			err:     "dispersion report without results",
+		},
+		{
+			name:    "invalid",
+			message: "ERROR: Authorization Failure",
+			err:     "invalid dispersion report: invalid character 'E' looking for beginning of value",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := NewWithT(t)
+			status, err := ParseDispersionReport(test.message)
+			if test.err != "" {
+				g.Expect(err).To(MatchError(test.err))
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(status).To(Equal(test.expected))
+		})
+	}
+}
@@ -16,8 +16,16 @@ limitations under the License.
 package swift
 
 import (
-	corev1 "k8s.io/api/core/v1"
+	"context"
+	"fmt"
 	"math/rand"
+	"net"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/pod"
 )
 
 func GetSecurityContext() corev1.SecurityContext {
@@ -50,3 +58,66 @@ func RandomString(length int) string {
 	}
 	return string(str)
 }
+
+// OptionalValue returns the value as string or an empty string if unset, so
+// that templates can distinguish between unset and 0
+func OptionalValue(value *int32) string {
+	if value == nil {
+		return ""
+	}
+	return fmt.Sprint(*value)
+}
+
+// GetJobTerminationMessage returns the termination message of a succeeded
+// pod of the Job, or an empty string if there is none. Jobs use it to report
+// results back to the operator
+func GetJobTerminationMessage(ctx context.Context, h *helper.Helper, namespace string, jobName string) (string, error) {
+	pods, err := pod.GetPodListWithLabel(ctx, h, namespace, map[string]string{"job-name": jobName})
+	if err != nil {
+		return "", err
+	}
+	for _, p := range pods.Items {
+		if p.Status.Phase != corev1.PodSucceeded {
+			continue
+		}
+		for _, status := range p.Status.ContainerStatuses {
+			if status.State.Terminated != nil && status.State.Terminated.Message != "" {
+				return strings.TrimSpace(status.State.Terminated.Message), nil
+			}
+		}
+	}
+	return "", nil
+}
+
+// IPv6 returns true if the first IP family is IPv6
+func IPv6(families []corev1.IPFamily) bool {
+	return len(families) > 0 && families[0] == corev1.IPv6Protocol
+}
+
+// BindIP returns the wildcard address the services bind to, or an empty
+// string to use the Swift default. Binding to :: includes IPv4 on dual-stack
+// clusters
+func BindIP(families []corev1.IPFamily) string {
+	if IPv6(families) {
+		return "::"
+	}
+	return ""
+}
+
+// LoopbackIP returns the loopback address of the first IP family
+func LoopbackIP(families []corev1.IPFamily) string {
+	if IPv6(families) {
+		return "::1"
+	}
+	return "127.0.0.1"
+}
+
+// FormatHost returns the host to be used in host:port lists and the rings,
+// IPv6 addresses are bracketed
+func FormatHost(host string) string {
+	ip := net.ParseIP(host)
+	if ip != nil && ip.To4() == nil {
+		return "[" + host + "]"
+	}
+	return host
+}
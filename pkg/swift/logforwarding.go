@@ -0,0 +1,75 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package swift
+
+import (
+	corev1 "k8s.io/api/core/v1"
+
+	swiftv1beta1 "github.com/openstack-k8s-operators/swift-operator/api/v1beta1"
+)
+
+// LogForwardingTemplateParameters adds the parameters used to render the
+// rsyslog.conf and the log_address settings of the Swift services
+func LogForwardingTemplateParameters(spec swiftv1beta1.LogForwardingSpec, templateParameters map[string]interface{}) {
+	templateParameters["LogForwardingEnabled"] = spec.Enabled
+	templateParameters["LogSocket"] = LogSocketDir + "/log"
+	templateParameters["LogForwardingHost"] = spec.Host
+	templateParameters["LogForwardingPort"] = spec.Port
+	templateParameters["LogForwardingProtocol"] = spec.Protocol
+}
+
+// LogForwardingVolume returns the volume holding the syslog socket
+func LogForwardingVolume() corev1.Volume {
+	return corev1.Volume{
+		Name: "swift-syslog",
+		VolumeSource: corev1.VolumeSource{
+			EmptyDir: &corev1.EmptyDirVolumeSource{Medium: ""},
+		},
+	}
+}
+
+// LogForwardingVolumeMount returns the mount for the syslog socket directory
+func LogForwardingVolumeMount() corev1.VolumeMount {
+	return corev1.VolumeMount{
+		Name:      "swift-syslog",
+		MountPath: LogSocketDir,
+		ReadOnly:  false,
+	}
+}
+
+// LogForwardingContainer returns the sidecar that listens on the syslog
+// socket and forwards all messages to the configured endpoint. It expects
+// rsyslog.conf to be part of the config-data volume
+func LogForwardingContainer(spec swiftv1beta1.LogForwardingSpec) corev1.Container {
+	securityContext := GetSecurityContext()
+
+	return corev1.Container{
+		Name:            "log-forwarding",
+		Image:           spec.ContainerImage,
+		ImagePullPolicy: corev1.PullIfNotPresent,
+		SecurityContext: &securityContext,
+		VolumeMounts: []corev1.VolumeMount{
+			{
+				Name:      "config-data",
+				MountPath: "/var/lib/config-data/default",
+				ReadOnly:  true,
+			},
+			LogForwardingVolumeMount(),
+		},
+		Command: []string{"/usr/sbin/rsyslogd", "-n", "-i", "/tmp/rsyslogd.pid", "-f", "/var/lib/config-data/default/rsyslog.conf"},
+	}
+}
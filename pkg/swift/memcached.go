@@ -0,0 +1,135 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package swift
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/tls"
+)
+
+//+kubebuilder:rbac:groups=memcached.openstack.org,resources=memcacheds,verbs=get;list;watch
+
+// The infra-operator API is not vendored, the Memcached CR is handled as
+// unstructured object
+var memcachedGVK = schema.GroupVersionKind{
+	Group:   "memcached.openstack.org",
+	Version: "v1beta1",
+	Kind:    "Memcached",
+}
+
+// CABundleMountPath is the directory of the CA bundle in the Swift containers
+const CABundleMountPath = "/var/lib/config-data/ca-bundle"
+
+// CABundleFile is the path of the CA bundle in the Swift containers
+const CABundleFile = CABundleMountPath + "/" + tls.CABundleKey
+
+// Memcached describes the shared Memcached instance used by Swift
+type Memcached struct {
+	// Servers of the instance, host:port
+	Servers []string
+	// TLS is true if the instance only accepts TLS connections
+	TLS bool
+}
+
+// GetMemcached returns the server list and TLS setting of the Memcached
+// instance. The server list is empty if the instance is not ready yet
+func GetMemcached(ctx context.Context, h *helper.Helper, name string, namespace string) (*Memcached, error) {
+	memcached := &unstructured.Unstructured{}
+	memcached.SetGroupVersionKind(memcachedGVK)
+	err := h.GetClient().Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, memcached)
+	if err != nil {
+		return nil, err
+	}
+
+	readyCount, _, err := unstructured.NestedInt64(memcached.Object, "status", "readyCount")
+	if err != nil {
+		return nil, err
+	}
+	if readyCount == 0 {
+		return &Memcached{Servers: []string{}}, nil
+	}
+	servers, _, err := unstructured.NestedStringSlice(memcached.Object, "status", "serverList")
+	if err != nil {
+		return nil, err
+	}
+	tlsSupport, _, err := unstructured.NestedBool(memcached.Object, "status", "tlsSupport")
+	if err != nil {
+		return nil, err
+	}
+	return &Memcached{Servers: servers, TLS: tlsSupport}, nil
+}
+
+// MemcacheServers returns the memcache_servers setting. Without a shared
+// Memcached instance the sidecar on localhost is used
+func MemcacheServers(servers []string, families []corev1.IPFamily, port int32) string {
+	if len(servers) > 0 {
+		return strings.Join(servers, ",")
+	}
+	return fmt.Sprintf("%s:%d", FormatHost(LoopbackIP(families)), port)
+}
+
+// MemcacheTemplateParameters adds the parameters used to render the memcache
+// settings of the Swift services. memcached is nil if the sidecar is used
+func MemcacheTemplateParameters(memcached *Memcached, families []corev1.IPFamily, port int32, caBundleSecret string, templateParameters map[string]interface{}) {
+	servers := []string{}
+	tlsEnabled := false
+	if memcached != nil {
+		servers = memcached.Servers
+		tlsEnabled = memcached.TLS
+	}
+	templateParameters["MemcacheServers"] = MemcacheServers(servers, families, port)
+	templateParameters["MemcacheTLS"] = tlsEnabled
+	// Without a CA bundle the system trust store is used
+	templateParameters["MemcacheCAFile"] = ""
+	if caBundleSecret != "" {
+		templateParameters["MemcacheCAFile"] = CABundleFile
+	}
+}
+
+// CABundleVolume returns the volume with the CA bundle from the given Secret
+func CABundleVolume(caBundleSecret string) corev1.Volume {
+	return corev1.Volume{
+		Name: "ca-bundle",
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName: caBundleSecret,
+				Items: []corev1.KeyToPath{{
+					Key:  tls.CABundleKey,
+					Path: tls.CABundleKey,
+				}},
+			},
+		},
+	}
+}
+
+// CABundleVolumeMount returns the mount for the CA bundle volume
+func CABundleVolumeMount() corev1.VolumeMount {
+	return corev1.VolumeMount{
+		Name:      "ca-bundle",
+		MountPath: CABundleMountPath,
+		ReadOnly:  true,
+	}
+}
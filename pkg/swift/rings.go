@@ -0,0 +1,78 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package swift
+
+import (
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	swiftv1beta1 "github.com/openstack-k8s-operators/swift-operator/api/v1beta1"
+)
+
+// RingDataVolume returns the volume with the published rings, mounted from
+// the ConfigMap or the Secret depending on the ring distribution. Optional
+// volumes allow pods to start before the rings are created
+func RingDataVolume(distribution string, optional bool) corev1.Volume {
+	// Unset unless optional, required volumes are unchanged for existing
+	// pods
+	var optionalVolume *bool
+	if optional {
+		optionalVolume = &optional
+	}
+	if distribution == swiftv1beta1.RingDistributionSecret {
+		return corev1.Volume{
+			Name: "ring-data",
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: swiftv1beta1.RingSecretName,
+					Optional:   optionalVolume,
+				},
+			},
+		}
+	}
+	return corev1.Volume{
+		Name: "ring-data",
+		VolumeSource: corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: swiftv1beta1.RingConfigMapName,
+				},
+				Optional: optionalVolume,
+			},
+		},
+	}
+}
+
+// DeviceLists returns the device lists of all SwiftStorage instances from
+// the data of the device ConfigMap
+func DeviceLists(data map[string]string) string {
+	keys := []string{}
+	for key := range data {
+		if strings.HasSuffix(key, swiftv1beta1.DeviceListKeySuffix) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	var devices strings.Builder
+	for _, key := range keys {
+		devices.WriteString(data[key])
+	}
+	return devices.String()
+}
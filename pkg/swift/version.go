@@ -0,0 +1,144 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package swift
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	swiftv1beta1 "github.com/openstack-k8s-operators/swift-operator/api/v1beta1"
+)
+
+// VersionSkewRule is one entry of the support matrix. Storage services are
+// upgraded before the proxies, thus the storage images might be a few
+// releases ahead of the proxy image, but not the other way round
+type VersionSkewRule struct {
+	// Major version both images have to use
+	Major int
+	// Maximum number of minor releases the storage might be ahead
+	MaxStorageAhead int
+	// Maximum number of minor releases the proxy might be ahead
+	MaxProxyAhead int
+}
+
+// SupportMatrix lists the supported version combinations per major version.
+// Combinations across major versions are never supported
+var SupportMatrix = []VersionSkewRule{
+	{Major: 2, MaxStorageAhead: 2, MaxProxyAhead: 0},
+}
+
+// VersionJobName returns the name of the Job detecting the Swift version of
+// the given component image
+func VersionJobName(instance *swiftv1beta1.Swift, component string) string {
+	return fmt.Sprintf("%s-version-%s", instance.Name, component)
+}
+
+// VersionJob returns a Job that writes the Swift version of the image to
+// the termination message of its container
+func VersionJob(instance *swiftv1beta1.Swift, component string, image string, labels map[string]string) *batchv1.Job {
+	securityContext := GetSecurityContext()
+	backoffLimit := int32(2)
+
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      VersionJobName(instance, component),
+			Namespace: instance.Namespace,
+			Labels:    labels,
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: &backoffLimit,
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					RestartPolicy:      corev1.RestartPolicyNever,
+					ServiceAccountName: ServiceAccount,
+					ImagePullSecrets:   instance.Spec.ImagePullSecrets,
+					SecurityContext: &corev1.PodSecurityContext{
+						SeccompProfile: &corev1.SeccompProfile{
+							Type: corev1.SeccompProfileTypeRuntimeDefault,
+						},
+					},
+					Containers: []corev1.Container{
+						{
+							Name:            "version",
+							Image:           image,
+							ImagePullPolicy: corev1.PullIfNotPresent,
+							SecurityContext: &securityContext,
+							Command: []string{
+								"/bin/sh", "-c",
+								"python3 -c 'import swift; print(swift.__version__)' > /dev/termination-log",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// parseVersion returns the major and minor version of a Swift version, eg.
+// 2.32.1.dev12
+func parseVersion(version string) (int, int, error) {
+	parts := strings.Split(version, ".")
+	if len(parts) < 2 {
+		return 0, 0, fmt.Errorf("invalid version %q", version)
+	}
+	major, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid version %q", version)
+	}
+	minor, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid version %q", version)
+	}
+	return major, minor, nil
+}
+
+// ValidateVersionSkew checks the proxy version against the storage version
+// using the SupportMatrix and returns an error describing an unsupported
+// combination
+func ValidateVersionSkew(proxyVersion string, storageVersion string) error {
+	proxyMajor, proxyMinor, err := parseVersion(proxyVersion)
+	if err != nil {
+		return err
+	}
+	storageMajor, storageMinor, err := parseVersion(storageVersion)
+	if err != nil {
+		return err
+	}
+	if proxyMajor != storageMajor {
+		return fmt.Errorf("proxy %s and storage %s use different major versions", proxyVersion, storageVersion)
+	}
+
+	for _, rule := range SupportMatrix {
+		if rule.Major != proxyMajor {
+			continue
+		}
+		if storageMinor-proxyMinor > rule.MaxStorageAhead {
+			return fmt.Errorf("storage %s is more than %d releases ahead of proxy %s", storageVersion, rule.MaxStorageAhead, proxyVersion)
+		}
+		if proxyMinor-storageMinor > rule.MaxProxyAhead {
+			return fmt.Errorf("proxy %s is newer than storage %s, storage must be updated first", proxyVersion, storageVersion)
+		}
+		return nil
+	}
+	return fmt.Errorf("major version %d is not supported", proxyMajor)
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package swiftproxy
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	swiftv1beta1 "github.com/openstack-k8s-operators/swift-operator/api/v1beta1"
+	swift "github.com/openstack-k8s-operators/swift-operator/pkg/swift"
+)
+
+// affinityLocation matches a region or a zone of a region, eg. r1 or r1z2
+var affinityLocation = regexp.MustCompile(`^r([0-9]+)(z([0-9]+))?$`)
+
+// RingLocations returns the regions and zones of the ring devices, eg. r1 and
+// r1z2, from the CSV device list. Without a device list all devices are in
+// the default region and zone
+func RingLocations(devices string) map[string]bool {
+	locations := map[string]bool{}
+	for _, line := range strings.Split(devices, "\n") {
+		fields := strings.Split(line, ",")
+		if len(fields) < 2 {
+			continue
+		}
+		locations[fmt.Sprintf("r%s", fields[0])] = true
+		locations[fmt.Sprintf("r%sz%s", fields[0], fields[1])] = true
+	}
+	if len(locations) == 0 {
+		locations[fmt.Sprintf("r%d", swift.RingRegion)] = true
+		locations[fmt.Sprintf("r%dz%d", swift.RingRegion, swift.RingZone)] = true
+	}
+	return locations
+}
+
+// validateAffinityLocation checks the syntax of the location and that its
+// region and zone are used in the rings
+func validateAffinityLocation(field string, location string, locations map[string]bool) error {
+	match := affinityLocation.FindStringSubmatch(location)
+	if match == nil {
+		return fmt.Errorf("%s: invalid location %q, expected r<region> or r<region>z<zone>", field, location)
+	}
+	region, _ := strconv.Atoi(match[1])
+	if !locations[fmt.Sprintf("r%d", region)] {
+		return fmt.Errorf("%s: region %d is not used in the rings", field, region)
+	}
+	if match[3] != "" {
+		if zone, _ := strconv.Atoi(match[3]); !locations[fmt.Sprintf("r%dz%d", region, zone)] {
+			return fmt.Errorf("%s: zone %d is not used in the rings", field, zone)
+		}
+	}
+	return nil
+}
+
+// ValidateReplicaAffinity checks the read and write affinity against the
+// regions and zones of the ring devices, and that they don't conflict with
+// the sorting method
+func ValidateReplicaAffinity(instance *swiftv1beta1.SwiftProxy, locations map[string]bool) error {
+	affinity := instance.Spec.ReplicaAffinity
+	if affinity.ReadAffinity != "" {
+		for _, entry := range strings.Split(affinity.ReadAffinity, ",") {
+			location, priority, found := strings.Cut(strings.TrimSpace(entry), "=")
+			if !found {
+				return fmt.Errorf("replicaAffinity.readAffinity: %q is not <location>=<priority>", entry)
+			}
+			if _, err := strconv.Atoi(strings.TrimSpace(priority)); err != nil {
+				return fmt.Errorf("replicaAffinity.readAffinity: invalid priority %q", priority)
+			}
+			if err := validateAffinityLocation("replicaAffinity.readAffinity", strings.TrimSpace(location), locations); err != nil {
+				return err
+			}
+		}
+	}
+	if affinity.WriteAffinity != "" {
+		for _, location := range strings.Split(affinity.WriteAffinity, ",") {
+			if err := validateAffinityLocation("replicaAffinity.writeAffinity", strings.TrimSpace(location), locations); err != nil {
+				return err
+			}
+		}
+	}
+	if affinity.WriteAffinityNodeCount != "" && affinity.WriteAffinity == "" {
+		return fmt.Errorf("replicaAffinity.writeAffinityNodeCount requires replicaAffinity.writeAffinity")
+	}
+	if affinity.ReadAffinity != "" && instance.Spec.ProxyServer.SortingMethod != "" {
+		return fmt.Errorf("proxyServer.sortingMethod can't be used with replicaAffinity.readAffinity")
+	}
+	if instance.Spec.ProxyServer.TimingExpiry != nil && instance.Spec.ProxyServer.SortingMethod != "timing" {
+		return fmt.Errorf("proxyServer.timingExpiry requires proxyServer.sortingMethod timing")
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package swiftproxy
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/util"
+
+	swiftv1beta1 "github.com/openstack-k8s-operators/swift-operator/api/v1beta1"
+)
+
+//+kubebuilder:rbac:groups=keda.sh,resources=scaledobjects,verbs=get;list;watch;create;update;patch;delete
+
+// KEDA is an optional dependency, thus the ScaledObject is managed as
+// unstructured object
+var scaledObjectGVK = schema.GroupVersionKind{
+	Group:   "keda.sh",
+	Version: "v1alpha1",
+	Kind:    "ScaledObject",
+}
+
+func newScaledObject(instance *swiftv1beta1.SwiftProxy) *unstructured.Unstructured {
+	scaledObject := &unstructured.Unstructured{}
+	scaledObject.SetGroupVersionKind(scaledObjectGVK)
+	scaledObject.SetName(instance.Name)
+	scaledObject.SetNamespace(instance.Namespace)
+	return scaledObject
+}
+
+// EnsureScaledObject creates or updates the ScaledObject scaling the proxy
+// Deployment
+func EnsureScaledObject(ctx context.Context, h *helper.Helper, instance *swiftv1beta1.SwiftProxy, labels map[string]string) error {
+	autoscaling := instance.Spec.Autoscaling
+	if autoscaling.ServerAddress == "" || autoscaling.Query == "" {
+		return fmt.Errorf("autoscaling requires serverAddress and query")
+	}
+
+	scaledObject := newScaledObject(instance)
+	_, err := controllerutil.CreateOrPatch(ctx, h.GetClient(), scaledObject, func() error {
+		scaledObject.SetLabels(util.MergeStringMaps(scaledObject.GetLabels(), labels))
+		spec := map[string]interface{}{
+			"scaleTargetRef": map[string]interface{}{
+				"name": instance.Name,
+			},
+			"minReplicaCount": int64(autoscaling.MinReplicas),
+			"maxReplicaCount": int64(autoscaling.MaxReplicas),
+			"pollingInterval": int64(autoscaling.PollingInterval),
+			"cooldownPeriod":  int64(autoscaling.CooldownPeriod),
+			"triggers": []interface{}{
+				map[string]interface{}{
+					"type": "prometheus",
+					"metadata": map[string]interface{}{
+						"serverAddress": autoscaling.ServerAddress,
+						"query":         autoscaling.Query,
+						"threshold":     autoscaling.Threshold,
+					},
+				},
+			},
+		}
+		if err := unstructured.SetNestedMap(scaledObject.Object, spec, "spec"); err != nil {
+			return err
+		}
+		return controllerutil.SetControllerReference(h.GetBeforeObject(), scaledObject, h.GetScheme())
+	})
+	return err
+}
+
+// DeleteScaledObject deletes the ScaledObject once autoscaling is disabled.
+// Nothing is done if KEDA is not installed
+func DeleteScaledObject(ctx context.Context, h *helper.Helper, instance *swiftv1beta1.SwiftProxy) error {
+	err := h.GetClient().Delete(ctx, newScaledObject(instance))
+	if err != nil && !apierrors.IsNotFound(err) && !meta.IsNoMatchError(err) {
+		return err
+	}
+	return nil
+}
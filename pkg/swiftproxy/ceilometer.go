@@ -0,0 +1,92 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package swiftproxy
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/util"
+
+	swiftv1beta1 "github.com/openstack-k8s-operators/swift-operator/api/v1beta1"
+)
+
+//+kubebuilder:rbac:groups=rabbitmq.openstack.org,resources=transporturls,verbs=get;list;watch;create;update;patch;delete
+
+// The infra-operator API is not vendored, the TransportURL CR is handled as
+// unstructured object
+var transportURLGVK = schema.GroupVersionKind{
+	Group:   "rabbitmq.openstack.org",
+	Version: "v1beta1",
+	Kind:    "TransportURL",
+}
+
+func newTransportURL(instance *swiftv1beta1.SwiftProxy) *unstructured.Unstructured {
+	transportURL := &unstructured.Unstructured{}
+	transportURL.SetGroupVersionKind(transportURLGVK)
+	transportURL.SetName(instance.Name + "-transport")
+	transportURL.SetNamespace(instance.Namespace)
+	return transportURL
+}
+
+// EnsureTransportURL creates or updates the TransportURL of the RabbitMQ
+// cluster receiving the notifications. It returns the name of the Secret
+// with the transport URL, which is empty until the infra-operator created it
+func EnsureTransportURL(ctx context.Context, h *helper.Helper, instance *swiftv1beta1.SwiftProxy, labels map[string]string) (string, error) {
+	transportURL := newTransportURL(instance)
+	_, err := controllerutil.CreateOrPatch(ctx, h.GetClient(), transportURL, func() error {
+		transportURL.SetLabels(util.MergeStringMaps(transportURL.GetLabels(), labels))
+		err := unstructured.SetNestedField(transportURL.Object, instance.Spec.Ceilometer.RabbitMqClusterName, "spec", "rabbitmqClusterName")
+		if err != nil {
+			return err
+		}
+		return controllerutil.SetControllerReference(h.GetBeforeObject(), transportURL, h.GetScheme())
+	})
+	if err != nil {
+		return "", err
+	}
+	secretName, _, err := unstructured.NestedString(transportURL.Object, "status", "secretName")
+	return secretName, err
+}
+
+// DeleteTransportURL deletes the TransportURL once the ceilometer middleware
+// is disabled. Nothing is done if the infra-operator is not installed
+func DeleteTransportURL(ctx context.Context, h *helper.Helper, instance *swiftv1beta1.SwiftProxy) error {
+	err := h.GetClient().Delete(ctx, newTransportURL(instance))
+	if err != nil && !apierrors.IsNotFound(err) && !meta.IsNoMatchError(err) {
+		return err
+	}
+	return nil
+}
+
+// TransportURL returns the transport URL of the Secret created for the
+// TransportURL
+func TransportURL(transportSecret *corev1.Secret) (string, error) {
+	transportURL, ok := transportSecret.Data["transport_url"]
+	if !ok {
+		return "", fmt.Errorf("key transport_url not found in Secret %s", transportSecret.Name)
+	}
+	return string(transportURL), nil
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package swiftproxy
+
+import (
+	"context"
+	"net"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/util"
+
+	swiftv1beta1 "github.com/openstack-k8s-operators/swift-operator/api/v1beta1"
+)
+
+//+kubebuilder:rbac:groups=cert-manager.io,resources=certificates,verbs=get;list;watch;create;update;patch;delete
+
+// The cert-manager API is not a dependency of the operator, thus the
+// Certificate is managed as unstructured object
+var certificateGVK = schema.GroupVersionKind{
+	Group:   "cert-manager.io",
+	Version: "v1",
+	Kind:    "Certificate",
+}
+
+// TLSSecretName returns the name of the Secret with the certificate used by
+// the reverse proxy, or an empty string if TLS is not used
+func TLSSecretName(instance *swiftv1beta1.SwiftProxy) string {
+	if !instance.Spec.ReverseProxy.Enabled {
+		return ""
+	}
+	switch {
+	case instance.Spec.ReverseProxy.TLSSecret != "":
+		return instance.Spec.ReverseProxy.TLSSecret
+	case instance.Spec.TLS.SecretName != "":
+		return instance.Spec.TLS.SecretName
+	case instance.Spec.TLS.IssuerRef != nil:
+		return instance.Name + "-tls"
+	}
+	return ""
+}
+
+// EnsureCertificate requests the certificate for the given hostnames and IP
+// addresses from the cert-manager issuer and returns true once it is issued.
+// The names are sorted, thus the Certificate is only updated if they change
+func EnsureCertificate(ctx context.Context, h *helper.Helper, instance *swiftv1beta1.SwiftProxy, labels map[string]string, altNames []string) (bool, error) {
+	unique := map[string]bool{}
+	for _, name := range append(altNames, instance.Spec.TLS.DNSNames...) {
+		if name != "" {
+			unique[name] = true
+		}
+	}
+	sorted := []string{}
+	for name := range unique {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+
+	names := []interface{}{}
+	addresses := []interface{}{}
+	for _, name := range sorted {
+		if net.ParseIP(name) != nil {
+			addresses = append(addresses, name)
+		} else {
+			names = append(names, name)
+		}
+	}
+
+	cert := &unstructured.Unstructured{}
+	cert.SetGroupVersionKind(certificateGVK)
+	cert.SetName(instance.Name)
+	cert.SetNamespace(instance.Namespace)
+	_, err := controllerutil.CreateOrPatch(ctx, h.GetClient(), cert, func() error {
+		cert.SetLabels(util.MergeStringMaps(cert.GetLabels(), labels))
+		spec := map[string]interface{}{
+			"secretName":  TLSSecretName(instance),
+			"dnsNames":    names,
+			"ipAddresses": addresses,
+			"usages":      []interface{}{"server auth", "digital signature", "key encipherment"},
+			"issuerRef": map[string]interface{}{
+				"group": certificateGVK.Group,
+				"kind":  instance.Spec.TLS.IssuerRef.Kind,
+				"name":  instance.Spec.TLS.IssuerRef.Name,
+			},
+		}
+		if err := unstructured.SetNestedMap(cert.Object, spec, "spec"); err != nil {
+			return err
+		}
+		return controllerutil.SetControllerReference(h.GetBeforeObject(), cert, h.GetScheme())
+	})
+	if err != nil {
+		return false, err
+	}
+
+	conditions, _, err := unstructured.NestedSlice(cert.Object, "status", "conditions")
+	if err != nil {
+		return false, err
+	}
+	for _, c := range conditions {
+		c, ok := c.(map[string]interface{})
+		if ok && c["type"] == "Ready" && c["status"] == "True" {
+			return true, nil
+		}
+	}
+	return false, nil
+}
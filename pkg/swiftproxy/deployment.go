@@ -17,6 +17,8 @@ limitations under the License.
 package swiftproxy
 
 import (
+	"fmt"
+
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -28,7 +30,7 @@ import (
 )
 
 func Deployment(
-	instance *swiftv1beta1.SwiftProxy, labels map[string]string) *appsv1.Deployment {
+	instance *swiftv1beta1.SwiftProxy, labels map[string]string, annotations map[string]string) *appsv1.Deployment {
 
 	trueVal := true
 	securityContext := swift.GetSecurityContext()
@@ -44,13 +46,116 @@ func Deployment(
 		InitialDelaySeconds: 5,
 	}
 
-	livenessProbe.HTTPGet = &corev1.HTTPGetAction{
-		Path: "/healthcheck",
+	// With the reverse proxy enabled, the proxy server is only reachable
+	// through the sidecar, thus the probes check both
+	probeScheme := corev1.URISchemeHTTP
+	if TLSSecretName(instance) != "" {
+		probeScheme = corev1.URISchemeHTTPS
+	}
+
+	// /healthcheck returns 503 while the proxy server drains, the liveness
+	// probe only checks the port to not restart a draining container
+	livenessProbe.TCPSocket = &corev1.TCPSocketAction{
 		Port: intstr.FromInt(int(swift.ProxyPort)),
 	}
 	readinessProbe.HTTPGet = &corev1.HTTPGetAction{
-		Path: "/healthcheck",
-		Port: intstr.FromInt(int(swift.ProxyPort)),
+		Path:   "/healthcheck",
+		Port:   intstr.FromInt(int(swift.ProxyPort)),
+		Scheme: probeScheme,
+	}
+
+	proxyServerPorts := []corev1.ContainerPort{{
+		ContainerPort: swift.ProxyPort,
+		Name:          "proxy-server",
+	}}
+	if instance.Spec.ReverseProxy.Enabled {
+		proxyServerPorts = nil
+	}
+
+	proxyServerEnv := []corev1.EnvVar{}
+	if instance.Spec.ProxyServer.EventletHub != "" {
+		proxyServerEnv = append(proxyServerEnv, corev1.EnvVar{
+			Name:  "EVENTLET_HUB",
+			Value: instance.Spec.ProxyServer.EventletHub,
+		})
+	}
+	// The Keystone clients of the middlewares use the CA bundle, eg. to
+	// connect to Barbican
+	if instance.Spec.CABundleSecretName != "" {
+		proxyServerEnv = append(proxyServerEnv, corev1.EnvVar{
+			Name:  "REQUESTS_CA_BUNDLE",
+			Value: swift.CABundleFile,
+		})
+	}
+
+	var proxyServerLifecycle *corev1.Lifecycle
+	if instance.Spec.Drain.Enabled {
+		proxyServerEnv = append(proxyServerEnv, drainEnv(instance)...)
+		proxyServerLifecycle = drainLifecycle()
+	}
+
+	containers := []corev1.Container{
+		{
+			Name:            "ring-sync",
+			Image:           instance.Spec.ContainerImageProxy,
+			ImagePullPolicy: corev1.PullIfNotPresent,
+			SecurityContext: &securityContext,
+			ReadinessProbe:  readinessProbe,
+			LivenessProbe:   livenessProbe,
+			VolumeMounts:    getProxyVolumeMounts(instance),
+			Command:         []string{"/usr/local/bin/container-scripts/ring-sync.sh"},
+		},
+		{
+			Image:           instance.Spec.ContainerImageProxy,
+			Name:            "proxy-server",
+			ImagePullPolicy: corev1.PullIfNotPresent,
+			SecurityContext: &securityContext,
+			Ports:           proxyServerPorts,
+			Env:             proxyServerEnv,
+			ReadinessProbe:  readinessProbe,
+			LivenessProbe:   livenessProbe,
+			Lifecycle:       proxyServerLifecycle,
+			Resources:       instance.Spec.Resources,
+			VolumeMounts:    getProxyVolumeMounts(instance),
+			Command:         []string{"/usr/bin/swift-proxy-server", "/etc/swift/proxy-server.conf", "-v"},
+		},
+	}
+
+	// A shared Memcached instance or a server list replaces the sidecar
+	if instance.Spec.MemcachedInstance == "" && len(instance.Spec.MemcachePool.Servers) == 0 {
+		containers = append(containers, corev1.Container{
+			Image:           instance.Spec.ContainerImageMemcached,
+			Name:            "memcached",
+			ImagePullPolicy: corev1.PullIfNotPresent,
+			SecurityContext: &securityContext,
+			Ports: []corev1.ContainerPort{{
+				ContainerPort: memcachedPort(instance),
+				Name:          "memcached",
+			}},
+			VolumeMounts: getProxyVolumeMounts(instance),
+			Command:      []string{"/usr/bin/memcached", "-p", fmt.Sprint(memcachedPort(instance)), "-u", "memcached"},
+		})
+	}
+
+	if instance.Spec.LogForwarding.Enabled {
+		containers = append(containers, swift.LogForwardingContainer(instance.Spec.LogForwarding))
+	}
+
+	if instance.Spec.ReverseProxy.Enabled {
+		reverseProxy := reverseProxyContainer(instance)
+		// The requests in flight pass the reverse proxy
+		if instance.Spec.Drain.Enabled {
+			reverseProxy.Lifecycle = sleepLifecycle(instance)
+		}
+		containers = append(containers, reverseProxy)
+	}
+
+	if instance.Spec.Drain.Enabled {
+		containers = append(containers, drainContainer(instance))
+	}
+
+	if instance.Spec.Metrics.Enabled {
+		containers = append(containers, statsdExporterContainer(instance))
 	}
 
 	return &appsv1.Deployment{
@@ -65,55 +170,25 @@ func Deployment(
 			Replicas: instance.Spec.Replicas,
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
-					Labels: labels,
+					Labels:      labels,
+					Annotations: annotations,
 				},
 				Spec: corev1.PodSpec{
-					ServiceAccountName: swift.ServiceAccount,
+					ServiceAccountName:            swift.ServiceAccount,
+					ImagePullSecrets:              instance.Spec.ImagePullSecrets,
+					PriorityClassName:             instance.Spec.PriorityClassName,
+					TerminationGracePeriodSeconds: terminationGracePeriod(instance),
 					SecurityContext: &corev1.PodSecurityContext{
 						RunAsNonRoot: &trueVal,
 						SeccompProfile: &corev1.SeccompProfile{
 							Type: corev1.SeccompProfileTypeRuntimeDefault,
 						},
 					},
-					Volumes: getProxyVolumes(instance),
-					Containers: []corev1.Container{
-						{
-							Name:            "ring-sync",
-							Image:           instance.Spec.ContainerImageProxy,
-							ImagePullPolicy: corev1.PullIfNotPresent,
-							SecurityContext: &securityContext,
-							ReadinessProbe:  readinessProbe,
-							LivenessProbe:   livenessProbe,
-							VolumeMounts:    getProxyVolumeMounts(),
-							Command:         []string{"/usr/local/bin/container-scripts/ring-sync.sh"},
-						},
-						{
-							Image:           instance.Spec.ContainerImageProxy,
-							Name:            "proxy-server",
-							ImagePullPolicy: corev1.PullIfNotPresent,
-							SecurityContext: &securityContext,
-							Ports: []corev1.ContainerPort{{
-								ContainerPort: swift.ProxyPort,
-								Name:          "proxy-server",
-							}},
-							ReadinessProbe: readinessProbe,
-							LivenessProbe:  livenessProbe,
-							VolumeMounts:   getProxyVolumeMounts(),
-							Command:        []string{"/usr/bin/swift-proxy-server", "/etc/swift/proxy-server.conf", "-v"},
-						},
-						{
-							Image:           instance.Spec.ContainerImageMemcached,
-							Name:            "memcached",
-							ImagePullPolicy: corev1.PullIfNotPresent,
-							SecurityContext: &securityContext,
-							Ports: []corev1.ContainerPort{{
-								ContainerPort: swift.MemcachedPort,
-								Name:          "memcached",
-							}},
-							VolumeMounts: getProxyVolumeMounts(),
-							Command:      []string{"/usr/bin/memcached", "-p", "11211", "-u", "memcached"},
-						},
-					},
+					NodeSelector: instance.Spec.NodeSelector,
+					Tolerations:  instance.Spec.Tolerations,
+					Affinity:     instance.Spec.Affinity,
+					Volumes:      getProxyVolumes(instance),
+					Containers:   containers,
 				},
 			},
 		},
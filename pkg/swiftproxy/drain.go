@@ -0,0 +1,114 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package swiftproxy
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+
+	swiftv1beta1 "github.com/openstack-k8s-operators/swift-operator/api/v1beta1"
+	swift "github.com/openstack-k8s-operators/swift-operator/pkg/swift"
+)
+
+// DrainFile is the disable_path of the healthcheck middleware. /etc/swift
+// is shared by all containers of the pod
+const DrainFile = "/etc/swift/proxy-server.drain"
+
+// drainEnv returns the environment of the drain script
+func drainEnv(instance *swiftv1beta1.SwiftProxy) []corev1.EnvVar {
+	return []corev1.EnvVar{
+		{
+			Name:  "DRAIN_FILE",
+			Value: DrainFile,
+		},
+		{
+			Name:  "DRAIN_SECONDS",
+			Value: fmt.Sprint(instance.Spec.Drain.Seconds),
+		},
+	}
+}
+
+// drainLifecycle returns the preStop hook draining the proxy server
+func drainLifecycle() *corev1.Lifecycle {
+	return &corev1.Lifecycle{
+		PreStop: &corev1.LifecycleHandler{
+			Exec: &corev1.ExecAction{
+				Command: []string{"/usr/local/bin/container-scripts/drain.sh", "stop"},
+			},
+		},
+	}
+}
+
+// sleepLifecycle returns a preStop hook keeping the container running while
+// the proxy server drains
+func sleepLifecycle(instance *swiftv1beta1.SwiftProxy) *corev1.Lifecycle {
+	return &corev1.Lifecycle{
+		PreStop: &corev1.LifecycleHandler{
+			Exec: &corev1.ExecAction{
+				Command: []string{"/bin/sleep", fmt.Sprint(instance.Spec.Drain.Seconds)},
+			},
+		},
+	}
+}
+
+// drainContainer returns the container draining the proxy while the pod
+// has the drain annotation
+func drainContainer(instance *swiftv1beta1.SwiftProxy) corev1.Container {
+	securityContext := swift.GetSecurityContext()
+
+	return corev1.Container{
+		Name:            "drain",
+		Image:           instance.Spec.ContainerImageProxy,
+		ImagePullPolicy: corev1.PullIfNotPresent,
+		SecurityContext: &securityContext,
+		Env:             drainEnv(instance),
+		VolumeMounts: append(getProxyVolumeMounts(instance), corev1.VolumeMount{
+			Name:      "podinfo",
+			MountPath: "/etc/podinfo",
+			ReadOnly:  true,
+		}),
+		Command: []string{"/usr/local/bin/container-scripts/drain.sh", "watch"},
+	}
+}
+
+// podInfoVolume returns the volume with the annotations of the pod
+func podInfoVolume() corev1.Volume {
+	return corev1.Volume{
+		Name: "podinfo",
+		VolumeSource: corev1.VolumeSource{
+			DownwardAPI: &corev1.DownwardAPIVolumeSource{
+				Items: []corev1.DownwardAPIVolumeFile{{
+					Path: "annotations",
+					FieldRef: &corev1.ObjectFieldSelector{
+						FieldPath: "metadata.annotations",
+					},
+				}},
+			},
+		},
+	}
+}
+
+// terminationGracePeriod returns the termination grace period of the proxy
+// pods, leaving the proxy server time to stop once it is drained
+func terminationGracePeriod(instance *swiftv1beta1.SwiftProxy) *int64 {
+	if !instance.Spec.Drain.Enabled {
+		return nil
+	}
+	seconds := int64(instance.Spec.Drain.Seconds) + 30
+	return &seconds
+}
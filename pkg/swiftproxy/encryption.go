@@ -0,0 +1,110 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package swiftproxy
+
+import (
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+
+	swiftv1beta1 "github.com/openstack-k8s-operators/swift-operator/api/v1beta1"
+)
+
+// KMIPCertMountPath is the directory of the KMIP client certificate
+const KMIPCertMountPath = "/var/lib/config-data/kmip-tls"
+
+// keymaster returns the keymaster middleware fetching the root secret
+func keymaster(instance *swiftv1beta1.SwiftProxy) string {
+	if instance.Spec.Encryption.Keymaster == "KMIP" {
+		return "kmip_keymaster"
+	}
+	return "kms_keymaster"
+}
+
+// RootSecrets returns the IDs of the Barbican secrets or KMIP keys per root
+// secret ID, "" is the ID of the default root secret
+func RootSecrets(instance *swiftv1beta1.SwiftProxy, keySecret *corev1.Secret) (map[string]string, error) {
+	encryption := instance.Spec.Encryption
+	rootSecrets := map[string]string{}
+	if keyID, ok := keySecret.Data[encryption.KeySelector]; ok {
+		rootSecrets[""] = string(keyID)
+	} else if len(encryption.RootSecrets) == 0 {
+		return nil, fmt.Errorf("key %s not found in Secret %s", encryption.KeySelector, keySecret.Name)
+	}
+	for _, rootSecret := range encryption.RootSecrets {
+		keyID, ok := keySecret.Data[rootSecret.KeySelector]
+		if !ok {
+			return nil, fmt.Errorf("key %s not found in Secret %s", rootSecret.KeySelector, keySecret.Name)
+		}
+		rootSecrets[rootSecret.ID] = string(keyID)
+	}
+	if _, ok := rootSecrets[encryption.ActiveRootSecretID]; !ok {
+		return nil, fmt.Errorf("active root secret %s not found in rootSecrets", ActiveRootSecretID(instance))
+	}
+	return rootSecrets, nil
+}
+
+// ActiveRootSecretID returns the ID of the root secret used to encrypt new
+// objects
+func ActiveRootSecretID(instance *swiftv1beta1.SwiftProxy) string {
+	if instance.Spec.Encryption.ActiveRootSecretID == "" {
+		return "default"
+	}
+	return instance.Spec.Encryption.ActiveRootSecretID
+}
+
+// rootSecretOptions returns the keymaster options of the additional root
+// secrets
+func rootSecretOptions(rootSecrets map[string]string) []string {
+	options := []string{}
+	for id, keyID := range rootSecrets {
+		if id != "" {
+			options = append(options, fmt.Sprintf("key_id_%s = %s", id, keyID))
+		}
+	}
+	sort.Strings(options)
+	return options
+}
+
+// KMIPCertSecretName returns the name of the Secret with the KMIP client
+// certificate mounted into the proxy pods, or "" if none is used
+func KMIPCertSecretName(instance *swiftv1beta1.SwiftProxy) string {
+	if !instance.Spec.Encryption.Enabled || instance.Spec.Encryption.Keymaster != "KMIP" {
+		return ""
+	}
+	return instance.Spec.Encryption.KMIP.CertSecret
+}
+
+func kmipCertVolume(instance *swiftv1beta1.SwiftProxy) corev1.Volume {
+	return corev1.Volume{
+		Name: "kmip-tls",
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName: KMIPCertSecretName(instance),
+			},
+		},
+	}
+}
+
+func kmipCertVolumeMount() corev1.VolumeMount {
+	return corev1.VolumeMount{
+		Name:      "kmip-tls",
+		MountPath: KMIPCertMountPath,
+		ReadOnly:  true,
+	}
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package swiftproxy
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/service"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/util"
+
+	swiftv1beta1 "github.com/openstack-k8s-operators/swift-operator/api/v1beta1"
+	swift "github.com/openstack-k8s-operators/swift-operator/pkg/swift"
+)
+
+// endpointsLabel distinguishes the pods of proxies serving a single endpoint
+// type, so that the Services only select their own proxies
+const endpointsLabel = "swift.openstack.org/endpoints"
+
+// ServiceLabels returns the labels of the proxy pods and their Services
+func ServiceLabels(instance *swiftv1beta1.SwiftProxy) map[string]string {
+	if instance.Spec.Endpoints == "" || instance.Spec.Endpoints == swiftv1beta1.EndpointsAll {
+		return Labels()
+	}
+	return util.MergeStringMaps(Labels(), map[string]string{
+		endpointsLabel: strings.ToLower(instance.Spec.Endpoints),
+	})
+}
+
+// ServesEndpoint returns true if the proxies serve the given endpoint type
+func ServesEndpoint(instance *swiftv1beta1.SwiftProxy, endpointType service.Endpoint) bool {
+	switch instance.Spec.Endpoints {
+	case swiftv1beta1.EndpointsPublic:
+		return endpointType == service.EndpointPublic
+	case swiftv1beta1.EndpointsInternal:
+		return endpointType == service.EndpointInternal
+	}
+	return true
+}
+
+// OwnsKeystoneService returns true if the proxies register the Keystone
+// service. Internal proxies use the one of the public proxies
+func OwnsKeystoneService(instance *swiftv1beta1.SwiftProxy) bool {
+	return instance.Spec.Endpoints != swiftv1beta1.EndpointsInternal
+}
+
+// KeystoneEndpointName returns the name of the KeystoneEndpoint with the
+// endpoints of the proxies
+func KeystoneEndpointName(instance *swiftv1beta1.SwiftProxy) string {
+	if instance.Spec.Endpoints == swiftv1beta1.EndpointsInternal {
+		return swift.ServiceName + "-internal"
+	}
+	return swift.ServiceName
+}
+
+// DeleteStaleDeployment deletes the Deployment of the proxies if its
+// selector doesn't match the labels anymore, eg. after changing the
+// endpoints. The selector of a Deployment can't be updated
+func DeleteStaleDeployment(ctx context.Context, h *helper.Helper, instance *swiftv1beta1.SwiftProxy, labels map[string]string) error {
+	deployment := &appsv1.Deployment{}
+	err := h.GetClient().Get(ctx, types.NamespacedName{Name: instance.Name, Namespace: instance.Namespace}, deployment)
+	if apierrors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	if deployment.Spec.Selector == nil || equality.Semantic.DeepEqual(deployment.Spec.Selector.MatchLabels, labels) {
+		return nil
+	}
+	if err := h.GetClient().Delete(ctx, deployment); err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	h.GetLogger().Info(fmt.Sprintf("Deployment %s deleted to change its selector", deployment.Name))
+	return nil
+}
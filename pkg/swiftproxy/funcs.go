@@ -15,6 +15,19 @@ limitations under the License.
 
 package swiftproxy
 
+import (
+	swiftv1beta1 "github.com/openstack-k8s-operators/swift-operator/api/v1beta1"
+	swift "github.com/openstack-k8s-operators/swift-operator/pkg/swift"
+)
+
+// memcachedPort returns the port of the memcached sidecar
+func memcachedPort(instance *swiftv1beta1.SwiftProxy) int32 {
+	if instance.Spec.Ports.Memcached == 0 {
+		return swift.MemcachedPort
+	}
+	return instance.Spec.Ports.Memcached
+}
+
 func Labels() map[string]string {
 	return map[string]string{"app.kubernetes.io/name": "SwiftProxy"}
 }
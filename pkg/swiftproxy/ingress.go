@@ -0,0 +1,121 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package swiftproxy
+
+import (
+	"context"
+	"fmt"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/util"
+
+	swiftv1beta1 "github.com/openstack-k8s-operators/swift-operator/api/v1beta1"
+	swift "github.com/openstack-k8s-operators/swift-operator/pkg/swift"
+)
+
+//+kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses,verbs=get;list;watch;create;update;patch;delete
+
+// IngressEndpointURL returns the public endpoint URL served by the Ingress,
+// or an empty string if the Ingress has no host
+func IngressEndpointURL(instance *swiftv1beta1.SwiftProxy) string {
+	if instance.Spec.Ingress.Host == "" {
+		return ""
+	}
+	if instance.Spec.Ingress.TLSSecret != "" {
+		return fmt.Sprintf("https://%s", instance.Spec.Ingress.Host)
+	}
+	return fmt.Sprintf("http://%s", instance.Spec.Ingress.Host)
+}
+
+// ingressAnnotations returns the annotations of the Ingress. The
+// ingress-nginx defaults limit the body size and buffer the requests, which
+// breaks object uploads
+func ingressAnnotations(instance *swiftv1beta1.SwiftProxy) map[string]string {
+	annotations := map[string]string{
+		"nginx.ingress.kubernetes.io/proxy-body-size":         instance.Spec.Ingress.MaxBodySize,
+		"nginx.ingress.kubernetes.io/proxy-request-buffering": "off",
+	}
+	if TLSSecretName(instance) != "" {
+		annotations["nginx.ingress.kubernetes.io/backend-protocol"] = "HTTPS"
+	}
+	return util.MergeStringMaps(instance.Spec.Ingress.Annotations, annotations)
+}
+
+// EnsureIngress creates or updates the Ingress forwarding to the public
+// Service
+func EnsureIngress(ctx context.Context, h *helper.Helper, instance *swiftv1beta1.SwiftProxy, labels map[string]string, serviceName string) error {
+	pathType := networkingv1.PathTypePrefix
+	ingress := &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      instance.Name,
+			Namespace: instance.Namespace,
+		},
+	}
+	_, err := controllerutil.CreateOrPatch(ctx, h.GetClient(), ingress, func() error {
+		ingress.Labels = util.MergeStringMaps(ingress.Labels, labels)
+		ingress.Annotations = ingressAnnotations(instance)
+		ingress.Spec.IngressClassName = instance.Spec.Ingress.IngressClassName
+		ingress.Spec.Rules = []networkingv1.IngressRule{{
+			Host: instance.Spec.Ingress.Host,
+			IngressRuleValue: networkingv1.IngressRuleValue{
+				HTTP: &networkingv1.HTTPIngressRuleValue{
+					Paths: []networkingv1.HTTPIngressPath{{
+						Path:     "/",
+						PathType: &pathType,
+						Backend: networkingv1.IngressBackend{
+							Service: &networkingv1.IngressServiceBackend{
+								Name: serviceName,
+								Port: networkingv1.ServiceBackendPort{
+									Number: swift.ProxyPort,
+								},
+							},
+						},
+					}},
+				},
+			},
+		}}
+		ingress.Spec.TLS = nil
+		if instance.Spec.Ingress.TLSSecret != "" {
+			tls := networkingv1.IngressTLS{SecretName: instance.Spec.Ingress.TLSSecret}
+			if instance.Spec.Ingress.Host != "" {
+				tls.Hosts = []string{instance.Spec.Ingress.Host}
+			}
+			ingress.Spec.TLS = []networkingv1.IngressTLS{tls}
+		}
+		return controllerutil.SetControllerReference(h.GetBeforeObject(), ingress, h.GetScheme())
+	})
+	return err
+}
+
+// DeleteIngress deletes the Ingress once it is disabled
+func DeleteIngress(ctx context.Context, h *helper.Helper, instance *swiftv1beta1.SwiftProxy) error {
+	ingress := &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      instance.Name,
+			Namespace: instance.Namespace,
+		},
+	}
+	if err := h.GetClient().Delete(ctx, ingress); err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
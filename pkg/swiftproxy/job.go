@@ -0,0 +1,125 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package swiftproxy
+
+import (
+	"path/filepath"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/tls"
+
+	swiftv1beta1 "github.com/openstack-k8s-operators/swift-operator/api/v1beta1"
+	swift "github.com/openstack-k8s-operators/swift-operator/pkg/swift"
+)
+
+// secretKeyEnv returns an environment variable set from the key of the
+// Secret
+func secretKeyEnv(name string, secretName string, key string, optional bool) corev1.EnvVar {
+	return corev1.EnvVar{
+		Name: name,
+		ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: secretName,
+				},
+				Key:      key,
+				Optional: &optional,
+			},
+		},
+	}
+}
+
+// serviceUserJob returns a Job running the script with the credentials of
+// the service user in the OS_* environment variables
+func serviceUserJob(instance *swiftv1beta1.SwiftProxy, labels map[string]string, keystoneInternalURL string, name string, script string, extraEnv []corev1.EnvVar) *batchv1.Job {
+	securityContext := swift.GetSecurityContext()
+	backoffLimit := int32(6)
+
+	envVars := append([]corev1.EnvVar{
+		{
+			Name:  "OS_AUTH_URL",
+			Value: keystoneInternalURL,
+		},
+		{
+			Name:  "OS_USERNAME",
+			Value: instance.Spec.ServiceUser,
+		},
+		secretKeyEnv("OS_PASSWORD", instance.Spec.Secret, instance.Spec.PasswordSelectors.Service, false),
+	}, extraEnv...)
+
+	var scriptsVolumeDefaultMode int32 = 0755
+	volumes := []corev1.Volume{{
+		Name: "scripts",
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				DefaultMode: &scriptsVolumeDefaultMode,
+				SecretName:  instance.Name + "-scripts",
+			},
+		},
+	}}
+	volumeMounts := []corev1.VolumeMount{{
+		Name:      "scripts",
+		MountPath: "/usr/local/bin/container-scripts",
+		ReadOnly:  true,
+	}}
+	if instance.Spec.CABundleSecretName != "" {
+		volumes = append(volumes, swift.CABundleVolume(instance.Spec.CABundleSecretName))
+		volumeMounts = append(volumeMounts, swift.CABundleVolumeMount())
+		envVars = append(envVars, corev1.EnvVar{
+			Name:  "OS_CACERT",
+			Value: filepath.Join(swift.CABundleMountPath, tls.CABundleKey),
+		})
+	}
+
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      instance.Name + "-" + name,
+			Namespace: instance.Namespace,
+			Labels:    labels,
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: &backoffLimit,
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					RestartPolicy:      corev1.RestartPolicyNever,
+					ServiceAccountName: swift.ServiceAccount,
+					ImagePullSecrets:   instance.Spec.ImagePullSecrets,
+					SecurityContext: &corev1.PodSecurityContext{
+						SeccompProfile: &corev1.SeccompProfile{
+							Type: corev1.SeccompProfileTypeRuntimeDefault,
+						},
+					},
+					Containers: []corev1.Container{
+						{
+							Name:            name,
+							Image:           instance.Spec.ContainerImageProxy,
+							ImagePullPolicy: corev1.PullIfNotPresent,
+							SecurityContext: &securityContext,
+							Env:             envVars,
+							VolumeMounts:    volumeMounts,
+							Command:         []string{"/usr/bin/python3", "/usr/local/bin/container-scripts/" + script},
+						},
+					},
+					Volumes: volumes,
+				},
+			},
+		},
+	}
+}
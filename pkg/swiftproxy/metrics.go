@@ -0,0 +1,66 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package swiftproxy
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+
+	swiftv1beta1 "github.com/openstack-k8s-operators/swift-operator/api/v1beta1"
+	swift "github.com/openstack-k8s-operators/swift-operator/pkg/swift"
+)
+
+const (
+	// StatsdPort is the UDP port the proxy server sends the StatsD
+	// metrics to
+	StatsdPort int32 = 9125
+	// MetricsPort is the port of the Prometheus metrics of the sidecar
+	MetricsPort int32 = 9102
+)
+
+// statsdExporterContainer returns the sidecar translating the StatsD
+// metrics of the proxy server to Prometheus metrics
+func statsdExporterContainer(instance *swiftv1beta1.SwiftProxy) corev1.Container {
+	securityContext := swift.GetSecurityContext()
+
+	return corev1.Container{
+		Name:            "statsd-exporter",
+		Image:           instance.Spec.Metrics.ContainerImage,
+		ImagePullPolicy: corev1.PullIfNotPresent,
+		SecurityContext: &securityContext,
+		Ports: []corev1.ContainerPort{
+			{
+				ContainerPort: MetricsPort,
+				Name:          "metrics",
+			},
+		},
+		VolumeMounts: []corev1.VolumeMount{
+			{
+				Name:      "config-data",
+				MountPath: "/var/lib/config-data/default",
+				ReadOnly:  true,
+			},
+		},
+		Args: []string{
+			fmt.Sprintf("--statsd.listen-udp=127.0.0.1:%d", StatsdPort),
+			"--statsd.listen-tcp=",
+			fmt.Sprintf("--web.listen-address=:%d", MetricsPort),
+			"--statsd.mapping-config=/var/lib/config-data/default/statsd-mapping.yaml",
+		},
+	}
+}
@@ -0,0 +1,68 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package swiftproxy
+
+import (
+	"context"
+
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/util"
+
+	swiftv1beta1 "github.com/openstack-k8s-operators/swift-operator/api/v1beta1"
+)
+
+//+kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=get;list;watch;create;update;patch;delete
+
+// EnsurePodDisruptionBudget creates or updates the PodDisruptionBudget of the
+// proxy pods
+func EnsurePodDisruptionBudget(ctx context.Context, h *helper.Helper, instance *swiftv1beta1.SwiftProxy, labels map[string]string) error {
+	pdb := &policyv1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      instance.Name,
+			Namespace: instance.Namespace,
+		},
+	}
+	_, err := controllerutil.CreateOrPatch(ctx, h.GetClient(), pdb, func() error {
+		pdb.Labels = util.MergeStringMaps(pdb.Labels, labels)
+		pdb.Spec.MinAvailable = instance.Spec.PodDisruptionBudget.MinAvailable
+		pdb.Spec.Selector = &metav1.LabelSelector{
+			MatchLabels: labels,
+		}
+		return controllerutil.SetControllerReference(h.GetBeforeObject(), pdb, h.GetScheme())
+	})
+	return err
+}
+
+// DeletePodDisruptionBudget deletes the PodDisruptionBudget once it is
+// disabled or there is only one proxy left
+func DeletePodDisruptionBudget(ctx context.Context, h *helper.Helper, instance *swiftv1beta1.SwiftProxy) error {
+	pdb := &policyv1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      instance.Name,
+			Namespace: instance.Namespace,
+		},
+	}
+	if err := h.GetClient().Delete(ctx, pdb); err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,197 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package swiftproxy
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	swiftv1beta1 "github.com/openstack-k8s-operators/swift-operator/api/v1beta1"
+)
+
+// mandatoryFilters must be part of every pipeline. The probes use the
+// healthcheck middleware
+var mandatoryFilters = []string{"catch_errors", "healthcheck"}
+
+// definedFilters are the filter sections that are always part of the
+// proxy-server.conf, besides the ones of the enabled features
+var definedFilters = []string{
+	"account-quotas", "bulk", "cache", "catch_errors", "container-quotas",
+	"container_sync", "copy", "dlo", "formpost", "gatekeeper",
+	"healthcheck", "listing_formats", "proxy-logging", "ratelimit", "slo",
+	"tempurl", "versioned_writes",
+}
+
+// defaultPipeline returns the pipeline of the enabled features
+func defaultPipeline(instance *swiftv1beta1.SwiftProxy) []string {
+	spec := instance.Spec
+	pipeline := []string{"catch_errors"}
+	if spec.ForwardedHeaders.Enabled {
+		pipeline = append(pipeline, "http_proxy_to_wsgi")
+	}
+	pipeline = append(pipeline, "gatekeeper", "healthcheck", "proxy-logging", "cache")
+	if spec.DomainRemap.Enabled && spec.DomainRemap.CNAMELookup {
+		pipeline = append(pipeline, "cname_lookup")
+	}
+	if spec.DomainRemap.Enabled {
+		pipeline = append(pipeline, "domain_remap")
+	}
+	pipeline = append(pipeline, "listing_formats", "container_sync")
+	if spec.Bulk.Enabled {
+		pipeline = append(pipeline, "bulk")
+	}
+	pipeline = append(pipeline, "tempurl")
+	if spec.Ratelimit.Enabled {
+		pipeline = append(pipeline, "ratelimit")
+	}
+	if spec.TempURL.FormPost {
+		pipeline = append(pipeline, "formpost")
+	}
+	// s3api authenticates with tempauth directly
+	if TempAuth(instance) {
+		if spec.S3API.Enabled {
+			pipeline = append(pipeline, "s3api")
+		}
+		pipeline = append(pipeline, "tempauth")
+	} else {
+		if spec.S3API.Enabled {
+			pipeline = append(pipeline, "s3api", "s3token")
+		}
+		pipeline = append(pipeline, "authtoken", "keystone")
+	}
+	if spec.StaticWeb.Enabled {
+		pipeline = append(pipeline, "staticweb")
+	}
+	pipeline = append(pipeline, "copy")
+	if spec.Quotas.ContainerQuotas {
+		pipeline = append(pipeline, "container-quotas")
+	}
+	if spec.Quotas.AccountQuotas {
+		pipeline = append(pipeline, "account-quotas")
+	}
+	pipeline = append(pipeline, "slo", "dlo", "versioned_writes")
+	if spec.Symlink.Enabled || spec.VersionedWrites.AllowObjectVersioning {
+		pipeline = append(pipeline, "symlink")
+	}
+	if spec.Encryption.Enabled {
+		pipeline = append(pipeline, keymaster(instance), "encryption")
+	}
+	pipeline = append(pipeline, "proxy-logging")
+	if spec.Ceilometer.Enabled {
+		pipeline = append(pipeline, "ceilometer")
+	}
+	return append(pipeline, "proxy-server")
+}
+
+// Pipeline returns the pipeline of the proxy server, either the one given
+// in the spec or the one of the enabled features
+func Pipeline(instance *swiftv1beta1.SwiftProxy) []string {
+	if len(instance.Spec.Pipeline) > 0 {
+		return instance.Spec.Pipeline
+	}
+	return defaultPipeline(instance)
+}
+
+// ValidatePipeline checks that the pipeline given in the spec only uses
+// defined filters, contains the mandatory ones and ends with the proxy
+// server, and that all additional filters are used
+func ValidatePipeline(instance *swiftv1beta1.SwiftProxy) error {
+	defined := map[string]bool{}
+	for _, name := range definedFilters {
+		defined[name] = true
+	}
+	for _, name := range defaultPipeline(instance) {
+		defined[name] = true
+	}
+	custom := map[string]bool{}
+	for _, filter := range instance.Spec.Filters {
+		if defined[filter.Name] || filter.Name == "proxy-server" {
+			return fmt.Errorf("filter %s is already defined by the operator", filter.Name)
+		}
+		if filter.Use == "" && filter.Options["paste.filter_factory"] == "" {
+			return fmt.Errorf("filter %s requires use or the paste.filter_factory option", filter.Name)
+		}
+		for key, value := range filter.Options {
+			if strings.ContainsAny(key+value, "\n\r") {
+				return fmt.Errorf("option %s of filter %s must not contain line breaks", key, filter.Name)
+			}
+		}
+		custom[filter.Name] = true
+	}
+
+	if len(instance.Spec.Pipeline) == 0 {
+		if len(custom) > 0 {
+			return fmt.Errorf("filters require a pipeline using them")
+		}
+		return nil
+	}
+
+	pipeline := instance.Spec.Pipeline
+	if pipeline[len(pipeline)-1] != "proxy-server" {
+		return fmt.Errorf("pipeline must end with proxy-server")
+	}
+	used := map[string]bool{}
+	for _, name := range pipeline[:len(pipeline)-1] {
+		if !defined[name] && !custom[name] {
+			return fmt.Errorf("pipeline uses undefined filter %s", name)
+		}
+		// The proxy-logging is used twice to log the subrequests
+		if used[name] && name != "proxy-logging" {
+			return fmt.Errorf("pipeline uses filter %s more than once", name)
+		}
+		used[name] = true
+	}
+	for _, name := range mandatoryFilters {
+		if !used[name] {
+			return fmt.Errorf("pipeline requires %s", name)
+		}
+	}
+	for name := range custom {
+		if !used[name] {
+			return fmt.Errorf("filter %s is not used in the pipeline", name)
+		}
+	}
+	return nil
+}
+
+// filterSection is a filter section of the proxy-server.conf
+type filterSection struct {
+	Name    string
+	Options []string
+}
+
+// filterSections returns the additional filter sections
+func filterSections(instance *swiftv1beta1.SwiftProxy) []filterSection {
+	sections := []filterSection{}
+	for _, filter := range instance.Spec.Filters {
+		section := filterSection{Name: filter.Name, Options: []string{}}
+		if filter.Use != "" {
+			section.Options = append(section.Options, "use = "+filter.Use)
+		}
+		keys := make([]string, 0, len(filter.Options))
+		for key := range filter.Options {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			section.Options = append(section.Options, fmt.Sprintf("%s = %s", key, filter.Options[key]))
+		}
+		sections = append(sections, section)
+	}
+	return sections
+}
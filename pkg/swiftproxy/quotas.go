@@ -0,0 +1,38 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package swiftproxy
+
+import (
+	"fmt"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+
+	swiftv1beta1 "github.com/openstack-k8s-operators/swift-operator/api/v1beta1"
+)
+
+// AccountQuotaJob returns a Job setting the default quota on the accounts
+// without a quota
+func AccountQuotaJob(instance *swiftv1beta1.SwiftProxy, labels map[string]string, keystoneInternalURL string) *batchv1.Job {
+	envVars := []corev1.EnvVar{
+		{
+			Name:  "QUOTA_BYTES",
+			Value: fmt.Sprint(instance.Spec.Quotas.DefaultAccountQuota.Value()),
+		},
+	}
+	return serviceUserJob(instance, labels, keystoneInternalURL, "account-quotas", "account-quotas.py", envVars)
+}
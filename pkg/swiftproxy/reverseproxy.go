@@ -0,0 +1,184 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package swiftproxy
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	swiftv1beta1 "github.com/openstack-k8s-operators/swift-operator/api/v1beta1"
+	swift "github.com/openstack-k8s-operators/swift-operator/pkg/swift"
+)
+
+// reverseProxyTLSVolume returns the volume with the TLS certificate and key.
+// HAProxy loads the key from the file next to the certificate
+func reverseProxyTLSVolume(instance *swiftv1beta1.SwiftProxy) corev1.Volume {
+	return corev1.Volume{
+		Name: "reverse-proxy-tls",
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName: TLSSecretName(instance),
+				Items: []corev1.KeyToPath{
+					{
+						Key:  "tls.crt",
+						Path: "server.pem",
+					},
+					{
+						Key:  "tls.key",
+						Path: "server.pem.key",
+					},
+				},
+			},
+		},
+	}
+}
+
+// reverseProxyContainer returns the sidecar listening on the proxy port and
+// forwarding all requests to the proxy server on localhost
+func reverseProxyContainer(instance *swiftv1beta1.SwiftProxy) corev1.Container {
+	securityContext := swift.GetSecurityContext()
+
+	volumeMounts := []corev1.VolumeMount{
+		{
+			Name:      "config-data",
+			MountPath: "/var/lib/config-data/default",
+			ReadOnly:  true,
+		},
+	}
+	if TLSSecretName(instance) != "" {
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      "reverse-proxy-tls",
+			MountPath: "/var/lib/config-data/reverse-proxy-tls",
+			ReadOnly:  true,
+		})
+	}
+	if len(instance.Spec.ReverseProxy.ErrorPages) > 0 {
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      "error-pages",
+			MountPath: ErrorPagesMountPath,
+			ReadOnly:  true,
+		})
+	}
+
+	return corev1.Container{
+		Name:            "reverse-proxy",
+		Image:           instance.Spec.ReverseProxy.ContainerImage,
+		ImagePullPolicy: corev1.PullIfNotPresent,
+		SecurityContext: &securityContext,
+		Ports: []corev1.ContainerPort{{
+			ContainerPort: swift.ProxyPort,
+			Name:          "proxy-server",
+		}},
+		VolumeMounts: volumeMounts,
+		Command:      []string{"/usr/sbin/haproxy", "-db", "-f", "/var/lib/config-data/default/haproxy.cfg"},
+	}
+}
+
+// ErrorPagesMountPath is the directory of the custom error page bodies
+const ErrorPagesMountPath = "/var/lib/config-data/error-pages"
+
+// haproxyQuote returns the value as single quoted HAProxy argument, which
+// disables the escaping and environment variable expansion
+func haproxyQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
+
+// errorPageFile returns the name of the file with the body for the status
+func errorPageFile(status int32) string {
+	return fmt.Sprintf("error-%d.http", status)
+}
+
+// errorPageRules returns the HAProxy rules replacing the error responses of
+// the proxy server (http-response) and of HAProxy itself (http-error)
+func errorPageRules(instance *swiftv1beta1.SwiftProxy) []string {
+	rules := []string{}
+	for _, page := range instance.Spec.ReverseProxy.ErrorPages {
+		args := fmt.Sprintf("status %d", page.Status)
+		if page.Body != "" {
+			args += fmt.Sprintf(" content-type %s file %s/%s", haproxyQuote(page.ContentType), ErrorPagesMountPath, errorPageFile(page.Status))
+		}
+		headers := map[string]string{}
+		for name, value := range page.Headers {
+			headers[name] = value
+		}
+		if page.RetryAfter != "" {
+			headers["Retry-After"] = page.RetryAfter
+		}
+		names := make([]string, 0, len(headers))
+		for name := range headers {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			args += fmt.Sprintf(" hdr %s %s", haproxyQuote(name), haproxyQuote(headers[name]))
+		}
+
+		rules = append(rules,
+			fmt.Sprintf("http-response return %s if { status %d }", args, page.Status),
+			fmt.Sprintf("http-error %s", args),
+		)
+	}
+	return rules
+}
+
+// errorPagesData returns the content of the error pages Secret
+func errorPagesData(instance *swiftv1beta1.SwiftProxy) map[string]string {
+	data := map[string]string{}
+	for _, page := range instance.Spec.ReverseProxy.ErrorPages {
+		if page.Body != "" {
+			data[errorPageFile(page.Status)] = page.Body
+		}
+	}
+	return data
+}
+
+// errorPagesVolume returns the volume with the custom error page bodies
+func errorPagesVolume(instance *swiftv1beta1.SwiftProxy) corev1.Volume {
+	return corev1.Volume{
+		Name: "error-pages",
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName: instance.Name + "-error-pages",
+			},
+		},
+	}
+}
+
+// ValidateForwardedHeaders checks the trusted CIDRs and that the reverse
+// proxy handling them is enabled
+func ValidateForwardedHeaders(instance *swiftv1beta1.SwiftProxy) error {
+	forwarded := instance.Spec.ForwardedHeaders
+	if len(forwarded.TrustedCIDRs) == 0 && !forwarded.SecureScheme {
+		return nil
+	}
+	if !forwarded.Enabled {
+		return fmt.Errorf("forwardedHeaders.trustedCIDRs and secureScheme require forwardedHeaders.enabled")
+	}
+	if !instance.Spec.ReverseProxy.Enabled {
+		return fmt.Errorf("forwardedHeaders.trustedCIDRs and secureScheme require reverseProxy.enabled")
+	}
+	for _, cidr := range forwarded.TrustedCIDRs {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return fmt.Errorf("forwardedHeaders.trustedCIDRs: %w", err)
+		}
+	}
+	return nil
+}
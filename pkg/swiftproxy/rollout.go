@@ -0,0 +1,269 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package swiftproxy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/deployment"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/util"
+
+	swiftv1beta1 "github.com/openstack-k8s-operators/swift-operator/api/v1beta1"
+)
+
+// canaryLabel distinguishes the canary pods from the other proxies, which
+// share all other labels so that the Services send requests to both. The
+// stable proxies are labeled "false", thus the selectors of the Deployments
+// don't overlap
+const canaryLabel = "swift.openstack.org/canary"
+
+// StableLabels returns the labels of the stable proxies, used as the
+// selector of their Deployment regardless of the rollout strategy
+func StableLabels(labels map[string]string) map[string]string {
+	return util.MergeStringMaps(labels, map[string]string{canaryLabel: "false"})
+}
+
+// CanaryDeploymentName returns the name of the canary Deployment
+func CanaryDeploymentName(instance *swiftv1beta1.SwiftProxy) string {
+	return instance.Name + "-canary"
+}
+
+// Canary returns true if new proxy images are rolled out using canaries
+func Canary(instance *swiftv1beta1.SwiftProxy) bool {
+	return instance.Spec.Rollout.Strategy == swiftv1beta1.RolloutStrategyCanary
+}
+
+// StableDeployment returns the Deployment of the proxies. It uses the stable
+// image while a canary rollout is in progress or was rolled back
+func StableDeployment(instance *swiftv1beta1.SwiftProxy, labels map[string]string, annotations map[string]string) *appsv1.Deployment {
+	stableImage := instance.Status.Rollout.StableImage
+	if !Canary(instance) || stableImage == "" {
+		return Deployment(instance, StableLabels(labels), annotations)
+	}
+	stable := instance.DeepCopy()
+	stable.Spec.ContainerImageProxy = stableImage
+	return Deployment(stable, StableLabels(labels), annotations)
+}
+
+// canaryDeployment returns the Deployment of the canaries using the new
+// image
+func canaryDeployment(instance *swiftv1beta1.SwiftProxy, labels map[string]string, annotations map[string]string) *appsv1.Deployment {
+	stableReplicas := int32(1)
+	if instance.Spec.Replicas != nil {
+		stableReplicas = *instance.Spec.Replicas
+	}
+	replicas := (stableReplicas*instance.Spec.Rollout.CanaryPercent + 99) / 100
+	if replicas < 1 {
+		replicas = 1
+	}
+	canaryLabels := util.MergeStringMaps(labels, map[string]string{canaryLabel: "true"})
+
+	canary := Deployment(instance, labels, annotations)
+	canary.Name = CanaryDeploymentName(instance)
+	canary.Spec.Replicas = &replicas
+	canary.Spec.Selector = &metav1.LabelSelector{MatchLabels: canaryLabels}
+	canary.Spec.Template.Labels = canaryLabels
+	return canary
+}
+
+// deleteCanary deletes the canary Deployment
+func deleteCanary(ctx context.Context, h *helper.Helper, instance *swiftv1beta1.SwiftProxy) error {
+	canary := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      CanaryDeploymentName(instance),
+			Namespace: instance.Namespace,
+		},
+	}
+	if err := h.GetClient().Delete(ctx, canary); err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// canaryRestarts returns the name of a canary pod with a restarted container
+func canaryRestarts(ctx context.Context, h *helper.Helper, instance *swiftv1beta1.SwiftProxy, labels map[string]string) (string, error) {
+	pods := &corev1.PodList{}
+	err := h.GetClient().List(ctx, pods, client.InNamespace(instance.Namespace),
+		client.MatchingLabels(util.MergeStringMaps(labels, map[string]string{canaryLabel: "true"})))
+	if err != nil {
+		return "", err
+	}
+	for _, pod := range pods.Items {
+		for _, status := range pod.Status.ContainerStatuses {
+			if status.RestartCount > 0 {
+				return pod.Name, nil
+			}
+		}
+	}
+	return "", nil
+}
+
+type prometheusResponse struct {
+	Status string `json:"status"`
+	Error  string `json:"error"`
+	Data   struct {
+		Result []struct {
+			Value []interface{} `json:"value"`
+		} `json:"result"`
+	} `json:"data"`
+}
+
+// queryErrorRate returns the value of the error rate query. A query without
+// result, eg. because there were no errors, returns 0
+func queryErrorRate(ctx context.Context, errorRate swiftv1beta1.SwiftProxyErrorRateSpec) (float64, error) {
+	httpClient := &http.Client{Timeout: 10 * time.Second}
+	queryURL := fmt.Sprintf("%s/api/v1/query?query=%s", errorRate.ServerAddress, url.QueryEscape(errorRate.Query))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, queryURL, nil)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	data := prometheusResponse{}
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return 0, fmt.Errorf("invalid response of %s: %w", errorRate.ServerAddress, err)
+	}
+	if data.Status != "success" {
+		return 0, fmt.Errorf("query failed: %s", data.Error)
+	}
+	if len(data.Data.Result) == 0 {
+		return 0, nil
+	}
+	if len(data.Data.Result) > 1 || len(data.Data.Result[0].Value) != 2 {
+		return 0, fmt.Errorf("query has to return a single value")
+	}
+	value, ok := data.Data.Result[0].Value[1].(string)
+	if !ok {
+		return 0, fmt.Errorf("query has to return a single value")
+	}
+	return strconv.ParseFloat(value, 64)
+}
+
+// rollback removes the canaries and keeps the stable image
+func rollback(ctx context.Context, h *helper.Helper, instance *swiftv1beta1.SwiftProxy, message string) error {
+	h.GetLogger().Info(fmt.Sprintf("Canary rollout of %s rolled back: %s", instance.Status.Rollout.CanaryImage, message))
+	instance.Status.Rollout.Phase = swiftv1beta1.RolloutPhaseRolledBack
+	instance.Status.Rollout.Message = message
+	return deleteCanary(ctx, h, instance)
+}
+
+// ReconcileCanary runs the canary rollout of a new proxy image and updates
+// the rollout status. The stable image is replaced once the canaries passed
+// the analysis. The first image and all images rolled out without the Canary
+// strategy become the stable image immediately
+func ReconcileCanary(ctx context.Context, h *helper.Helper, instance *swiftv1beta1.SwiftProxy, labels map[string]string, annotations map[string]string) (ctrl.Result, error) {
+	status := &instance.Status.Rollout
+	image := instance.Spec.ContainerImageProxy
+	if !Canary(instance) || status.StableImage == "" || status.StableImage == image {
+		if status.StableImage != image {
+			status.StableImage = image
+			status.Phase = swiftv1beta1.RolloutPhaseCompleted
+		}
+		return ctrl.Result{}, deleteCanary(ctx, h, instance)
+	}
+
+	// A rolled back image is not tried again
+	if status.CanaryImage == image && status.Phase == swiftv1beta1.RolloutPhaseRolledBack {
+		return ctrl.Result{}, deleteCanary(ctx, h, instance)
+	}
+	if status.CanaryImage != image {
+		now := metav1.Now()
+		status.CanaryImage = image
+		status.Phase = swiftv1beta1.RolloutPhaseProgressing
+		status.StartTime = &now
+		status.ReadyTime = nil
+		status.Message = ""
+	}
+
+	canaryDef := canaryDeployment(instance, labels, annotations)
+	canary := deployment.NewDeployment(canaryDef, 5*time.Second)
+	ctrlResult, err := canary.CreateOrPatch(ctx, h)
+	if err != nil || (ctrlResult != ctrl.Result{}) {
+		return ctrlResult, err
+	}
+
+	podName, err := canaryRestarts(ctx, h, instance, labels)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if podName != "" {
+		return ctrl.Result{}, rollback(ctx, h, instance, fmt.Sprintf("canary pod %s restarted", podName))
+	}
+
+	current := canary.GetDeployment()
+	if current.Status.ObservedGeneration < current.Generation || current.Status.ReadyReplicas < *canaryDef.Spec.Replicas {
+		timeout := time.Duration(instance.Spec.Rollout.TimeoutSeconds) * time.Second
+		if time.Since(status.StartTime.Time) > timeout {
+			return ctrl.Result{}, rollback(ctx, h, instance, fmt.Sprintf("canaries not ready within %s", timeout))
+		}
+		status.Phase = swiftv1beta1.RolloutPhaseProgressing
+		status.ReadyTime = nil
+		return ctrl.Result{RequeueAfter: 10 * time.Second}, nil
+	}
+
+	if status.ReadyTime == nil {
+		now := metav1.Now()
+		status.ReadyTime = &now
+	}
+	status.Phase = swiftv1beta1.RolloutPhaseAnalyzing
+	remaining := time.Duration(instance.Spec.Rollout.AnalysisSeconds)*time.Second - time.Since(status.ReadyTime.Time)
+	if remaining > 0 {
+		if remaining > 10*time.Second {
+			remaining = 10 * time.Second
+		}
+		return ctrl.Result{RequeueAfter: remaining}, nil
+	}
+
+	errorRate := instance.Spec.Rollout.ErrorRate
+	if errorRate.ServerAddress != "" {
+		threshold, err := strconv.ParseFloat(errorRate.Threshold, 64)
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("invalid errorRate threshold %q", errorRate.Threshold)
+		}
+		value, err := queryErrorRate(ctx, errorRate)
+		if err != nil {
+			h.GetLogger().Info(fmt.Sprintf("Error rate of the canaries not available: %s", err))
+			return ctrl.Result{RequeueAfter: 10 * time.Second}, nil
+		}
+		if value > threshold {
+			return ctrl.Result{}, rollback(ctx, h, instance, fmt.Sprintf("error rate %g exceeds %g", value, threshold))
+		}
+	}
+
+	h.GetLogger().Info(fmt.Sprintf("Canary rollout of %s completed", image))
+	status.StableImage = image
+	status.Phase = swiftv1beta1.RolloutPhaseCompleted
+	return ctrl.Result{}, deleteCanary(ctx, h, instance)
+}
@@ -0,0 +1,63 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package swiftproxy
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	swiftv1beta1 "github.com/openstack-k8s-operators/swift-operator/api/v1beta1"
+)
+
+func TestCanarySelectors(t *testing.T) {
+	g := NewWithT(t)
+
+	replicas := int32(4)
+	instance := &swiftv1beta1.SwiftProxy{
+		ObjectMeta: metav1.ObjectMeta{Name: "swift-proxy", Namespace: "openstack"},
+	}
+	instance.Spec.Replicas = &replicas
+	instance.Spec.ContainerImageProxy = "proxy:new"
+	instance.Spec.Rollout.Strategy = swiftv1beta1.RolloutStrategyCanary
+	instance.Spec.Rollout.CanaryPercent = 25
+	instance.Status.Rollout.StableImage = "proxy:stable"
+	serviceLabels := ServiceLabels(instance)
+
+	stable := StableDeployment(instance, serviceLabels, map[string]string{})
+	canary := canaryDeployment(instance, serviceLabels, map[string]string{})
+	stableSelector, err := metav1.LabelSelectorAsSelector(stable.Spec.Selector)
+	g.Expect(err).NotTo(HaveOccurred())
+	canarySelector, err := metav1.LabelSelectorAsSelector(canary.Spec.Selector)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	// The Deployments only select their own pods
+	g.Expect(stableSelector.Matches(labels.Set(stable.Spec.Template.Labels))).To(BeTrue())
+	g.Expect(stableSelector.Matches(labels.Set(canary.Spec.Template.Labels))).To(BeFalse())
+	g.Expect(canarySelector.Matches(labels.Set(canary.Spec.Template.Labels))).To(BeTrue())
+	g.Expect(canarySelector.Matches(labels.Set(stable.Spec.Template.Labels))).To(BeFalse())
+
+	// The Services send requests to both
+	serviceSelector := labels.SelectorFromSet(serviceLabels)
+	g.Expect(serviceSelector.Matches(labels.Set(stable.Spec.Template.Labels))).To(BeTrue())
+	g.Expect(serviceSelector.Matches(labels.Set(canary.Spec.Template.Labels))).To(BeTrue())
+
+	g.Expect(*canary.Spec.Replicas).To(Equal(int32(1)))
+	g.Expect(stable.Spec.Template.Spec.Containers).NotTo(BeEmpty())
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package swiftproxy
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	swiftv1beta1 "github.com/openstack-k8s-operators/swift-operator/api/v1beta1"
+)
+
+// TempAuth returns true if the requests are authenticated using the tempauth
+// users instead of Keystone
+func TempAuth(instance *swiftv1beta1.SwiftProxy) bool {
+	return instance.Spec.AuthMode == swiftv1beta1.AuthModeTempAuth
+}
+
+// ValidateTempAuth checks that no feature requiring Keystone is enabled in
+// the TempAuth mode
+func ValidateTempAuth(instance *swiftv1beta1.SwiftProxy) error {
+	if !TempAuth(instance) {
+		return nil
+	}
+	spec := instance.Spec
+	if spec.TempAuth.UsersSecret == "" {
+		return fmt.Errorf("authMode TempAuth requires tempAuth.usersSecret")
+	}
+	if spec.TempURL.KeySecret != "" {
+		return fmt.Errorf("tempURL.keySecret requires authMode Keystone")
+	}
+	if spec.Quotas.DefaultAccountQuota != nil {
+		return fmt.Errorf("quotas.defaultAccountQuota requires authMode Keystone")
+	}
+	if spec.Encryption.Enabled && spec.Encryption.Keymaster != "KMIP" {
+		return fmt.Errorf("the Barbican keymaster requires authMode Keystone")
+	}
+	if spec.Ceilometer.Enabled {
+		return fmt.Errorf("ceilometer requires authMode Keystone")
+	}
+	return nil
+}
+
+// TempAuthUsers returns the user options of the tempauth middleware
+func TempAuthUsers(usersSecret *corev1.Secret) ([]string, error) {
+	users := []string{}
+	for key, value := range usersSecret.Data {
+		if !strings.Contains(key, "_") {
+			return nil, fmt.Errorf("key %s of Secret %s is not <account>_<user>", key, usersSecret.Name)
+		}
+		if strings.ContainsAny(string(value), "\n\r") {
+			return nil, fmt.Errorf("key %s of Secret %s must not contain line breaks", key, usersSecret.Name)
+		}
+		users = append(users, fmt.Sprintf("user_%s = %s", key, strings.TrimSpace(string(value))))
+	}
+	sort.Strings(users)
+	return users, nil
+}
@@ -18,9 +18,11 @@ package swiftproxy
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/openstack-k8s-operators/lib-common/modules/common/util"
 	swiftv1beta1 "github.com/openstack-k8s-operators/swift-operator/api/v1beta1"
+	"github.com/openstack-k8s-operators/swift-operator/pkg/swift"
 )
 
 func SecretTemplates(
@@ -28,22 +30,170 @@ func SecretTemplates(
 	labels map[string]string,
 	keystonePublicURL string,
 	keystoneInternalURL string,
+	publicURL string,
 	password string,
+	rootSecrets map[string]string,
+	transportURL string,
+	tempAuthUsers []string,
+	memcached *swift.Memcached,
 ) []util.Template {
 	templateParameters := make(map[string]interface{})
 	templateParameters["ServiceUser"] = instance.Spec.ServiceUser
 	templateParameters["ServicePassword"] = password
 	templateParameters["KeystonePublicURL"] = keystonePublicURL
 	templateParameters["KeystoneInternalURL"] = keystoneInternalURL
+	swift.LogForwardingTemplateParameters(instance.Spec.LogForwarding, templateParameters)
 
-	return []util.Template{
+	// The proxy server only listens on localhost if the reverse proxy is
+	// in front of it
+	templateParameters["ProxyPort"] = swift.ProxyPort
+	templateParameters["ProxyBindIP"] = swift.BindIP(instance.Spec.IPFamilies)
+	templateParameters["ProxyBindPort"] = swift.ProxyPort
+	if instance.Spec.ReverseProxy.Enabled {
+		templateParameters["ProxyBindIP"] = swift.LoopbackIP(instance.Spec.IPFamilies)
+		templateParameters["ProxyBindPort"] = swift.ProxyBackendPort
+	}
+	templateParameters["ProxyBackendHost"] = swift.FormatHost(swift.LoopbackIP(instance.Spec.IPFamilies))
+	templateParameters["ProxyBackendPort"] = swift.ProxyBackendPort
+	swift.MemcacheTemplateParameters(memcached, instance.Spec.IPFamilies, memcachedPort(instance), instance.Spec.CABundleSecretName, templateParameters)
+	templateParameters["CAFile"] = ""
+	if instance.Spec.CABundleSecretName != "" {
+		templateParameters["CAFile"] = swift.CABundleFile
+	}
+	pool := instance.Spec.MemcachePool
+	if len(pool.Servers) > 0 {
+		templateParameters["MemcacheServers"] = strings.Join(pool.Servers, ",")
+	}
+	templateParameters["MemcacheMaxConnections"] = swift.OptionalValue(pool.MaxConnections)
+	templateParameters["MemcacheConnectTimeout"] = pool.ConnectTimeout
+	templateParameters["MemcachePoolTimeout"] = pool.PoolTimeout
+	templateParameters["MemcacheIOTimeout"] = pool.IOTimeout
+	templateParameters["MemcacheTries"] = swift.OptionalValue(pool.Tries)
+
+	// HAProxy binds to IPv4 only when using *
+	templateParameters["ReverseProxyBind"] = fmt.Sprintf("*:%d", swift.ProxyPort)
+	if swift.IPv6(instance.Spec.IPFamilies) {
+		templateParameters["ReverseProxyBind"] = fmt.Sprintf(":::%d v4v6", swift.ProxyPort)
+	}
+	templateParameters["ReverseProxyTLS"] = TLSSecretName(instance) != ""
+	templateParameters["ReverseProxySecureScheme"] = TLSSecretName(instance) != "" || instance.Spec.ForwardedHeaders.SecureScheme
+	templateParameters["ReverseProxyTrustedCIDRs"] = strings.Join(instance.Spec.ForwardedHeaders.TrustedCIDRs, " ")
+	templateParameters["ForwardedHeadersEnabled"] = instance.Spec.ForwardedHeaders.Enabled
+	templateParameters["ReverseProxyHTTP2"] = instance.Spec.ReverseProxy.HTTP2
+	templateParameters["ReverseProxyErrorRules"] = errorPageRules(instance)
+	templateParameters["DrainFile"] = ""
+	if instance.Spec.Drain.Enabled {
+		templateParameters["DrainFile"] = DrainFile
+	}
+	templateParameters["TempURLMethods"] = strings.Join(instance.Spec.TempURL.Methods, " ")
+	templateParameters["TempURLAllowedDigests"] = strings.Join(instance.Spec.TempURL.AllowedDigests, " ")
+	templateParameters["StaticWebEnabled"] = instance.Spec.StaticWeb.Enabled
+	// Redirects of staticweb use the public endpoint instead of the
+	// hostname of the request
+	templateParameters["StaticWebURLBase"] = publicURL
+	templateParameters["DomainRemapEnabled"] = instance.Spec.DomainRemap.Enabled
+	templateParameters["CNAMELookupEnabled"] = instance.Spec.DomainRemap.Enabled && instance.Spec.DomainRemap.CNAMELookup
+	templateParameters["DomainRemapStorageDomains"] = strings.Join(instance.Spec.DomainRemap.StorageDomains, ",")
+	templateParameters["DomainRemapPathRoot"] = instance.Spec.DomainRemap.PathRoot
+	templateParameters["CNAMELookupDepth"] = swift.OptionalValue(instance.Spec.DomainRemap.LookupDepth)
+	templateParameters["RatelimitAccount"] = swift.OptionalValue(instance.Spec.Ratelimit.AccountRatelimit)
+	templateParameters["RatelimitMaxSleepTime"] = swift.OptionalValue(instance.Spec.Ratelimit.MaxSleepTimeSeconds)
+	templateParameters["RatelimitContainers"] = ratelimitBuckets("container_ratelimit", instance.Spec.Ratelimit.ContainerRatelimits)
+	templateParameters["RatelimitContainerListings"] = ratelimitBuckets("container_listing_ratelimit", instance.Spec.Ratelimit.ContainerListingRatelimits)
+	templateParameters["RatelimitAccountWhitelist"] = strings.Join(instance.Spec.Ratelimit.AccountWhitelist, ",")
+	templateParameters["RatelimitAccountBlacklist"] = strings.Join(instance.Spec.Ratelimit.AccountBlacklist, ",")
+	templateParameters["BulkMaxContainersPerExtraction"] = swift.OptionalValue(instance.Spec.Bulk.MaxContainersPerExtraction)
+	templateParameters["BulkMaxFailedExtractions"] = swift.OptionalValue(instance.Spec.Bulk.MaxFailedExtractions)
+	templateParameters["BulkMaxDeletesPerRequest"] = swift.OptionalValue(instance.Spec.Bulk.MaxDeletesPerRequest)
+	templateParameters["BulkMaxFailedDeletes"] = swift.OptionalValue(instance.Spec.Bulk.MaxFailedDeletes)
+	templateParameters["AllowVersionedWrites"] = instance.Spec.VersionedWrites.AllowVersionedWrites
+	templateParameters["AllowObjectVersioning"] = instance.Spec.VersionedWrites.AllowObjectVersioning
+	// The object versioning API stores the versions as symlinks
+	templateParameters["SymlinkEnabled"] = instance.Spec.Symlink.Enabled || instance.Spec.VersionedWrites.AllowObjectVersioning
+	templateParameters["SymloopMax"] = swift.OptionalValue(instance.Spec.Symlink.SymloopMax)
+	templateParameters["EncryptionEnabled"] = instance.Spec.Encryption.Enabled
+	templateParameters["RootSecretID"] = rootSecrets[""]
+	templateParameters["RootSecrets"] = rootSecretOptions(rootSecrets)
+	templateParameters["ActiveRootSecretID"] = instance.Spec.Encryption.ActiveRootSecretID
+	templateParameters["Keymaster"] = keymaster(instance)
+	templateParameters["KMIPHost"] = instance.Spec.Encryption.KMIP.Host
+	templateParameters["KMIPPort"] = instance.Spec.Encryption.KMIP.Port
+	templateParameters["KMIPCertDir"] = ""
+	if KMIPCertSecretName(instance) != "" {
+		templateParameters["KMIPCertDir"] = KMIPCertMountPath
+	}
+	templateParameters["CORSAllowOrigin"] = strings.Join(instance.Spec.CORS.AllowOrigin, ",")
+	templateParameters["CORSExposeHeaders"] = strings.Join(instance.Spec.CORS.ExposeHeaders, ",")
+	templateParameters["CORSStrictMode"] = instance.Spec.CORS.StrictMode
+	templateParameters["SLOMaxManifestSegments"] = swift.OptionalValue(instance.Spec.LargeObjects.MaxManifestSegments)
+	templateParameters["SLOMaxManifestSize"] = swift.OptionalValue(instance.Spec.LargeObjects.MaxManifestSize)
+	templateParameters["RateLimitAfterSegment"] = swift.OptionalValue(instance.Spec.LargeObjects.RateLimitAfterSegment)
+	templateParameters["RateLimitSegmentsPerSec"] = swift.OptionalValue(instance.Spec.LargeObjects.RateLimitSegmentsPerSec)
+	templateParameters["S3APIEnabled"] = instance.Spec.S3API.Enabled
+	templateParameters["S3APILegacyClients"] = instance.Spec.S3API.LegacyClients
+	templateParameters["S3APILocation"] = instance.Spec.S3API.Location
+	templateParameters["S3APIMaxUploadPartNum"] = swift.OptionalValue(instance.Spec.S3API.MaxUploadPartNum)
+	templateParameters["S3APIStorageDomain"] = instance.Spec.S3API.StorageDomain
+	templateParameters["AccessLogMsgTemplate"] = accessLogMsgTemplate(instance)
+	templateParameters["AccessLogAnonymizationMethod"] = instance.Spec.AccessLog.AnonymizationMethod
+	templateParameters["AccessLogAnonymizationSalt"] = instance.Spec.AccessLog.AnonymizationSalt
+	templateParameters["AccessLogHeaders"] = instance.Spec.AccessLog.LogHeaders
+	templateParameters["AccessLogHeadersOnly"] = strings.Join(instance.Spec.AccessLog.LogHeadersOnly, ",")
+	templateParameters["ReadAffinity"] = instance.Spec.ReplicaAffinity.ReadAffinity
+	templateParameters["WriteAffinity"] = instance.Spec.ReplicaAffinity.WriteAffinity
+	templateParameters["WriteAffinityNodeCount"] = instance.Spec.ReplicaAffinity.WriteAffinityNodeCount
+	templateParameters["ResellerPrefixes"] = strings.Join(resellerPrefixes(instance), ", ")
+	templateParameters["OperatorRoles"] = strings.Join(instance.Spec.KeystoneAuth.OperatorRoles, ", ")
+	templateParameters["ResellerAdminRole"] = instance.Spec.KeystoneAuth.ResellerAdminRole
+	templateParameters["ResellerPrefixRoles"] = resellerPrefixRoles(instance)
+	templateParameters["TempAuth"] = TempAuth(instance)
+	templateParameters["TempAuthUsers"] = tempAuthUsers
+	// The storage URLs are generated from the requests, which are HTTP
+	// behind the reverse proxy and the Ingress
+	templateParameters["TempAuthStorageURLScheme"] = ""
+	if TLSSecretName(instance) != "" || (instance.Spec.Ingress.Enabled && instance.Spec.Ingress.TLSSecret != "") ||
+		instance.Spec.ForwardedHeaders.SecureScheme {
+		templateParameters["TempAuthStorageURLScheme"] = "https"
+	}
+	templateParameters["CeilometerEnabled"] = instance.Spec.Ceilometer.Enabled
+	templateParameters["TransportURL"] = transportURL
+	templateParameters["CeilometerTopic"] = instance.Spec.Ceilometer.Topic
+	templateParameters["CeilometerIgnoreProjects"] = strings.Join(instance.Spec.Ceilometer.IgnoreProjects, " ")
+	templateParameters["Pipeline"] = strings.Join(Pipeline(instance), " ")
+	templateParameters["Filters"] = filterSections(instance)
+	templateParameters["ProxyWorkers"] = swift.OptionalValue(instance.Spec.ProxyServer.Workers)
+	templateParameters["ProxyMaxClients"] = swift.OptionalValue(instance.Spec.ProxyServer.MaxClients)
+	templateParameters["ProxyNodeTimeout"] = swift.OptionalValue(instance.Spec.ProxyServer.NodeTimeout)
+	templateParameters["ProxyRecoverableNodeTimeout"] = swift.OptionalValue(instance.Spec.ProxyServer.RecoverableNodeTimeout)
+	templateParameters["ProxyConnTimeout"] = instance.Spec.ProxyServer.ConnTimeout
+	templateParameters["ProxyClientTimeout"] = swift.OptionalValue(instance.Spec.ProxyServer.ClientTimeout)
+	templateParameters["ErrorSuppressionInterval"] = swift.OptionalValue(instance.Spec.ProxyServer.ErrorSuppressionInterval)
+	templateParameters["ErrorSuppressionLimit"] = swift.OptionalValue(instance.Spec.ProxyServer.ErrorSuppressionLimit)
+	templateParameters["SortingMethod"] = instance.Spec.ProxyServer.SortingMethod
+	templateParameters["TimingExpiry"] = swift.OptionalValue(instance.Spec.ProxyServer.TimingExpiry)
+	templateParameters["MetricsEnabled"] = instance.Spec.Metrics.Enabled
+	templateParameters["StatsdPort"] = StatsdPort
+
+	configTemplates := map[string]string{}
+	if instance.Spec.LogForwarding.Enabled {
+		configTemplates["rsyslog.conf"] = "/common/config/rsyslog.conf"
+	}
+	if instance.Spec.ReverseProxy.Enabled {
+		configTemplates["haproxy.cfg"] = "/swiftproxy/reverse-proxy/haproxy.cfg"
+	}
+	if instance.Spec.Metrics.Enabled {
+		configTemplates["statsd-mapping.yaml"] = "/swiftproxy/metrics/statsd-mapping.yaml"
+	}
+
+	templates := []util.Template{
 		{
-			Name:          fmt.Sprintf("%s-config-data", instance.Name),
-			Namespace:     instance.Namespace,
-			Type:          util.TemplateTypeConfig,
-			InstanceType:  instance.Kind,
-			ConfigOptions: templateParameters,
-			Labels:        labels,
+			Name:               fmt.Sprintf("%s-config-data", instance.Name),
+			Namespace:          instance.Namespace,
+			Type:               util.TemplateTypeConfig,
+			InstanceType:       instance.Kind,
+			ConfigOptions:      templateParameters,
+			Labels:             labels,
+			AdditionalTemplate: configTemplates,
 		},
 		{
 			Name:               fmt.Sprintf("%s-scripts", instance.Name),
@@ -54,4 +204,78 @@ func SecretTemplates(
 			Labels:             labels,
 		},
 	}
+
+	// The bodies are user content, thus they are kept out of the rendered
+	// config templates
+	if instance.Spec.ReverseProxy.Enabled && len(instance.Spec.ReverseProxy.ErrorPages) > 0 {
+		templates = append(templates, util.Template{
+			Name:         fmt.Sprintf("%s-error-pages", instance.Name),
+			Namespace:    instance.Namespace,
+			Type:         util.TemplateTypeNone,
+			InstanceType: instance.Kind,
+			Labels:       labels,
+			CustomData:   errorPagesData(instance),
+		})
+	}
+
+	return templates
+}
+
+// ratelimitBuckets returns the ratelimit options for the container sizes
+func ratelimitBuckets(prefix string, buckets []swiftv1beta1.SwiftRatelimitBucket) []string {
+	options := []string{}
+	for _, bucket := range buckets {
+		options = append(options, fmt.Sprintf("%s_%d = %d", prefix, bucket.Size, bucket.Rate))
+	}
+	return options
+}
+
+// defaultAccessLogMsgTemplate is the access log format of proxy-logging
+const defaultAccessLogMsgTemplate = "{client_ip} {remote_addr} {end_time.datetime} {method} {path} {protocol} {status_int} {referer} {user_agent} {auth_token} {bytes_recvd} {bytes_sent} {client_etag} {transaction_id} {headers} {request_time} {source} {log_info} {start_time} {end_time} {policy_index}"
+
+// accessLogMsgTemplate returns the log_msg_template of the proxy-logging
+// middleware, with the client addresses replaced by their hashes if they
+// are anonymized
+func accessLogMsgTemplate(instance *swiftv1beta1.SwiftProxy) string {
+	msgTemplate := instance.Spec.AccessLog.MsgTemplate
+	if !instance.Spec.AccessLog.AnonymizeClientIP {
+		return msgTemplate
+	}
+	if msgTemplate == "" {
+		msgTemplate = defaultAccessLogMsgTemplate
+	}
+	return strings.NewReplacer(
+		"{client_ip}", "{client_ip.anonymized}",
+		"{remote_addr}", "{remote_addr.anonymized}",
+	).Replace(msgTemplate)
+}
+
+// resellerPrefixes returns the reseller prefixes of the keystoneauth
+// middleware, AUTH_ is always the first one
+func resellerPrefixes(instance *swiftv1beta1.SwiftProxy) []string {
+	prefixes := []string{"AUTH_"}
+	for _, resellerPrefix := range instance.Spec.KeystoneAuth.ResellerPrefixes {
+		if resellerPrefix.Prefix != "AUTH_" {
+			prefixes = append(prefixes, resellerPrefix.Prefix)
+		}
+	}
+	return prefixes
+}
+
+// resellerPrefixRoles returns the per prefix role options of the
+// keystoneauth middleware
+func resellerPrefixRoles(instance *swiftv1beta1.SwiftProxy) []string {
+	options := []string{}
+	if len(instance.Spec.KeystoneAuth.ServiceRoles) > 0 {
+		options = append(options, "AUTH_service_roles = "+strings.Join(instance.Spec.KeystoneAuth.ServiceRoles, ", "))
+	}
+	for _, resellerPrefix := range instance.Spec.KeystoneAuth.ResellerPrefixes {
+		if len(resellerPrefix.OperatorRoles) > 0 {
+			options = append(options, fmt.Sprintf("%soperator_roles = %s", resellerPrefix.Prefix, strings.Join(resellerPrefix.OperatorRoles, ", ")))
+		}
+		if len(resellerPrefix.ServiceRoles) > 0 {
+			options = append(options, fmt.Sprintf("%sservice_roles = %s", resellerPrefix.Prefix, strings.Join(resellerPrefix.ServiceRoles, ", ")))
+		}
+	}
+	return options
 }
@@ -0,0 +1,40 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package swiftproxy
+
+import (
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+
+	swiftv1beta1 "github.com/openstack-k8s-operators/swift-operator/api/v1beta1"
+)
+
+// TempURLKeyJob returns a Job setting the temp URL keys on the account of
+// the service project. The keys are passed as references to the Secret,
+// thus keySecretHash is used to run the Job again once they change
+func TempURLKeyJob(instance *swiftv1beta1.SwiftProxy, labels map[string]string, keystoneInternalURL string, keySecretHash string) *batchv1.Job {
+	selectors := instance.Spec.TempURL.KeySelectors
+	envVars := []corev1.EnvVar{
+		secretKeyEnv("TEMPURL_KEY", instance.Spec.TempURL.KeySecret, selectors.Key, true),
+		secretKeyEnv("TEMPURL_KEY_2", instance.Spec.TempURL.KeySecret, selectors.Key2, true),
+		{
+			Name:  "KEY_SECRET_HASH",
+			Value: keySecretHash,
+		},
+	}
+	return serviceUserJob(instance, labels, keystoneInternalURL, "tempurl-keys", "tempurl-keys.py", envVars)
+}
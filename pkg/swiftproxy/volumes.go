@@ -18,12 +18,13 @@ package swiftproxy
 
 import (
 	swiftv1beta1 "github.com/openstack-k8s-operators/swift-operator/api/v1beta1"
+	swift "github.com/openstack-k8s-operators/swift-operator/pkg/swift"
 	corev1 "k8s.io/api/core/v1"
 )
 
 func getProxyVolumes(instance *swiftv1beta1.SwiftProxy) []corev1.Volume {
 	var scriptsVolumeDefaultMode int32 = 0755
-	return []corev1.Volume{
+	volumes := []corev1.Volume{
 		{
 			Name: "config-data",
 			VolumeSource: corev1.VolumeSource{
@@ -40,16 +41,7 @@ func getProxyVolumes(instance *swiftv1beta1.SwiftProxy) []corev1.Volume {
 				},
 			},
 		},
-		{
-			Name: "ring-data",
-			VolumeSource: corev1.VolumeSource{
-				ConfigMap: &corev1.ConfigMapVolumeSource{
-					LocalObjectReference: corev1.LocalObjectReference{
-						Name: swiftv1beta1.RingConfigMapName,
-					},
-				},
-			},
-		},
+		swift.RingDataVolume(instance.Spec.RingDistribution, false),
 		{
 			Name: "config-data-merged",
 			VolumeSource: corev1.VolumeSource{
@@ -66,10 +58,36 @@ func getProxyVolumes(instance *swiftv1beta1.SwiftProxy) []corev1.Volume {
 			},
 		},
 	}
+
+	if instance.Spec.LogForwarding.Enabled {
+		volumes = append(volumes, swift.LogForwardingVolume())
+	}
+
+	if TLSSecretName(instance) != "" {
+		volumes = append(volumes, reverseProxyTLSVolume(instance))
+	}
+
+	if instance.Spec.ReverseProxy.Enabled && len(instance.Spec.ReverseProxy.ErrorPages) > 0 {
+		volumes = append(volumes, errorPagesVolume(instance))
+	}
+
+	if instance.Spec.CABundleSecretName != "" {
+		volumes = append(volumes, swift.CABundleVolume(instance.Spec.CABundleSecretName))
+	}
+
+	if instance.Spec.Drain.Enabled {
+		volumes = append(volumes, podInfoVolume())
+	}
+
+	if KMIPCertSecretName(instance) != "" {
+		volumes = append(volumes, kmipCertVolume(instance))
+	}
+
+	return volumes
 }
 
-func getProxyVolumeMounts() []corev1.VolumeMount {
-	return []corev1.VolumeMount{
+func getProxyVolumeMounts(instance *swiftv1beta1.SwiftProxy) []corev1.VolumeMount {
+	volumeMounts := []corev1.VolumeMount{
 		{
 			Name:      "config-data",
 			MountPath: "/var/lib/config-data/default",
@@ -96,4 +114,18 @@ func getProxyVolumeMounts() []corev1.VolumeMount {
 			ReadOnly:  true,
 		},
 	}
+
+	if instance.Spec.LogForwarding.Enabled {
+		volumeMounts = append(volumeMounts, swift.LogForwardingVolumeMount())
+	}
+
+	if instance.Spec.CABundleSecretName != "" {
+		volumeMounts = append(volumeMounts, swift.CABundleVolumeMount())
+	}
+
+	if KMIPCertSecretName(instance) != "" {
+		volumeMounts = append(volumeMounts, kmipCertVolumeMount())
+	}
+
+	return volumeMounts
 }
@@ -0,0 +1,72 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package swiftring
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/secret"
+
+	swiftv1beta1 "github.com/openstack-k8s-operators/swift-operator/api/v1beta1"
+)
+
+// ManualUpdate returns if the rebalanced rings have to be approved before
+// they are published. The steps of a partition power increase are published
+// without approval
+func ManualUpdate(instance *swiftv1beta1.SwiftRing) bool {
+	return instance.Spec.RingUpdatePolicy == swiftv1beta1.RingUpdatePolicyManual && instance.Status.PartPowerIncrease == nil
+}
+
+// RingsApproved returns if the pending rings were approved using the
+// ApproveRingsAnnotation. The approval references the pending rings by their
+// hash, thus rings prepared after the approval are not published
+func RingsApproved(instance *swiftv1beta1.SwiftRing) bool {
+	if !ManualUpdate(instance) || instance.Status.PendingRings == "" {
+		return false
+	}
+	return instance.Annotations[swiftv1beta1.ApproveRingsAnnotation] == instance.Status.PendingRings
+}
+
+// GetPendingRings returns the hash of the Secret with the rings waiting for
+// approval, or an empty string if there are none
+func GetPendingRings(ctx context.Context, h *helper.Helper, instance *swiftv1beta1.SwiftRing) (string, error) {
+	_, hash, err := secret.GetSecret(ctx, h, swiftv1beta1.PendingRingSecretName, instance.Namespace)
+	if apierrors.IsNotFound(err) {
+		return "", nil
+	}
+	return hash, err
+}
+
+// DeletePendingRings deletes the rings waiting for approval, they are not
+// published once the RingUpdatePolicy is Auto
+func DeletePendingRings(ctx context.Context, h *helper.Helper, instance *swiftv1beta1.SwiftRing) error {
+	pending := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      swiftv1beta1.PendingRingSecretName,
+			Namespace: instance.Namespace,
+		},
+	}
+	if err := h.GetClient().Delete(ctx, pending); err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,281 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package swiftring
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	swiftv1beta1 "github.com/openstack-k8s-operators/swift-operator/api/v1beta1"
+)
+
+// auditMaxReported is the number of mismatches kept in the status
+const auditMaxReported = 20
+
+// ringDevice is a device of a published ring
+type ringDevice struct {
+	IP              string `json:"ip"`
+	Port            int32  `json:"port"`
+	ReplicationIP   string `json:"replication_ip"`
+	ReplicationPort int32  `json:"replication_port"`
+	Device          string `json:"device"`
+}
+
+// listedDevice is a device of the device list of a SwiftStorage
+type listedDevice struct {
+	region           int32
+	zone             int32
+	ports            [3]int32
+	replicationPorts [3]int32
+	labels           map[string]string
+}
+
+// NextAuditTime returns the time of the next audit of the ring devices, nil
+// if the audit is disabled
+func NextAuditTime(instance *swiftv1beta1.SwiftRing) *time.Time {
+	if !instance.Spec.Audit.Enabled {
+		return nil
+	}
+	if instance.Status.Audit == nil || instance.Status.Audit.LastAuditTime == nil {
+		now := time.Now()
+		return &now
+	}
+	next := instance.Status.Audit.LastAuditTime.Add(time.Duration(instance.Spec.Audit.IntervalSeconds) * time.Second)
+	return &next
+}
+
+// parseRing returns the devices of a ring.gz file. Only the header of the
+// format version 1 is read, it contains the devices as JSON
+func parseRing(data []byte) ([]*ringDevice, error) {
+	reader, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	header := make([]byte, 6)
+	if _, err := io.ReadFull(reader, header); err != nil {
+		return nil, err
+	}
+	if string(header[:4]) != "R1NG" {
+		return nil, fmt.Errorf("not a ring file")
+	}
+	if version := binary.BigEndian.Uint16(header[4:]); version != 1 {
+		return nil, fmt.Errorf("unsupported ring format version %d", version)
+	}
+	var length uint32
+	if err := binary.Read(reader, binary.BigEndian, &length); err != nil {
+		return nil, err
+	}
+	metadata := make([]byte, length)
+	if _, err := io.ReadFull(reader, metadata); err != nil {
+		return nil, err
+	}
+	ring := struct {
+		Devs []*ringDevice `json:"devs"`
+	}{}
+	if err := json.Unmarshal(metadata, &ring); err != nil {
+		return nil, err
+	}
+	return ring.Devs, nil
+}
+
+// parseRings returns the devices per ring of the published swiftrings.tar.gz
+func parseRings(ringData []byte) (map[string][]*ringDevice, error) {
+	reader, err := gzip.NewReader(bytes.NewReader(ringData))
+	if err != nil {
+		return nil, err
+	}
+	rings := map[string][]*ringDevice{}
+	archive := tar.NewReader(reader)
+	for {
+		header, err := archive.Next()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		if !strings.HasSuffix(header.Name, ".ring.gz") {
+			continue
+		}
+		data, err := io.ReadAll(archive)
+		if err != nil {
+			return nil, err
+		}
+		ring := strings.TrimSuffix(header.Name, ".ring.gz")
+		devices, err := parseRing(data)
+		if err != nil {
+			return nil, fmt.Errorf("%s.ring.gz: %w", ring, err)
+		}
+		rings[ring] = devices
+	}
+	return rings, nil
+}
+
+// parseDeviceLists returns the devices of all device lists by <host>/<device>
+// and the failed devices with an applied action, see DeviceList and
+// FailedDeviceList of the SwiftStorage
+func parseDeviceLists(deviceData map[string]string) (map[string]listedDevice, map[string]bool) {
+	devices := map[string]listedDevice{}
+	failed := map[string]bool{}
+	for key, data := range deviceData {
+		for _, line := range strings.Split(data, "\n") {
+			fields := strings.Split(line, ",")
+			switch {
+			case strings.HasSuffix(key, swiftv1beta1.FailedDeviceListKeySuffix) && len(fields) >= 2:
+				failed[deviceKey(fields[0], fields[1])] = true
+			case strings.HasSuffix(key, swiftv1beta1.DeviceListKeySuffix) && len(fields) >= 12:
+				device := listedDevice{labels: map[string]string{}}
+				device.region = parseInt32(fields[0])
+				device.zone = parseInt32(fields[1])
+				for i := 0; i < 3; i++ {
+					device.ports[i] = parseInt32(fields[5+i])
+					device.replicationPorts[i] = parseInt32(fields[9+i])
+				}
+				if len(fields) >= 13 {
+					for _, label := range strings.Split(fields[12], ";") {
+						if name, value, ok := strings.Cut(label, "="); ok {
+							device.labels[name] = value
+						}
+					}
+				}
+				devices[deviceKey(fields[2], fields[3])] = device
+			}
+		}
+	}
+	return devices, failed
+}
+
+func parseInt32(value string) int32 {
+	i, _ := strconv.ParseInt(value, 10, 32)
+	return int32(i)
+}
+
+// deviceKey identifies a device by its host and device name. IPv6 addresses
+// are stored without brackets in the rings
+func deviceKey(host string, device string) string {
+	return strings.Trim(host, "[]") + "/" + device
+}
+
+// ringPortIndex returns the index of the server port used by the ring in
+// the device lists
+func ringPortIndex(ring string) int {
+	switch ring {
+	case "account":
+		return 0
+	case "container":
+		return 1
+	}
+	return 2
+}
+
+// deviceSelectedByRing returns if the device of the device list belongs to
+// the ring, see device_selected and object_builder of the rebalance Job
+func deviceSelectedByRing(instance *swiftv1beta1.SwiftRing, ring string, device listedDevice) bool {
+	for key, value := range ringSpecParameters(instance, ring).DeviceSelector {
+		if device.labels[key] != value {
+			return false
+		}
+	}
+	if ring != "object" || !instance.Spec.Composite.Enabled {
+		return true
+	}
+	for _, component := range instance.Spec.Composite.Components {
+		for _, region := range component.Regions {
+			if region == device.region {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// AuditRings compares the devices of the published rings with the device
+// lists of the storage pods, which contain their current hosts and ports. It
+// returns the number of ring devices and the mismatches. Failed devices with
+// an applied action are ignored
+func AuditRings(instance *swiftv1beta1.SwiftRing, ringData []byte, deviceData map[string]string) (int64, []string, error) {
+	rings, err := parseRings(ringData)
+	if err != nil {
+		return 0, nil, err
+	}
+	listed, failed := parseDeviceLists(deviceData)
+
+	names := []string{}
+	for ring := range rings {
+		names = append(names, ring)
+	}
+	sort.Strings(names)
+
+	count := int64(0)
+	mismatches := []string{}
+	for _, ring := range names {
+		index := ringPortIndex(ring)
+		inRing := map[string]bool{}
+		for _, dev := range rings[ring] {
+			if dev == nil {
+				continue
+			}
+			count++
+			key := deviceKey(dev.IP, dev.Device)
+			inRing[key] = true
+			if failed[key] {
+				continue
+			}
+			device, ok := listed[key]
+			switch {
+			case !ok:
+				mismatches = append(mismatches, fmt.Sprintf("%s ring: device %s has no storage pod", ring, key))
+			case dev.Port != device.ports[index] || dev.ReplicationPort != device.replicationPorts[index]:
+				mismatches = append(mismatches, fmt.Sprintf("%s ring: device %s uses the ports %d/%d instead of %d/%d",
+					ring, key, dev.Port, dev.ReplicationPort, device.ports[index], device.replicationPorts[index]))
+			case dev.ReplicationIP != "" && strings.Trim(dev.ReplicationIP, "[]") != strings.Trim(dev.IP, "[]"):
+				mismatches = append(mismatches, fmt.Sprintf("%s ring: device %s uses the replication IP %s", ring, key, dev.ReplicationIP))
+			}
+		}
+
+		missing := []string{}
+		for key, device := range listed {
+			if !inRing[key] && deviceSelectedByRing(instance, ring, device) {
+				missing = append(missing, fmt.Sprintf("%s ring: device %s of a storage pod is missing", ring, key))
+			}
+		}
+		sort.Strings(missing)
+		mismatches = append(mismatches, missing...)
+	}
+	return count, mismatches, nil
+}
+
+// AuditStatus returns the status of an audit, keeping the first mismatches
+func AuditStatus(devices int64, mismatches []string) *swiftv1beta1.SwiftRingAudit {
+	audit := &swiftv1beta1.SwiftRingAudit{
+		Devices:         devices,
+		TotalMismatches: int64(len(mismatches)),
+	}
+	if len(mismatches) > auditMaxReported {
+		mismatches = mismatches[:auditMaxReported]
+	}
+	audit.Mismatches = mismatches
+	return audit
+}
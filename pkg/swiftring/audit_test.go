@@ -0,0 +1,161 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package swiftring
+
+import (
+	"bytes"
+	"compress/gzip"
+	"os"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	swiftv1beta1 "github.com/openstack-k8s-operators/swift-operator/api/v1beta1"
+)
+
+// gzipData compresses the data like a ring.gz file
+func gzipData(g *WithT, data []byte) []byte {
+	var buf bytes.Buffer
+	writer := gzip.NewWriter(&buf)
+	_, err := writer.Write(data)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(writer.Close()).To(Succeed())
+	return buf.Bytes()
+}
+
+func TestParseRing(t *testing.T) {
+	g := NewWithT(t)
+
+	// object.ring.gz is written in the format version 1 of RingData.save,
+	// with a removed device
+	ring, err := os.ReadFile("testdata/object.ring.gz")
+	g.Expect(err).NotTo(HaveOccurred())
+
+	tests := []struct {
+		name    string
+		data    []byte
+		devices []*ringDevice
+		err     string
+	}{
+		{
+			name: "ring file",
+			data: ring,
+			devices: []*ringDevice{
+				{IP: "172.18.0.10", Port: 6200, ReplicationIP: "172.18.0.10", ReplicationPort: 6200, Device: "d1"},
+				nil,
+				{IP: "fd00::11", Port: 6200, ReplicationIP: "fd00::11", ReplicationPort: 6400, Device: "d1"},
+			},
+		},
+		{
+			name: "not compressed",
+			data: []byte("R1NG\x00\x01\x00\x00\x00\x02{}"),
+			err:  "gzip: invalid header",
+		},
+		{
+			name: "not a ring file",
+			data: gzipData(g, []byte("R2NG\x00\x01")),
+			err:  "not a ring file",
+		},
+		{
+			name: "unsupported version",
+			data: gzipData(g, []byte("R1NG\x00\x02")),
+			err:  "unsupported ring format version 2",
+		},
+		{
+			name: "truncated metadata",
+			data: gzipData(g, []byte("R1NG\x00\x01\x00\x00\x00\x10{}")),
+			err:  "unexpected EOF",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := NewWithT(t)
+			devices, err := parseRing(test.data)
+			if test.err != "" {
+				g.Expect(err).To(MatchError(test.err))
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(devices).To(Equal(test.devices))
+		})
+	}
+}
+
+func TestParseDeviceLists(t *testing.T) {
+	tests := []struct {
+		name       string
+		deviceData map[string]string
+		devices    map[string]listedDevice
+		failed     map[string]bool
+	}{
+		{
+			name:       "empty",
+			deviceData: map[string]string{},
+			devices:    map[string]listedDevice{},
+			failed:     map[string]bool{},
+		},
+		{
+			name: "devices with labels",
+			deviceData: map[string]string{
+				"swift-storage" + swiftv1beta1.DeviceListKeySuffix: "1,1,swift-storage-0.swift-storage,d1,10,6202,6201,6200,873,6302,6301,6300,disk=ssd;rack=r1,swift-storage-0\n" +
+					"1,2,[fd00::11],d2,10,6202,6201,6200,873,6202,6201,6200,,swift-storage-1\n",
+			},
+			devices: map[string]listedDevice{
+				"swift-storage-0.swift-storage/d1": {
+					region:           1,
+					zone:             1,
+					ports:            [3]int32{6202, 6201, 6200},
+					replicationPorts: [3]int32{6302, 6301, 6300},
+					labels:           map[string]string{"disk": "ssd", "rack": "r1"},
+				},
+				"fd00::11/d2": {
+					region:           1,
+					zone:             2,
+					ports:            [3]int32{6202, 6201, 6200},
+					replicationPorts: [3]int32{6202, 6201, 6200},
+					labels:           map[string]string{},
+				},
+			},
+			failed: map[string]bool{},
+		},
+		{
+			name: "failed devices",
+			deviceData: map[string]string{
+				"swift-storage" + swiftv1beta1.FailedDeviceListKeySuffix: "swift-storage-0.swift-storage,d1,Remove\n",
+			},
+			devices: map[string]listedDevice{},
+			failed:  map[string]bool{"swift-storage-0.swift-storage/d1": true},
+		},
+		{
+			name: "short lines and other keys",
+			deviceData: map[string]string{
+				"swift-storage" + swiftv1beta1.DeviceListKeySuffix: "1,1,swift-storage-0.swift-storage,d1\n",
+				"other": "1,1,swift-storage-0.swift-storage,d1,10,6202,6201,6200,873,6202,6201,6200\n",
+			},
+			devices: map[string]listedDevice{},
+			failed:  map[string]bool{},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := NewWithT(t)
+			devices, failed := parseDeviceLists(test.deviceData)
+			g.Expect(devices).To(Equal(test.devices))
+			g.Expect(failed).To(Equal(test.failed))
+		})
+	}
+}
@@ -0,0 +1,69 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package swiftring
+
+import (
+	"fmt"
+	"strings"
+
+	swiftv1beta1 "github.com/openstack-k8s-operators/swift-operator/api/v1beta1"
+)
+
+// ComponentRing returns the name of the builder of a composite ring
+// component, without the .builder suffix
+func ComponentRing(component swiftv1beta1.SwiftRingComponent) string {
+	return "object." + component.Name
+}
+
+// compositeEnv returns the COMPOSITE_COMPONENTS of the rebalance Job with
+// <name>:<replicas>:<regions> per component, empty if the object ring is not
+// composed
+func compositeEnv(instance *swiftv1beta1.SwiftRing) string {
+	if !instance.Spec.Composite.Enabled {
+		return ""
+	}
+	components := []string{}
+	for _, component := range instance.Spec.Composite.Components {
+		regions := []string{}
+		for _, region := range component.Regions {
+			regions = append(regions, fmt.Sprint(region))
+		}
+		components = append(components, fmt.Sprintf("%s:%d:%s", component.Name, component.Replicas, strings.Join(regions, ",")))
+	}
+	return strings.Join(components, " ")
+}
+
+// ValidateComposite checks that a composite ring has at least two components
+// and that every region is used by one component only
+func ValidateComposite(instance *swiftv1beta1.SwiftRing) error {
+	if !instance.Spec.Composite.Enabled {
+		return nil
+	}
+	if len(instance.Spec.Composite.Components) < 2 {
+		return fmt.Errorf("composite rings require at least two components")
+	}
+	regions := map[int32]string{}
+	for _, component := range instance.Spec.Composite.Components {
+		for _, region := range component.Regions {
+			if other, ok := regions[region]; ok {
+				return fmt.Errorf("region %d is used by the components %s and %s", region, other, component.Name)
+			}
+			regions[region] = component.Name
+		}
+	}
+	return nil
+}
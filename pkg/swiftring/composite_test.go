@@ -0,0 +1,86 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package swiftring
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	swiftv1beta1 "github.com/openstack-k8s-operators/swift-operator/api/v1beta1"
+)
+
+func TestValidateComposite(t *testing.T) {
+	tests := []struct {
+		name      string
+		composite swiftv1beta1.SwiftRingCompositeSpec
+		err       string
+	}{
+		{
+			name: "disabled",
+			composite: swiftv1beta1.SwiftRingCompositeSpec{
+				Components: []swiftv1beta1.SwiftRingComponent{
+					{Name: "a", Regions: []int32{1}, Replicas: 1},
+				},
+			},
+		},
+		{
+			name: "valid",
+			composite: swiftv1beta1.SwiftRingCompositeSpec{
+				Enabled: true,
+				Components: []swiftv1beta1.SwiftRingComponent{
+					{Name: "a", Regions: []int32{1, 2}, Replicas: 2},
+					{Name: "b", Regions: []int32{3}, Replicas: 1},
+				},
+			},
+		},
+		{
+			name: "single component",
+			composite: swiftv1beta1.SwiftRingCompositeSpec{
+				Enabled: true,
+				Components: []swiftv1beta1.SwiftRingComponent{
+					{Name: "a", Regions: []int32{1}, Replicas: 3},
+				},
+			},
+			err: "composite rings require at least two components",
+		},
+		{
+			name: "shared region",
+			composite: swiftv1beta1.SwiftRingCompositeSpec{
+				Enabled: true,
+				Components: []swiftv1beta1.SwiftRingComponent{
+					{Name: "a", Regions: []int32{1, 2}, Replicas: 2},
+					{Name: "b", Regions: []int32{2}, Replicas: 1},
+				},
+			},
+			err: "region 2 is used by the components a and b",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := NewWithT(t)
+			instance := &swiftv1beta1.SwiftRing{}
+			instance.Spec.Composite = test.composite
+			err := ValidateComposite(instance)
+			if test.err != "" {
+				g.Expect(err).To(MatchError(test.err))
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+		})
+	}
+}
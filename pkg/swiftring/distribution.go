@@ -0,0 +1,71 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package swiftring
+
+import (
+	"context"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/configmap"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/secret"
+
+	swiftv1beta1 "github.com/openstack-k8s-operators/swift-operator/api/v1beta1"
+)
+
+const (
+	// RingDataKey is the key of the tar file with the published rings
+	RingDataKey = "swiftrings.tar.gz"
+
+	// RingDataSizeLimit is the maximum size of the data of a ConfigMap or
+	// a Secret
+	RingDataSizeLimit = 1024 * 1024
+
+	// RingDataSizeWarning is the percentage of RingDataSizeLimit at which
+	// the SwiftRingSize condition warns about the size of the rings
+	RingDataSizeWarning = 80
+)
+
+// RingDistribution returns the kind of the object the rings are published in
+func RingDistribution(instance *swiftv1beta1.SwiftRing) string {
+	if instance.Spec.RingDistribution == swiftv1beta1.RingDistributionSecret {
+		return swiftv1beta1.RingDistributionSecret
+	}
+	return swiftv1beta1.RingDistributionConfigMap
+}
+
+// GetRingData returns the tar file with the published rings and the hash of
+// the ConfigMap or Secret it is published in
+func GetRingData(ctx context.Context, h *helper.Helper, instance *swiftv1beta1.SwiftRing) ([]byte, string, error) {
+	if RingDistribution(instance) == swiftv1beta1.RingDistributionSecret {
+		rings, hash, err := secret.GetSecret(ctx, h, swiftv1beta1.RingSecretName, instance.Namespace)
+		if err != nil {
+			return nil, "", err
+		}
+		return rings.Data[RingDataKey], hash, nil
+	}
+	rings, hash, err := configmap.GetConfigMapAndHashWithName(ctx, h, swiftv1beta1.RingConfigMapName, instance.Namespace)
+	if err != nil {
+		return nil, "", err
+	}
+	return rings.BinaryData[RingDataKey], hash, nil
+}
+
+// RingDataUsage returns the percentage of RingDataSizeLimit used by rings of
+// the given size
+func RingDataUsage(size int64) int {
+	return int(size * 100 / RingDataSizeLimit)
+}
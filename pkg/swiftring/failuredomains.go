@@ -0,0 +1,79 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package swiftring
+
+import (
+	"fmt"
+
+	swiftv1beta1 "github.com/openstack-k8s-operators/swift-operator/api/v1beta1"
+)
+
+// failureDomains returns the number of devices and zones of the devices
+// selected for a ring. Zones are unique per region
+func failureDomains(devices map[string]listedDevice, selected func(listedDevice) bool) (int64, int64) {
+	count := int64(0)
+	zones := map[[2]int32]bool{}
+	for _, device := range devices {
+		if !selected(device) {
+			continue
+		}
+		count++
+		zones[[2]int32{device.region, device.zone}] = true
+	}
+	return count, int64(len(zones))
+}
+
+// ValidateFailureDomains compares the devices and zones of the device lists
+// selected for each ring with its replica count. It returns the rings with
+// fewer devices than replicas, which store several replicas of a partition
+// on the same device, and the rings with fewer zones than replicas. Zones
+// are only checked if the devices of a ring use more than one zone. The
+// components of a composite ring are checked individually
+func ValidateFailureDomains(instance *swiftv1beta1.SwiftRing, deviceData map[string]string) ([]string, []string) {
+	listed, _ := parseDeviceLists(deviceData)
+
+	lowDevices := []string{}
+	lowZones := []string{}
+	check := func(ring string, replicas int64, selected func(listedDevice) bool) {
+		devices, zones := failureDomains(listed, selected)
+		if devices < replicas {
+			lowDevices = append(lowDevices, fmt.Sprintf("%s ring has %d replicas but %d devices", ring, replicas, devices))
+		} else if zones > 1 && zones < replicas {
+			lowZones = append(lowZones, fmt.Sprintf("%s ring has %d replicas but %d zones", ring, replicas, zones))
+		}
+	}
+
+	for _, ring := range RingNames(instance) {
+		if ring == "object" && instance.Spec.Composite.Enabled {
+			for _, component := range instance.Spec.Composite.Components {
+				regions := map[int32]bool{}
+				for _, region := range component.Regions {
+					regions[region] = true
+				}
+				check(ComponentRing(component), int64(component.Replicas), func(device listedDevice) bool {
+					return regions[device.region] && deviceSelectedByRing(instance, ring, device)
+				})
+			}
+			continue
+		}
+		_, _, replicas := RingParameters(instance, ring)
+		check(ring, replicas, func(device listedDevice) bool {
+			return deviceSelectedByRing(instance, ring, device)
+		})
+	}
+	return lowDevices, lowZones
+}
@@ -0,0 +1,128 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package swiftring
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	swiftv1beta1 "github.com/openstack-k8s-operators/swift-operator/api/v1beta1"
+)
+
+// deviceListLine returns a line of the device list of a SwiftStorage
+func deviceListLine(region int32, zone int32, host string, labels string) string {
+	return fmt.Sprintf("%d,%d,%s,d1,10,6202,6201,6200,873,6202,6201,6200,%s,%s", region, zone, host, labels, host)
+}
+
+func TestValidateFailureDomains(t *testing.T) {
+	tests := []struct {
+		name       string
+		replicas   int64
+		selector   map[string]string
+		composite  swiftv1beta1.SwiftRingCompositeSpec
+		devices    []string
+		lowDevices []string
+		lowZones   []string
+	}{
+		{
+			name:     "single zone",
+			replicas: 3,
+			devices: []string{
+				deviceListLine(1, 1, "swift-storage-0", ""),
+				deviceListLine(1, 1, "swift-storage-1", ""),
+				deviceListLine(1, 1, "swift-storage-2", ""),
+			},
+		},
+		{
+			name:     "fewer devices than replicas",
+			replicas: 3,
+			devices: []string{
+				deviceListLine(1, 1, "swift-storage-0", ""),
+				deviceListLine(1, 2, "swift-storage-1", ""),
+			},
+			lowDevices: []string{
+				"account ring has 3 replicas but 2 devices",
+				"container ring has 3 replicas but 2 devices",
+				"object ring has 3 replicas but 2 devices",
+			},
+		},
+		{
+			name:     "fewer zones than replicas",
+			replicas: 3,
+			devices: []string{
+				deviceListLine(1, 1, "swift-storage-0", ""),
+				deviceListLine(1, 1, "swift-storage-1", ""),
+				deviceListLine(1, 2, "swift-storage-2", ""),
+			},
+			lowZones: []string{
+				"account ring has 3 replicas but 2 zones",
+				"container ring has 3 replicas but 2 zones",
+				"object ring has 3 replicas but 2 zones",
+			},
+		},
+		{
+			name:     "device selector",
+			replicas: 2,
+			selector: map[string]string{"disk": "ssd"},
+			devices: []string{
+				deviceListLine(1, 1, "swift-storage-0", "disk=ssd"),
+				deviceListLine(1, 1, "swift-storage-1", "disk=hdd"),
+			},
+			lowDevices: []string{
+				"object ring has 2 replicas but 1 devices",
+			},
+		},
+		{
+			name:     "composite",
+			replicas: 2,
+			composite: swiftv1beta1.SwiftRingCompositeSpec{
+				Enabled: true,
+				Components: []swiftv1beta1.SwiftRingComponent{
+					{Name: "a", Regions: []int32{1}, Replicas: 2},
+					{Name: "b", Regions: []int32{2}, Replicas: 1},
+				},
+			},
+			devices: []string{
+				deviceListLine(1, 1, "swift-storage-0", ""),
+				deviceListLine(2, 1, "swift-storage-1", ""),
+			},
+			lowDevices: []string{
+				"object.a ring has 2 replicas but 1 devices",
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := NewWithT(t)
+			replicas := test.replicas
+			instance := &swiftv1beta1.SwiftRing{}
+			instance.Spec.RingReplicas = &replicas
+			instance.Spec.RingParameters.Object.DeviceSelector = test.selector
+			instance.Spec.Composite = test.composite
+			deviceData := map[string]string{
+				"swift-storage" + swiftv1beta1.DeviceListKeySuffix: strings.Join(test.devices, "\n") + "\n",
+			}
+
+			lowDevices, lowZones := ValidateFailureDomains(instance, deviceData)
+			g.Expect(lowDevices).To(ConsistOf(test.lowDevices))
+			g.Expect(lowZones).To(ConsistOf(test.lowZones))
+		})
+	}
+}
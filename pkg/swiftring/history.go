@@ -0,0 +1,288 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package swiftring
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/util"
+
+	swiftv1beta1 "github.com/openstack-k8s-operators/swift-operator/api/v1beta1"
+)
+
+const (
+	// BuilderDataKey is the key of the tar file with the builders
+	BuilderDataKey = "swiftbuilders.tar.gz"
+
+	// builderVersionLabel labels the copies of the builders of a ring
+	// version
+	builderVersionLabel = "swift.openstack.org/builder-version"
+)
+
+// RingVersionName returns the name of the ConfigMap or Secret with the given
+// ring version
+func RingVersionName(version int64) string {
+	return fmt.Sprintf("%s-%d", swiftv1beta1.RingConfigMapName, version)
+}
+
+// RingVersionBuilderName returns the name of the Secret with the builders of
+// the given ring version
+func RingVersionBuilderName(version int64) string {
+	return fmt.Sprintf("%s-%d", swiftv1beta1.BuilderSecretName, version)
+}
+
+// ActiveRingVersion returns the version of the published rings
+func ActiveRingVersion(instance *swiftv1beta1.SwiftRing) int64 {
+	if instance.Status.ActiveRingVersion == 0 {
+		return instance.Status.RingVersion
+	}
+	return instance.Status.ActiveRingVersion
+}
+
+// SaveRingVersion stores a copy of the published rings as the given version,
+// using the same kind of object as the published rings. The copies are
+// labeled with the version. The builders the rings
+// were written from are stored in a Secret of the same version
+func SaveRingVersion(ctx context.Context, h *helper.Helper, instance *swiftv1beta1.SwiftRing, version int64) error {
+	data, _, err := GetRingData(ctx, h, instance)
+	if err != nil {
+		return err
+	}
+
+	objectMeta := metav1.ObjectMeta{
+		Name:      RingVersionName(version),
+		Namespace: instance.Namespace,
+	}
+	labels := util.MergeStringMaps(Labels(), map[string]string{
+		swiftv1beta1.RingVersionLabel: strconv.FormatInt(version, 10),
+	})
+	if RingDistribution(instance) == swiftv1beta1.RingDistributionSecret {
+		saved := &corev1.Secret{ObjectMeta: objectMeta}
+		_, err = controllerutil.CreateOrPatch(ctx, h.GetClient(), saved, func() error {
+			saved.Labels = labels
+			saved.Data = map[string][]byte{RingDataKey: data}
+			return controllerutil.SetControllerReference(instance, saved, h.GetScheme())
+		})
+	} else {
+		saved := &corev1.ConfigMap{ObjectMeta: objectMeta}
+		_, err = controllerutil.CreateOrPatch(ctx, h.GetClient(), saved, func() error {
+			saved.Labels = labels
+			saved.BinaryData = map[string][]byte{RingDataKey: data}
+			return controllerutil.SetControllerReference(instance, saved, h.GetScheme())
+		})
+	}
+	if err != nil {
+		return err
+	}
+
+	builders := &corev1.Secret{}
+	err = h.GetClient().Get(ctx, types.NamespacedName{Name: swiftv1beta1.BuilderSecretName, Namespace: instance.Namespace}, builders)
+	if err != nil {
+		return err
+	}
+	savedBuilders := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      RingVersionBuilderName(version),
+			Namespace: instance.Namespace,
+		},
+	}
+	_, err = controllerutil.CreateOrPatch(ctx, h.GetClient(), savedBuilders, func() error {
+		savedBuilders.Labels = util.MergeStringMaps(Labels(), map[string]string{
+			builderVersionLabel: strconv.FormatInt(version, 10),
+		})
+		savedBuilders.Data = map[string][]byte{BuilderDataKey: builders.Data[BuilderDataKey]}
+		return controllerutil.SetControllerReference(instance, savedBuilders, h.GetScheme())
+	})
+	if err != nil {
+		return err
+	}
+	h.GetLogger().Info(fmt.Sprintf("Ring version %d saved", version))
+	return nil
+}
+
+// getRingVersionData returns the tar file with the rings of the given
+// version, which is stored in a ConfigMap or a Secret
+func getRingVersionData(ctx context.Context, h *helper.Helper, namespace string, version int64) ([]byte, error) {
+	name := types.NamespacedName{Name: RingVersionName(version), Namespace: namespace}
+	config := &corev1.ConfigMap{}
+	err := h.GetClient().Get(ctx, name, config)
+	if err == nil {
+		return config.BinaryData[RingDataKey], nil
+	} else if !apierrors.IsNotFound(err) {
+		return nil, err
+	}
+	saved := &corev1.Secret{}
+	err = h.GetClient().Get(ctx, name, saved)
+	if apierrors.IsNotFound(err) {
+		return nil, fmt.Errorf("ring version %d not found", version)
+	} else if err != nil {
+		return nil, err
+	}
+	return saved.Data[RingDataKey], nil
+}
+
+// RestoreRingVersion publishes the rings and builders saved as the given
+// version. The builders are restored first, the published rings are never
+// ahead of them. The replaced builders are kept in the builder history
+func RestoreRingVersion(ctx context.Context, h *helper.Helper, instance *swiftv1beta1.SwiftRing, version int64) error {
+	data, err := getRingVersionData(ctx, h, instance.Namespace, version)
+	if err != nil {
+		return err
+	}
+	savedBuilders := &corev1.Secret{}
+	err = h.GetClient().Get(ctx, types.NamespacedName{Name: RingVersionBuilderName(version), Namespace: instance.Namespace}, savedBuilders)
+	if apierrors.IsNotFound(err) {
+		return fmt.Errorf("builders of ring version %d not found", version)
+	} else if err != nil {
+		return err
+	}
+
+	builders := &corev1.Secret{}
+	err = h.GetClient().Get(ctx, types.NamespacedName{Name: swiftv1beta1.BuilderSecretName, Namespace: instance.Namespace}, builders)
+	if err != nil {
+		return err
+	}
+	patch := client.MergeFrom(builders.DeepCopy())
+	limit := int(builderHistoryLimit(instance))
+	for i := limit; i > 0; i-- {
+		previous := BuilderDataKey
+		if i > 1 {
+			previous = fmt.Sprintf("%s.%d", BuilderDataKey, i-1)
+		}
+		if value, ok := builders.Data[previous]; ok {
+			builders.Data[fmt.Sprintf("%s.%d", BuilderDataKey, i)] = value
+		}
+	}
+	builders.Data[BuilderDataKey] = savedBuilders.Data[BuilderDataKey]
+	if err := h.GetClient().Patch(ctx, builders, patch); err != nil {
+		return err
+	}
+
+	if RingDistribution(instance) == swiftv1beta1.RingDistributionSecret {
+		rings := &corev1.Secret{}
+		err := h.GetClient().Get(ctx, types.NamespacedName{Name: swiftv1beta1.RingSecretName, Namespace: instance.Namespace}, rings)
+		if err != nil {
+			return err
+		}
+		patch := client.MergeFrom(rings.DeepCopy())
+		rings.Data = map[string][]byte{RingDataKey: data}
+		return h.GetClient().Patch(ctx, rings, patch)
+	}
+	rings := &corev1.ConfigMap{}
+	err = h.GetClient().Get(ctx, types.NamespacedName{Name: swiftv1beta1.RingConfigMapName, Namespace: instance.Namespace}, rings)
+	if err != nil {
+		return err
+	}
+	patch = client.MergeFrom(rings.DeepCopy())
+	rings.BinaryData = map[string][]byte{RingDataKey: data}
+	return h.GetClient().Patch(ctx, rings, patch)
+}
+
+// listRingVersions returns the ConfigMaps and Secrets with the saved ring
+// versions. Both kinds are listed, the ring distribution might have been
+// changed
+func listRingVersions(ctx context.Context, h *helper.Helper, namespace string) ([]client.Object, error) {
+	configs := &corev1.ConfigMapList{}
+	err := h.GetClient().List(ctx, configs, client.InNamespace(namespace), client.HasLabels{swiftv1beta1.RingVersionLabel})
+	if err != nil {
+		return nil, err
+	}
+	secrets := &corev1.SecretList{}
+	err = h.GetClient().List(ctx, secrets, client.InNamespace(namespace), client.HasLabels{swiftv1beta1.RingVersionLabel})
+	if err != nil {
+		return nil, err
+	}
+
+	versions := []client.Object{}
+	for i := range configs.Items {
+		versions = append(versions, &configs.Items[i])
+	}
+	for i := range secrets.Items {
+		versions = append(versions, &secrets.Items[i])
+	}
+	return versions, nil
+}
+
+// PruneRingVersions deletes the ring versions beyond RingHistoryLimit,
+// together with their builders. The current and the active version are
+// never deleted
+func PruneRingVersions(ctx context.Context, h *helper.Helper, instance *swiftv1beta1.SwiftRing) error {
+	versions, err := listRingVersions(ctx, h, instance.Namespace)
+	if err != nil {
+		return err
+	}
+
+	limit := 5
+	if instance.Spec.RingHistoryLimit != nil {
+		limit = int(*instance.Spec.RingHistoryLimit)
+	}
+	if len(versions) <= limit {
+		return nil
+	}
+
+	// Newest versions first. Copies with an invalid version label are
+	// sorted last and pruned like old versions
+	version := func(obj client.Object) int64 {
+		v, _ := strconv.ParseInt(obj.GetLabels()[swiftv1beta1.RingVersionLabel], 10, 64)
+		return v
+	}
+	sort.Slice(versions, func(i, j int) bool {
+		return version(versions[i]) > version(versions[j])
+	})
+
+	for i := limit; i < len(versions); i++ {
+		obj := versions[i]
+		v := obj.GetLabels()[swiftv1beta1.RingVersionLabel]
+		if version(obj) == instance.Status.RingVersion || version(obj) == ActiveRingVersion(instance) {
+			continue
+		}
+		if err := h.GetClient().Delete(ctx, obj); err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+		if err := deleteRingVersionBuilders(ctx, h, instance.Namespace, v); err != nil {
+			return err
+		}
+		h.GetLogger().Info(fmt.Sprintf("Ring version %s deleted", v))
+	}
+	return nil
+}
+
+// deleteRingVersionBuilders deletes the builders saved for the ring version
+func deleteRingVersionBuilders(ctx context.Context, h *helper.Helper, namespace string, version string) error {
+	builders := &corev1.SecretList{}
+	err := h.GetClient().List(ctx, builders, client.InNamespace(namespace), client.MatchingLabels{builderVersionLabel: version})
+	if err != nil {
+		return err
+	}
+	for i := range builders.Items {
+		if err := h.GetClient().Delete(ctx, &builders.Items[i]); err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
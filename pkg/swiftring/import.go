@@ -0,0 +1,36 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package swiftring
+
+import (
+	"fmt"
+
+	swiftv1beta1 "github.com/openstack-k8s-operators/swift-operator/api/v1beta1"
+)
+
+// ValidateImport checks that the rings are either imported or restored from
+// a snapshot. Both initialize the rings if none exist yet. The imported
+// builders are checked against the part power, thus it can't be computed
+func ValidateImport(instance *swiftv1beta1.SwiftRing) error {
+	if instance.Spec.RingImportSecret != "" && instance.Spec.RingSnapshotSecret != "" {
+		return fmt.Errorf("ringImportSecret and ringSnapshotSecret can't be used together")
+	}
+	if instance.Spec.RingImportSecret != "" && instance.Spec.MaxDevices != nil {
+		return fmt.Errorf("ringImportSecret requires the partPower of the imported builders, maxDevices can't be used")
+	}
+	return nil
+}
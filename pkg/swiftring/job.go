@@ -28,18 +28,65 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+// builderHistoryLimit returns the number of previous builders to keep
+func builderHistoryLimit(instance *swiftv1beta1.SwiftRing) int32 {
+	if instance.Spec.BuilderHistoryLimit == nil {
+		return 3
+	}
+	return *instance.Spec.BuilderHistoryLimit
+}
+
 func GetRingJob(instance *swiftv1beta1.SwiftRing, labels map[string]string) *batchv1.Job {
 	securityContext := swift.GetSecurityContext()
 
 	envVars := map[string]env.Setter{}
 	envVars["CM_NAME"] = env.SetValue(swiftv1beta1.RingConfigMapName)
+	envVars["RING_DISTRIBUTION"] = env.SetValue(RingDistribution(instance))
+	envVars["RING_SECRET_NAME"] = env.SetValue(swiftv1beta1.RingSecretName)
+	envVars["RING_SIZE_LIMIT"] = env.SetValue(fmt.Sprint(RingDataSizeLimit))
+	envVars["BUILDER_SECRET_NAME"] = env.SetValue(swiftv1beta1.BuilderSecretName)
+	envVars["STATS_CM_NAME"] = env.SetValue(swiftv1beta1.RingStatsConfigMapName)
+	envVars["STATS_KEY"] = env.SetValue(swiftv1beta1.RingStatsKey)
+	envVars["BUILDER_HISTORY_LIMIT"] = env.SetValue(fmt.Sprint(builderHistoryLimit(instance)))
 	envVars["NAMESPACE"] = env.SetValue(instance.Namespace)
-	envVars["SWIFT_REPLICAS"] = env.SetValue(fmt.Sprint(*instance.Spec.RingReplicas))
+	if instance.Spec.RecoverBuilders {
+		envVars["RECOVER_BUILDERS"] = env.SetValue("true")
+	}
+	if DryRun(instance) {
+		envVars["DRY_RUN"] = env.SetValue("true")
+	}
+	if ManualUpdate(instance) {
+		envVars["RING_UPDATE_POLICY"] = env.SetValue(swiftv1beta1.RingUpdatePolicyManual)
+		envVars["PENDING_SECRET_NAME"] = env.SetValue(swiftv1beta1.PendingRingSecretName)
+	}
+	if RingsApproved(instance) {
+		envVars["PUBLISH_PENDING"] = env.SetValue("true")
+	}
+	if instance.Status.ScheduledRebalance {
+		envVars["SCHEDULED_REBALANCE"] = env.SetValue("true")
+	}
+	envVars["RING_PARAMETERS"] = env.SetValue(RingParametersEnv(instance))
+	if selectors := deviceSelectorsEnv(instance); selectors != "" {
+		envVars["DEVICE_SELECTORS"] = env.SetValue(selectors)
+	}
+	if conf := storagePoliciesConf(instance); conf != "" {
+		envVars["STORAGE_POLICIES_CONF"] = env.SetValue(conf)
+	}
+	if increase := PartPowerIncreaseEnv(instance); increase != "" {
+		envVars["PART_POWER_INCREASE"] = env.SetValue(increase)
+	}
+	if instance.Spec.Composite.Enabled {
+		envVars["COMPOSITE_COMPONENTS"] = env.SetValue(compositeEnv(instance))
+	}
 	envVars["OWNER_APIVERSION"] = env.SetValue(instance.APIVersion)
 	envVars["OWNER_KIND"] = env.SetValue(instance.Kind)
 	envVars["OWNER_UID"] = env.SetValue(string(instance.ObjectMeta.UID))
 	envVars["OWNER_NAME"] = env.SetValue(instance.ObjectMeta.Name)
 
+	// The rebalance script ignores SIGTERM until the rings are published,
+	// give it enough time to finish before it is killed
+	terminationGracePeriodSeconds := int64(swift.RingJobTerminationGracePeriod)
+
 	return &batchv1.Job{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      instance.Name + "-rebalance",
@@ -49,8 +96,12 @@ func GetRingJob(instance *swiftv1beta1.SwiftRing, labels map[string]string) *bat
 		Spec: batchv1.JobSpec{
 			Template: corev1.PodTemplateSpec{
 				Spec: corev1.PodSpec{
-					RestartPolicy:      "OnFailure",
-					ServiceAccountName: swift.ServiceAccount,
+					RestartPolicy:                 "OnFailure",
+					ServiceAccountName:            swift.ServiceAccount,
+					ImagePullSecrets:              instance.Spec.ImagePullSecrets,
+					TerminationGracePeriodSeconds: &terminationGracePeriodSeconds,
+					NodeSelector:                  instance.Spec.NodeSelector,
+					Tolerations:                   instance.Spec.Tolerations,
 					SecurityContext: &corev1.PodSecurityContext{
 						SeccompProfile: &corev1.SeccompProfile{
 							Type: corev1.SeccompProfileTypeRuntimeDefault,
@@ -62,7 +113,8 @@ func GetRingJob(instance *swiftv1beta1.SwiftRing, labels map[string]string) *bat
 							Command:         []string{"/usr/local/bin/container-scripts/swift-ring-rebalance.sh"},
 							Image:           instance.Spec.ContainerImage,
 							SecurityContext: &securityContext,
-							VolumeMounts:    getRingVolumeMounts(),
+							Resources:       instance.Spec.Resources,
+							VolumeMounts:    getRingVolumeMounts(instance),
 							Env:             env.MergeEnvs([]corev1.EnvVar{}, envVars),
 						},
 					},
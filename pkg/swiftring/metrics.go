@@ -0,0 +1,138 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package swiftring
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+
+	swiftv1beta1 "github.com/openstack-k8s-operators/swift-operator/api/v1beta1"
+)
+
+var (
+	balanceMetric = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "swift_ring_balance",
+			Help: "Balance of a Swift ring in percent",
+		},
+		[]string{"swiftring_namespace", "swiftring", "ring"},
+	)
+	dispersionMetric = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "swift_ring_dispersion",
+			Help: "Dispersion of a Swift ring in percent",
+		},
+		[]string{"swiftring_namespace", "swiftring", "ring"},
+	)
+	overloadMetric = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "swift_ring_overload",
+			Help: "Overload factor of a Swift ring in percent",
+		},
+		[]string{"swiftring_namespace", "swiftring", "ring"},
+	)
+	reassignedMetric = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "swift_ring_partitions_reassigned",
+			Help: "Number of partitions moved by the last rebalance of a Swift ring",
+		},
+		[]string{"swiftring_namespace", "swiftring", "ring"},
+	)
+	partitionsMovableMetric = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "swift_ring_partitions_movable_timestamp_seconds",
+			Help: "Time the partitions moved by the last rebalance of a Swift ring can be moved again",
+		},
+		[]string{"swiftring_namespace", "swiftring", "ring"},
+	)
+	auditMismatchesMetric = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "swift_ring_audit_mismatches",
+			Help: "Number of ring devices not matching the storage pods found by the last audit",
+		},
+		[]string{"swiftring_namespace", "swiftring"},
+	)
+)
+
+func init() {
+	metrics.Registry.MustRegister(balanceMetric, dispersionMetric, overloadMetric, reassignedMetric, partitionsMovableMetric, auditMismatchesMetric)
+}
+
+// ParseRingStats decodes the ring stats stored by the rebalance Job
+func ParseRingStats(message string) (map[string]swiftv1beta1.SwiftRingStats, error) {
+	stats := map[string]swiftv1beta1.SwiftRingStats{}
+	if err := json.Unmarshal([]byte(message), &stats); err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+// GetRingStats returns the ring stats stored by the rebalance Job, an empty
+// string if there are none
+func GetRingStats(ctx context.Context, h *helper.Helper, instance *swiftv1beta1.SwiftRing) (string, error) {
+	cm := &corev1.ConfigMap{}
+	err := h.GetClient().Get(ctx, types.NamespacedName{Name: swiftv1beta1.RingStatsConfigMapName, Namespace: instance.Namespace}, cm)
+	if apierrors.IsNotFound(err) {
+		return "", nil
+	} else if err != nil {
+		return "", err
+	}
+	return cm.Data[swiftv1beta1.RingStatsKey], nil
+}
+
+// UpdateMetrics replaces the ring metrics of the instance with the ones of
+// its status, thus the series of removed rings are deleted
+func UpdateMetrics(instance *swiftv1beta1.SwiftRing) {
+	DeleteMetrics(instance.Namespace, instance.Name)
+	for ring, stats := range instance.Status.Rings {
+		setGauge(balanceMetric, instance, ring, stats.Balance)
+		setGauge(dispersionMetric, instance, ring, stats.Dispersion)
+		setGauge(overloadMetric, instance, ring, stats.Overload)
+		reassignedMetric.WithLabelValues(instance.Namespace, instance.Name, ring).Set(float64(stats.PartitionsReassigned))
+		if stats.PartitionsMovableTime != nil {
+			partitionsMovableMetric.WithLabelValues(instance.Namespace, instance.Name, ring).Set(float64(stats.PartitionsMovableTime.Unix()))
+		}
+	}
+	if instance.Status.Audit != nil {
+		auditMismatchesMetric.WithLabelValues(instance.Namespace, instance.Name).Set(float64(instance.Status.Audit.TotalMismatches))
+	}
+}
+
+// DeleteMetrics removes all metrics of the instance
+func DeleteMetrics(namespace string, name string) {
+	labels := prometheus.Labels{"swiftring_namespace": namespace, "swiftring": name}
+	for _, gauge := range []*prometheus.GaugeVec{balanceMetric, dispersionMetric, overloadMetric, reassignedMetric, partitionsMovableMetric, auditMismatchesMetric} {
+		gauge.DeletePartialMatch(labels)
+	}
+}
+
+func setGauge(gauge *prometheus.GaugeVec, instance *swiftv1beta1.SwiftRing, ring string, value string) {
+	v, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return
+	}
+	gauge.WithLabelValues(instance.Namespace, instance.Name, ring).Set(v)
+}
@@ -0,0 +1,210 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package swiftring
+
+import (
+	"fmt"
+	"math/bits"
+	"sort"
+	"strings"
+
+	swiftv1beta1 "github.com/openstack-k8s-operators/swift-operator/api/v1beta1"
+)
+
+const (
+	// DefaultPartPower is the part power of rings created before it was
+	// configurable
+	DefaultPartPower int32 = 8
+	// DefaultMinPartHours is the min_part_hours of rings created before it
+	// was configurable
+	DefaultMinPartHours int32 = 1
+	// DefaultRingReplicas is the default of ringReplicas
+	DefaultRingReplicas int64 = 1
+)
+
+// Rings are the names of the rings built by the rebalance Job in addition
+// to the rings of the storage policies
+var Rings = []string{"account", "container", "object"}
+
+// RingNames returns the names of all rings, including the object-<index>
+// rings of the storage policies
+func RingNames(instance *swiftv1beta1.SwiftRing) []string {
+	rings := append([]string{}, Rings...)
+	for _, policy := range instance.Spec.StoragePolicies {
+		rings = append(rings, PolicyRing(policy.Index))
+	}
+	return rings
+}
+
+// ringSpecParameters returns the parameters given for the ring
+func ringSpecParameters(instance *swiftv1beta1.SwiftRing, ring string) swiftv1beta1.SwiftRingParameters {
+	switch ring {
+	case "account":
+		return instance.Spec.RingParameters.Account
+	case "container":
+		return instance.Spec.RingParameters.Container
+	case "object":
+		return instance.Spec.RingParameters.Object
+	}
+	for _, policy := range instance.Spec.StoragePolicies {
+		if PolicyRing(policy.Index) == ring {
+			return policy.Parameters
+		}
+	}
+	return swiftv1beta1.SwiftRingParameters{}
+}
+
+// RingParameters returns the part power, min_part_hours and replica count
+// of the given ring
+func RingParameters(instance *swiftv1beta1.SwiftRing, ring string) (int32, int32, int64) {
+	partPower := DefaultPartPower
+	if instance.Spec.PartPower != nil {
+		partPower = *instance.Spec.PartPower
+	}
+	if instance.Spec.MaxDevices != nil {
+		partPower = currentPartPower(instance, ring)
+		if partPower == 0 {
+			partPower = AutoPartPower(*instance.Spec.MaxDevices)
+		}
+	}
+	minPartHours := DefaultMinPartHours
+	if instance.Spec.MinPartHours != nil {
+		minPartHours = *instance.Spec.MinPartHours
+	}
+	replicas := DefaultRingReplicas
+	if instance.Spec.RingReplicas != nil {
+		replicas = *instance.Spec.RingReplicas
+	}
+
+	parameters := ringSpecParameters(instance, ring)
+	if parameters.PartPower != nil {
+		partPower = *parameters.PartPower
+	}
+	if parameters.MinPartHours != nil {
+		minPartHours = *parameters.MinPartHours
+	}
+	if parameters.Replicas != nil {
+		replicas = *parameters.Replicas
+	}
+	return partPower, minPartHours, replicas
+}
+
+// AutoPartPower returns the part power giving at least 100 partitions per
+// device for the expected maximum number of devices
+func AutoPartPower(maxDevices int32) int32 {
+	partPower := int32(bits.Len64(uint64(maxDevices)*100 - 1))
+	if partPower > 32 {
+		return 32
+	}
+	return partPower
+}
+
+// currentPartPower returns the part power of the existing ring, 0 if the
+// ring does not exist yet. The composite object ring uses the part power of
+// its components
+func currentPartPower(instance *swiftv1beta1.SwiftRing, ring string) int32 {
+	builders := []string{ring}
+	if ring == "object" {
+		for _, component := range instance.Spec.Composite.Components {
+			builders = append(builders, ComponentRing(component))
+		}
+	}
+	for _, builder := range builders {
+		if stats, ok := instance.Status.Rings[builder]; ok && stats.Partitions > 0 {
+			return int32(bits.Len64(uint64(stats.Partitions)) - 1)
+		}
+	}
+	return 0
+}
+
+// RingOverload returns the overload of the given ring in percent
+func RingOverload(instance *swiftv1beta1.SwiftRing, ring string) int32 {
+	if overload := ringSpecParameters(instance, ring).Overload; overload != nil {
+		return *overload
+	}
+	if instance.Spec.Overload != nil {
+		return *instance.Spec.Overload
+	}
+	return 0
+}
+
+// RingParametersEnv returns the RING_PARAMETERS of the rebalance Job
+func RingParametersEnv(instance *swiftv1beta1.SwiftRing) string {
+	parameters := []string{}
+	for _, ring := range RingNames(instance) {
+		partPower, minPartHours, replicas := RingParameters(instance, ring)
+		parameters = append(parameters, fmt.Sprintf("%s:%d:%d:%s:%d", ring, partPower, minPartHours, ringReplicasEnv(instance, ring, replicas), RingOverload(instance, ring)))
+	}
+	return strings.Join(parameters, " ")
+}
+
+// ValidatePartPower checks that the part power of the existing rings is
+// unchanged. Only the part power of object rings can be increased, by one at
+// a time, as this requires relinking the data on all devices. The
+// components of a composite ring use the part power of the object ring
+func ValidatePartPower(instance *swiftv1beta1.SwiftRing) error {
+	increase := instance.Status.PartPowerIncrease
+	if increase != nil && instance.Spec.RollbackToVersion != nil {
+		return fmt.Errorf("rollbackToVersion can't be set during the partition power increase of the %s ring", increase.Ring)
+	}
+
+	builders := map[string]string{}
+	for _, ring := range RingNames(instance) {
+		builders[ring] = ring
+	}
+	for _, component := range instance.Spec.Composite.Components {
+		builders[ComponentRing(component)] = "object"
+	}
+	for builder, ring := range builders {
+		current := currentPartPower(instance, builder)
+		if current == 0 {
+			continue
+		}
+		partPower, _, _ := RingParameters(instance, ring)
+		switch {
+		case partPower == current:
+		case increase != nil && increase.Ring == builder:
+			if partPower != increase.PartPower {
+				return fmt.Errorf("the part power of the %s ring is being increased to %d, it can't be changed to %d", builder, increase.PartPower, partPower)
+			}
+		case !partPowerIncreasable(instance, builder) || partPower < current:
+			return fmt.Errorf("the part power of the %s ring can't be changed from %d to %d", builder, current, partPower)
+		case partPower > current+1:
+			return fmt.Errorf("the part power of the %s ring can only be increased by one at a time, from %d to %d", builder, current, current+1)
+		}
+	}
+	return nil
+}
+
+// deviceSelectorsEnv returns the DEVICE_SELECTORS of the rebalance Job,
+// <ring>:<key>=<value>,... per ring with a device selector
+func deviceSelectorsEnv(instance *swiftv1beta1.SwiftRing) string {
+	selectors := []string{}
+	for _, ring := range RingNames(instance) {
+		selector := ringSpecParameters(instance, ring).DeviceSelector
+		if len(selector) == 0 {
+			continue
+		}
+		labels := []string{}
+		for key, value := range selector {
+			labels = append(labels, key+"="+value)
+		}
+		sort.Strings(labels)
+		selectors = append(selectors, ring+":"+strings.Join(labels, ","))
+	}
+	return strings.Join(selectors, " ")
+}
@@ -0,0 +1,138 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package swiftring
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	swiftv1beta1 "github.com/openstack-k8s-operators/swift-operator/api/v1beta1"
+)
+
+// RingDistributionDelay is the time the storage pods need to use newly
+// published rings, see ring-sync.sh. The relinker must not run before all
+// object servers use the rings of the current step
+const RingDistributionDelay = 3 * time.Minute
+
+// partPowerIncreasable returns if the part power of the ring can be
+// increased. Only object rings are relinked, and a composite ring can't be
+// increased by swift-ring-builder
+func partPowerIncreasable(instance *swiftv1beta1.SwiftRing, ring string) bool {
+	if ring == "object" {
+		return !instance.Spec.Composite.Enabled
+	}
+	return strings.HasPrefix(ring, "object-")
+}
+
+// PolicyIndex returns the index of the storage policy of an object ring
+func PolicyIndex(ring string) int32 {
+	index, err := strconv.ParseInt(strings.TrimPrefix(ring, "object-"), 10, 32)
+	if err != nil {
+		return 0
+	}
+	return int32(index)
+}
+
+// DryRun returns if the rebalance Job must not publish the rings. A
+// partition power increase in progress is never a dry run
+func DryRun(instance *swiftv1beta1.SwiftRing) bool {
+	return instance.Spec.DryRun && instance.Status.PartPowerIncrease == nil
+}
+
+// PartPowerIncreaseRing returns the ring and the part power of the next
+// partition power increase, an empty ring if there is none. The part power
+// is increased by one, and not during a dry run or rollback
+func PartPowerIncreaseRing(instance *swiftv1beta1.SwiftRing) (string, int32) {
+	if instance.Spec.DryRun || instance.Spec.RollbackToVersion != nil {
+		return "", 0
+	}
+	for _, ring := range RingNames(instance) {
+		current := currentPartPower(instance, ring)
+		if current == 0 || !partPowerIncreasable(instance, ring) {
+			continue
+		}
+		if partPower, _, _ := RingParameters(instance, ring); partPower == current+1 {
+			return ring, partPower
+		}
+	}
+	return "", 0
+}
+
+// PartPowerIncreaseEnv returns the PART_POWER_INCREASE of the rebalance Job,
+// <ring>:<step>:<part power> for the steps changing the builder
+func PartPowerIncreaseEnv(instance *swiftv1beta1.SwiftRing) string {
+	increase := instance.Status.PartPowerIncrease
+	if increase == nil {
+		return ""
+	}
+	step := ""
+	switch increase.Phase {
+	case swiftv1beta1.PartPowerPhasePrepare:
+		step = "prepare"
+	case swiftv1beta1.PartPowerPhaseIncrease:
+		step = "increase"
+	case swiftv1beta1.PartPowerPhaseFinish:
+		step = "finish"
+	default:
+		return ""
+	}
+	return fmt.Sprintf("%s:%s:%d", increase.Ring, step, increase.PartPower)
+}
+
+// RelinkerMode returns the mode of swift-object-relinker and the device
+// state reached by it in the current phase, an empty mode if the phase
+// does not relink
+func RelinkerMode(instance *swiftv1beta1.SwiftRing) (string, string) {
+	if instance.Status.PartPowerIncrease == nil {
+		return "", ""
+	}
+	switch instance.Status.PartPowerIncrease.Phase {
+	case swiftv1beta1.PartPowerPhaseRelink:
+		return "relink", swiftv1beta1.PartPowerDeviceRelinked
+	case swiftv1beta1.PartPowerPhaseCleanup:
+		return "cleanup", swiftv1beta1.PartPowerDeviceCleanedUp
+	}
+	return "", ""
+}
+
+// AdvancePartPowerIncrease moves the partition power increase to its next
+// phase once the current phase is done. The increase is removed from the
+// status once it is finished
+func AdvancePartPowerIncrease(instance *swiftv1beta1.SwiftRing) {
+	increase := instance.Status.PartPowerIncrease
+	if increase == nil {
+		return
+	}
+	next := map[string]string{
+		swiftv1beta1.PartPowerPhasePrepare:  swiftv1beta1.PartPowerPhaseRelink,
+		swiftv1beta1.PartPowerPhaseRelink:   swiftv1beta1.PartPowerPhaseIncrease,
+		swiftv1beta1.PartPowerPhaseIncrease: swiftv1beta1.PartPowerPhaseCleanup,
+		swiftv1beta1.PartPowerPhaseCleanup:  swiftv1beta1.PartPowerPhaseFinish,
+	}
+	phase, ok := next[increase.Phase]
+	if !ok {
+		instance.Status.PartPowerIncrease = nil
+		return
+	}
+	increase.Phase = phase
+	increase.PhaseTime = metav1.Now()
+	increase.Devices = nil
+}
@@ -0,0 +1,71 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package swiftring
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	swiftv1beta1 "github.com/openstack-k8s-operators/swift-operator/api/v1beta1"
+)
+
+func TestAdvancePartPowerIncrease(t *testing.T) {
+	tests := []struct {
+		phase string
+		next  string
+	}{
+		{phase: swiftv1beta1.PartPowerPhasePrepare, next: swiftv1beta1.PartPowerPhaseRelink},
+		{phase: swiftv1beta1.PartPowerPhaseRelink, next: swiftv1beta1.PartPowerPhaseIncrease},
+		{phase: swiftv1beta1.PartPowerPhaseIncrease, next: swiftv1beta1.PartPowerPhaseCleanup},
+		{phase: swiftv1beta1.PartPowerPhaseCleanup, next: swiftv1beta1.PartPowerPhaseFinish},
+		{phase: swiftv1beta1.PartPowerPhaseFinish},
+	}
+	for _, test := range tests {
+		t.Run(test.phase, func(t *testing.T) {
+			g := NewWithT(t)
+			instance := &swiftv1beta1.SwiftRing{}
+			instance.Status.PartPowerIncrease = &swiftv1beta1.SwiftRingPartPowerIncreaseStatus{
+				Ring:      "object",
+				PartPower: 11,
+				Phase:     test.phase,
+				Devices:   map[string]string{"swift-storage-0": test.phase},
+			}
+
+			AdvancePartPowerIncrease(instance)
+			if test.next == "" {
+				g.Expect(instance.Status.PartPowerIncrease).To(BeNil())
+				return
+			}
+			increase := instance.Status.PartPowerIncrease
+			g.Expect(increase).NotTo(BeNil())
+			g.Expect(increase.Ring).To(Equal("object"))
+			g.Expect(increase.PartPower).To(Equal(int32(11)))
+			g.Expect(increase.Phase).To(Equal(test.next))
+			g.Expect(increase.PhaseTime).NotTo(Equal(metav1.Time{}))
+			g.Expect(increase.Devices).To(BeNil())
+		})
+	}
+
+	t.Run("no increase", func(t *testing.T) {
+		g := NewWithT(t)
+		instance := &swiftv1beta1.SwiftRing{}
+		AdvancePartPowerIncrease(instance)
+		g.Expect(instance.Status.PartPowerIncrease).To(BeNil())
+	})
+}
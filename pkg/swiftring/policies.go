@@ -0,0 +1,130 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package swiftring
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	swiftv1beta1 "github.com/openstack-k8s-operators/swift-operator/api/v1beta1"
+)
+
+// defaultPolicyName is the name Swift uses for policy 0 if none is given
+const defaultPolicyName = "Policy-0"
+
+// PolicyRing returns the name of the ring of the storage policy
+func PolicyRing(index int32) string {
+	return fmt.Sprintf("object-%d", index)
+}
+
+// ValidateStoragePolicies checks the storage policies and the device
+// selectors of all rings. Swift requires unique policy names and a default
+// policy that is not deprecated. Objects of a removed policy would not be
+// reachable anymore, thus the policies of existing rings must be kept
+func ValidateStoragePolicies(instance *swiftv1beta1.SwiftRing) error {
+	names := map[string]bool{strings.ToLower(defaultPolicyName): true}
+	policies := map[string]bool{}
+	defaults := 0
+	for _, policy := range instance.Spec.StoragePolicies {
+		if names[strings.ToLower(policy.Name)] {
+			return fmt.Errorf("storagePolicies: the name %s is used more than once", policy.Name)
+		}
+		names[strings.ToLower(policy.Name)] = true
+		policies[PolicyRing(policy.Index)] = true
+		if policy.Default {
+			if policy.Deprecated {
+				return fmt.Errorf("storagePolicies: the default policy %s can't be deprecated", policy.Name)
+			}
+			defaults++
+		}
+	}
+	if defaults > 1 {
+		return fmt.Errorf("storagePolicies: only one policy can be the default")
+	}
+	for ring := range instance.Status.Rings {
+		if strings.HasPrefix(ring, "object-") && !policies[ring] {
+			return fmt.Errorf("storagePolicies: the policy of the %s ring can't be removed, deprecate it instead", ring)
+		}
+	}
+
+	for _, ring := range RingNames(instance) {
+		for key, value := range ringSpecParameters(instance, ring).DeviceSelector {
+			if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+				return fmt.Errorf("deviceSelector of the %s ring: invalid key %q: %s", ring, key, strings.Join(errs, ", "))
+			}
+			if errs := validation.IsValidLabelValue(value); len(errs) > 0 {
+				return fmt.Errorf("deviceSelector of the %s ring: invalid value %q of %s: %s", ring, value, key, strings.Join(errs, ", "))
+			}
+		}
+	}
+	return nil
+}
+
+// storagePoliciesConf returns the storage policy sections appended to
+// swift.conf, empty if there are no additional policies
+func storagePoliciesConf(instance *swiftv1beta1.SwiftRing) string {
+	if len(instance.Spec.StoragePolicies) == 0 {
+		return ""
+	}
+
+	var conf strings.Builder
+	isDefault := true
+	for _, policy := range instance.Spec.StoragePolicies {
+		if policy.Default {
+			isDefault = false
+		}
+	}
+	conf.WriteString(fmt.Sprintf("[storage-policy:0]\nname = %s\n", defaultPolicyName))
+	if isDefault {
+		conf.WriteString("default = yes\n")
+	}
+	for _, policy := range instance.Spec.StoragePolicies {
+		conf.WriteString(fmt.Sprintf("\n[storage-policy:%d]\nname = %s\n", policy.Index, policy.Name))
+		if policy.Default {
+			conf.WriteString("default = yes\n")
+		}
+		if policy.Deprecated {
+			conf.WriteString("deprecated = yes\n")
+		}
+	}
+	return conf.String()
+}
+
+// DevicePolicies returns the indexes of the storage policies whose object
+// ring uses devices with the given labels, including the default policy 0
+func DevicePolicies(instance *swiftv1beta1.SwiftRing, labels map[string]string) []int32 {
+	policies := []int32{}
+	selects := func(ring string) bool {
+		for key, value := range ringSpecParameters(instance, ring).DeviceSelector {
+			if labels[key] != value {
+				return false
+			}
+		}
+		return true
+	}
+	if selects("object") {
+		policies = append(policies, 0)
+	}
+	for _, policy := range instance.Spec.StoragePolicies {
+		if selects(PolicyRing(policy.Index)) {
+			policies = append(policies, policy.Index)
+		}
+	}
+	return policies
+}
@@ -0,0 +1,202 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package swiftring
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	swiftv1beta1 "github.com/openstack-k8s-operators/swift-operator/api/v1beta1"
+)
+
+// ReplicationCheckInterval is the time between the checks of the
+// replication of the storage pods during a replica count change
+const ReplicationCheckInterval = time.Minute
+
+// replicaChangeable returns if the replica count of the ring can be changed
+// in steps. The replica count of a composite ring is the sum of its
+// components
+func replicaChangeable(instance *swiftv1beta1.SwiftRing, ring string) bool {
+	return ring != "object" || !instance.Spec.Composite.Enabled
+}
+
+// RingService returns the Swift service using the ring
+func RingService(ring string) string {
+	if strings.HasPrefix(ring, "object") {
+		return "object"
+	}
+	return ring
+}
+
+// currentReplicas returns the replica count of the existing ring, 0 if the
+// ring does not exist yet
+func currentReplicas(instance *swiftv1beta1.SwiftRing, ring string) float64 {
+	stats, ok := instance.Status.Rings[ring]
+	if !ok {
+		return 0
+	}
+	replicas, err := strconv.ParseFloat(stats.Replicas, 64)
+	if err != nil {
+		return 0
+	}
+	return replicas
+}
+
+// formatReplicas formats a replica count like the ring stats
+func formatReplicas(replicas float64) string {
+	return strconv.FormatFloat(replicas, 'g', -1, 64)
+}
+
+// replicaStep returns the change of the replica count per step
+func replicaStep(instance *swiftv1beta1.SwiftRing) float64 {
+	if instance.Spec.ReplicaChange.StepPercent <= 0 {
+		return 1
+	}
+	return float64(instance.Spec.ReplicaChange.StepPercent) / 100
+}
+
+// nextReplicaStep returns the replica count of the step following the
+// current replica count, at most the target
+func nextReplicaStep(instance *swiftv1beta1.SwiftRing, current float64, target int64) string {
+	step := replicaStep(instance)
+	next := float64(target)
+	if next > current+step {
+		next = current + step
+	} else if next < current-step {
+		next = current - step
+	}
+	return formatReplicas(math.Round(next*100) / 100)
+}
+
+// ringReplicasEnv returns the replica count of the ring used by the
+// rebalance Job, the current step of a replica count change in progress
+func ringReplicasEnv(instance *swiftv1beta1.SwiftRing, ring string, replicas int64) string {
+	if change, ok := instance.Status.ReplicaChanges[ring]; ok {
+		return change.Replicas
+	}
+	return fmt.Sprint(replicas)
+}
+
+// UpdateReplicaChanges starts the replica count changes of the existing
+// rings whose replica count differs by more than one step from the ring
+// parameters, and removes the finished ones. It returns the rings whose
+// current step is applied and that wait for the next step
+func UpdateReplicaChanges(instance *swiftv1beta1.SwiftRing) []string {
+	if !instance.Spec.ReplicaChange.Enabled {
+		instance.Status.ReplicaChanges = nil
+		return nil
+	}
+
+	changes := map[string]swiftv1beta1.SwiftRingReplicaChangeStatus{}
+	applied := []string{}
+	for _, ring := range RingNames(instance) {
+		current := currentReplicas(instance, ring)
+		if current == 0 || !replicaChangeable(instance, ring) {
+			continue
+		}
+		_, _, target := RingParameters(instance, ring)
+		change, ok := instance.Status.ReplicaChanges[ring]
+		switch {
+		case current == float64(target):
+			// Finished, or the target was changed back
+			continue
+		case !ok && math.Abs(float64(target)-current) <= replicaStep(instance):
+			// Applied by a single rebalance
+			continue
+		case !ok:
+			change = swiftv1beta1.SwiftRingReplicaChangeStatus{
+				Replicas: nextReplicaStep(instance, current, target),
+				StepTime: metav1.Now(),
+			}
+		case formatReplicas(current) == change.Replicas:
+			applied = append(applied, ring)
+		}
+		change.TargetReplicas = target
+		changes[ring] = change
+	}
+
+	if len(changes) == 0 {
+		changes = nil
+	}
+	instance.Status.ReplicaChanges = changes
+	return applied
+}
+
+// ReplicationCheckTime returns the time all storage pods must have finished
+// a replication pass after, before the next step of the replica count change
+// of the ring. The replicators start using the rings of the current step
+// once they are distributed
+func ReplicationCheckTime(instance *swiftv1beta1.SwiftRing, ring string) time.Time {
+	published := instance.Status.ReplicaChanges[ring].StepTime.Time
+	if last := instance.Status.LastRebalanceTime; last != nil && last.After(published) {
+		published = last.Time
+	}
+	return published.Add(RingDistributionDelay)
+}
+
+// ReplicationCheckDue returns if the replication of the storage pods must be
+// checked for the current step of the replica count change of the ring. The
+// recon of the storage pods is queried at most every ReplicationCheckInterval
+// and not before the replicators can use the rings of the step
+func ReplicationCheckDue(instance *swiftv1beta1.SwiftRing, ring string) bool {
+	now := time.Now()
+	if now.Before(ReplicationCheckTime(instance, ring)) {
+		return false
+	}
+	last := instance.Status.ReplicaChanges[ring].LastCheckTime
+	return last == nil || !now.Before(last.Add(ReplicationCheckInterval))
+}
+
+// AdvanceReplicaChange moves the replica count change of the ring to its
+// next step
+func AdvanceReplicaChange(instance *swiftv1beta1.SwiftRing, ring string) {
+	change, ok := instance.Status.ReplicaChanges[ring]
+	if !ok {
+		return
+	}
+	change.Replicas = nextReplicaStep(instance, currentReplicas(instance, ring), change.TargetReplicas)
+	change.StepTime = metav1.Now()
+	change.LastCheckTime = nil
+	instance.Status.ReplicaChanges[ring] = change
+}
+
+// ReplicaChangesSummary returns the current step and the target of all
+// replica count changes in progress
+func ReplicaChangesSummary(instance *swiftv1beta1.SwiftRing) string {
+	changes := []string{}
+	for ring, change := range instance.Status.ReplicaChanges {
+		changes = append(changes, fmt.Sprintf("%s ring %s of %d replicas", ring, change.Replicas, change.TargetReplicas))
+	}
+	sort.Strings(changes)
+	return strings.Join(changes, ", ")
+}
+
+// NextReplicationCheck returns the time of the next replication check of
+// the replica count changes, nil if none is in progress
+func NextReplicationCheck(instance *swiftv1beta1.SwiftRing) *time.Time {
+	if len(instance.Status.ReplicaChanges) == 0 {
+		return nil
+	}
+	next := time.Now().Add(ReplicationCheckInterval)
+	return &next
+}
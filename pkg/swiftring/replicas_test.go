@@ -0,0 +1,217 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package swiftring
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	swiftv1beta1 "github.com/openstack-k8s-operators/swift-operator/api/v1beta1"
+)
+
+func TestUpdateReplicaChanges(t *testing.T) {
+	tests := []struct {
+		name        string
+		enabled     bool
+		stepPercent int32
+		target      int64
+		current     string
+		changes     map[string]swiftv1beta1.SwiftRingReplicaChangeStatus
+		applied     []string
+		expected    map[string]swiftv1beta1.SwiftRingReplicaChangeStatus
+	}{
+		{
+			name:    "disabled",
+			target:  3,
+			current: "1",
+			changes: map[string]swiftv1beta1.SwiftRingReplicaChangeStatus{
+				"object": {Replicas: "1.5", TargetReplicas: 3},
+			},
+		},
+		{
+			name:    "new ring",
+			enabled: true,
+			target:  3,
+		},
+		{
+			name:    "unchanged",
+			enabled: true,
+			target:  3,
+			current: "3",
+		},
+		{
+			name:        "single step",
+			enabled:     true,
+			stepPercent: 100,
+			target:      3,
+			current:     "2",
+		},
+		{
+			name:        "new change",
+			enabled:     true,
+			stepPercent: 50,
+			target:      3,
+			current:     "1",
+			expected: map[string]swiftv1beta1.SwiftRingReplicaChangeStatus{
+				"object": {Replicas: "1.5", TargetReplicas: 3},
+			},
+		},
+		{
+			name:        "decrease",
+			enabled:     true,
+			stepPercent: 25,
+			target:      2,
+			current:     "3",
+			expected: map[string]swiftv1beta1.SwiftRingReplicaChangeStatus{
+				"object": {Replicas: "2.75", TargetReplicas: 2},
+			},
+		},
+		{
+			name:        "step pending",
+			enabled:     true,
+			stepPercent: 50,
+			target:      3,
+			current:     "1",
+			changes: map[string]swiftv1beta1.SwiftRingReplicaChangeStatus{
+				"object": {Replicas: "1.5", TargetReplicas: 3},
+			},
+			expected: map[string]swiftv1beta1.SwiftRingReplicaChangeStatus{
+				"object": {Replicas: "1.5", TargetReplicas: 3},
+			},
+		},
+		{
+			name:        "step applied",
+			enabled:     true,
+			stepPercent: 50,
+			target:      3,
+			current:     "1.5",
+			changes: map[string]swiftv1beta1.SwiftRingReplicaChangeStatus{
+				"object": {Replicas: "1.5", TargetReplicas: 3},
+			},
+			applied: []string{"object"},
+			expected: map[string]swiftv1beta1.SwiftRingReplicaChangeStatus{
+				"object": {Replicas: "1.5", TargetReplicas: 3},
+			},
+		},
+		{
+			name:        "target changed",
+			enabled:     true,
+			stepPercent: 50,
+			target:      4,
+			current:     "1",
+			changes: map[string]swiftv1beta1.SwiftRingReplicaChangeStatus{
+				"object": {Replicas: "1.5", TargetReplicas: 3},
+			},
+			expected: map[string]swiftv1beta1.SwiftRingReplicaChangeStatus{
+				"object": {Replicas: "1.5", TargetReplicas: 4},
+			},
+		},
+		{
+			name:        "target changed back",
+			enabled:     true,
+			stepPercent: 50,
+			target:      1,
+			current:     "1",
+			changes: map[string]swiftv1beta1.SwiftRingReplicaChangeStatus{
+				"object": {Replicas: "1.5", TargetReplicas: 3},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := NewWithT(t)
+			target := test.target
+			instance := &swiftv1beta1.SwiftRing{}
+			instance.Spec.ReplicaChange = swiftv1beta1.SwiftRingReplicaChangeSpec{
+				Enabled:     test.enabled,
+				StepPercent: test.stepPercent,
+			}
+			instance.Spec.RingReplicas = &target
+			instance.Spec.RingParameters.Object.Replicas = &target
+			if test.current != "" {
+				instance.Status.Rings = map[string]swiftv1beta1.SwiftRingStats{
+					"object": {Replicas: test.current},
+				}
+			}
+			instance.Status.ReplicaChanges = test.changes
+
+			applied := UpdateReplicaChanges(instance)
+			if test.applied == nil {
+				g.Expect(applied).To(BeEmpty())
+			} else {
+				g.Expect(applied).To(Equal(test.applied))
+			}
+			if test.expected == nil {
+				g.Expect(instance.Status.ReplicaChanges).To(BeNil())
+				return
+			}
+			g.Expect(instance.Status.ReplicaChanges).To(HaveLen(len(test.expected)))
+			for ring, expected := range test.expected {
+				change := instance.Status.ReplicaChanges[ring]
+				g.Expect(change.Replicas).To(Equal(expected.Replicas))
+				g.Expect(change.TargetReplicas).To(Equal(expected.TargetReplicas))
+			}
+		})
+	}
+}
+
+func TestReplicationCheckDue(t *testing.T) {
+	ago := func(duration time.Duration) *metav1.Time {
+		past := metav1.NewTime(time.Now().Add(-duration))
+		return &past
+	}
+	tests := []struct {
+		name      string
+		stepTime  *metav1.Time
+		lastCheck *metav1.Time
+		due       bool
+	}{
+		{
+			name:     "rings not distributed",
+			stepTime: ago(RingDistributionDelay / 2),
+		},
+		{
+			name:     "first check",
+			stepTime: ago(RingDistributionDelay + time.Minute),
+			due:      true,
+		},
+		{
+			name:      "checked recently",
+			stepTime:  ago(RingDistributionDelay + time.Minute),
+			lastCheck: ago(ReplicationCheckInterval / 2),
+		},
+		{
+			name:      "check interval passed",
+			stepTime:  ago(RingDistributionDelay + time.Minute),
+			lastCheck: ago(ReplicationCheckInterval),
+			due:       true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := NewWithT(t)
+			instance := &swiftv1beta1.SwiftRing{}
+			instance.Status.ReplicaChanges = map[string]swiftv1beta1.SwiftRingReplicaChangeStatus{
+				"object": {Replicas: "1.5", TargetReplicas: 3, StepTime: *test.stepTime, LastCheckTime: test.lastCheck},
+			}
+			g.Expect(ReplicationCheckDue(instance, "object")).To(Equal(test.due))
+		})
+	}
+}
@@ -0,0 +1,41 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package swiftring
+
+import (
+	"time"
+
+	swiftv1beta1 "github.com/openstack-k8s-operators/swift-operator/api/v1beta1"
+)
+
+// NextRebalanceTime returns the time of the next scheduled rebalance. It is
+// nil if the automatic rebalance is disabled or the rings were not created
+// yet
+func NextRebalanceTime(instance *swiftv1beta1.SwiftRing) *time.Time {
+	if !instance.Spec.AutoRebalance.Enabled {
+		return nil
+	}
+	last := instance.Status.LastScheduledRebalanceTime
+	if rebalanced := instance.Status.LastRebalanceTime; rebalanced != nil && (last == nil || rebalanced.After(last.Time)) {
+		last = rebalanced
+	}
+	if last == nil {
+		return nil
+	}
+	next := last.Add(time.Duration(instance.Spec.AutoRebalance.IntervalSeconds) * time.Second)
+	return &next
+}
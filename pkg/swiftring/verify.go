@@ -0,0 +1,93 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package swiftring
+
+import (
+	"encoding/json"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	swiftv1beta1 "github.com/openstack-k8s-operators/swift-operator/api/v1beta1"
+	"github.com/openstack-k8s-operators/swift-operator/pkg/swift"
+)
+
+// VerifyJobName returns the name of the Job verifying the restored rings
+func VerifyJobName(instance *swiftv1beta1.SwiftRing) string {
+	return instance.Name + "-verify"
+}
+
+// GetVerifyJob returns a Job that compares the published rings with the
+// partitions and hashes stored on the devices. The devices are listed using
+// the rsync daemons of the storage pods, thus the image must provide rsync.
+// The result is reported in the termination message. The annotations attach
+// the pod to the networks of the storage pods
+func GetVerifyJob(instance *swiftv1beta1.SwiftRing, labels map[string]string, annotations map[string]string) *batchv1.Job {
+	securityContext := swift.GetSecurityContext()
+	backoffLimit := int32(2)
+
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      VerifyJobName(instance),
+			Namespace: instance.Namespace,
+			Labels:    labels,
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: &backoffLimit,
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels:      labels,
+					Annotations: annotations,
+				},
+				Spec: corev1.PodSpec{
+					RestartPolicy:      corev1.RestartPolicyNever,
+					ServiceAccountName: swift.ServiceAccount,
+					ImagePullSecrets:   instance.Spec.ImagePullSecrets,
+					NodeSelector:       instance.Spec.NodeSelector,
+					Tolerations:        instance.Spec.Tolerations,
+					SecurityContext: &corev1.PodSecurityContext{
+						SeccompProfile: &corev1.SeccompProfile{
+							Type: corev1.SeccompProfileTypeRuntimeDefault,
+						},
+					},
+					Containers: []corev1.Container{
+						{
+							Name:            "verify",
+							Command:         []string{"/usr/local/bin/container-scripts/swift-ring-verify.sh"},
+							Image:           instance.Spec.ContainerImage,
+							ImagePullPolicy: corev1.PullIfNotPresent,
+							SecurityContext: &securityContext,
+							Resources:       instance.Spec.Resources,
+							VolumeMounts:    getVerifyVolumeMounts(instance),
+						},
+					},
+					Volumes: getVerifyVolumes(instance),
+				},
+			},
+		},
+	}
+}
+
+// ParseVerification parses the result reported by the verification Job
+func ParseVerification(message string) (*swiftv1beta1.SwiftRingVerification, error) {
+	verification := &swiftv1beta1.SwiftRingVerification{}
+	if err := json.Unmarshal([]byte(message), verification); err != nil {
+		return nil, err
+	}
+	return verification, nil
+}
@@ -18,12 +18,13 @@ package swiftring
 
 import (
 	swiftv1beta1 "github.com/openstack-k8s-operators/swift-operator/api/v1beta1"
+	"github.com/openstack-k8s-operators/swift-operator/pkg/swift"
 	corev1 "k8s.io/api/core/v1"
 )
 
 func getRingVolumes(instance *swiftv1beta1.SwiftRing) []corev1.Volume {
 	var scriptsVolumeDefaultMode int32 = 0755
-	return []corev1.Volume{
+	volumes := []corev1.Volume{
 		{
 			Name: "scripts",
 			VolumeSource: corev1.VolumeSource{
@@ -58,10 +59,34 @@ func getRingVolumes(instance *swiftv1beta1.SwiftRing) []corev1.Volume {
 			},
 		},
 	}
+
+	if instance.Spec.RingSnapshotSecret != "" {
+		volumes = append(volumes, corev1.Volume{
+			Name: "ring-snapshot",
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: instance.Spec.RingSnapshotSecret,
+				},
+			},
+		})
+	}
+
+	if instance.Spec.RingImportSecret != "" {
+		volumes = append(volumes, corev1.Volume{
+			Name: "ring-import",
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: instance.Spec.RingImportSecret,
+				},
+			},
+		})
+	}
+
+	return volumes
 }
 
-func getRingVolumeMounts() []corev1.VolumeMount {
-	return []corev1.VolumeMount{
+func getRingVolumeMounts(instance *swiftv1beta1.SwiftRing) []corev1.VolumeMount {
+	volumeMounts := []corev1.VolumeMount{
 		{
 			Name:      "scripts",
 			MountPath: "/usr/local/bin/container-scripts",
@@ -83,4 +108,48 @@ func getRingVolumeMounts() []corev1.VolumeMount {
 			ReadOnly:  true,
 		},
 	}
+
+	if instance.Spec.RingSnapshotSecret != "" {
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      "ring-snapshot",
+			MountPath: "/var/lib/config-data/ring-snapshot",
+			ReadOnly:  true,
+		})
+	}
+
+	if instance.Spec.RingImportSecret != "" {
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      "ring-import",
+			MountPath: "/var/lib/config-data/ring-import",
+			ReadOnly:  true,
+		})
+	}
+
+	return volumeMounts
+}
+
+// getVerifyVolumes returns the volumes of the ring verification Job, which
+// uses the published rings instead of the ring snapshot or import
+func getVerifyVolumes(instance *swiftv1beta1.SwiftRing) []corev1.Volume {
+	volumes := []corev1.Volume{}
+	for _, volume := range getRingVolumes(instance) {
+		if volume.Name != "ring-snapshot" && volume.Name != "ring-import" {
+			volumes = append(volumes, volume)
+		}
+	}
+	return append(volumes, swift.RingDataVolume(instance.Spec.RingDistribution, false))
+}
+
+func getVerifyVolumeMounts(instance *swiftv1beta1.SwiftRing) []corev1.VolumeMount {
+	volumeMounts := []corev1.VolumeMount{}
+	for _, volumeMount := range getRingVolumeMounts(instance) {
+		if volumeMount.Name != "ring-snapshot" && volumeMount.Name != "ring-import" {
+			volumeMounts = append(volumeMounts, volumeMount)
+		}
+	}
+	return append(volumeMounts, corev1.VolumeMount{
+		Name:      "ring-data",
+		MountPath: "/var/lib/config-data/rings",
+		ReadOnly:  true,
+	})
 }
@@ -0,0 +1,193 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package swiftstorage
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+
+	swiftv1beta1 "github.com/openstack-k8s-operators/swift-operator/api/v1beta1"
+	"github.com/openstack-k8s-operators/swift-operator/pkg/swiftring"
+)
+
+const (
+	DefaultDiskUsagePercent      int32 = 80
+	DefaultAsyncPending          int32 = 1000
+	DefaultReplicationAgeSeconds int32 = 86400
+
+	// Interval to check the recon data against the thresholds
+	AlertsInterval = time.Minute
+
+	// Policy 0 uses the object ring, additional policies the object-<index>
+	// rings
+	defaultPolicy = "0"
+)
+
+func mergeThresholds(dst *swiftv1beta1.SwiftAlertThresholds, src *swiftv1beta1.SwiftAlertThresholds) {
+	if src == nil {
+		return
+	}
+	if src.DiskUsagePercent != nil {
+		dst.DiskUsagePercent = src.DiskUsagePercent
+	}
+	if src.AsyncPending != nil {
+		dst.AsyncPending = src.AsyncPending
+	}
+	if src.ReplicationAgeSeconds != nil {
+		dst.ReplicationAgeSeconds = src.ReplicationAgeSeconds
+	}
+}
+
+// AlertThresholds returns the thresholds for the given service and policy.
+// Policy thresholds only apply to the object service. All returned values
+// are set
+func AlertThresholds(alerts swiftv1beta1.SwiftStorageAlerts, service string, policy string) swiftv1beta1.SwiftAlertThresholds {
+	diskUsagePercent := DefaultDiskUsagePercent
+	asyncPending := DefaultAsyncPending
+	replicationAgeSeconds := DefaultReplicationAgeSeconds
+	thresholds := swiftv1beta1.SwiftAlertThresholds{
+		DiskUsagePercent:      &diskUsagePercent,
+		AsyncPending:          &asyncPending,
+		ReplicationAgeSeconds: &replicationAgeSeconds,
+	}
+
+	mergeThresholds(&thresholds, &alerts.SwiftAlertThresholds)
+	switch service {
+	case "account":
+		mergeThresholds(&thresholds, alerts.Account)
+	case "container":
+		mergeThresholds(&thresholds, alerts.Container)
+	case "object":
+		mergeThresholds(&thresholds, alerts.Object)
+		if policyThresholds, ok := alerts.Policies[policy]; ok {
+			mergeThresholds(&thresholds, &policyThresholds)
+		}
+	}
+	return thresholds
+}
+
+// StoragePolicies returns the indexes of the storage policies with object
+// data on the devices of the instance, as selected by the object rings of the
+// SwiftRings in the namespace. Devices without object data use the default
+// policy, eg. if there is no SwiftRing yet
+func StoragePolicies(ctx context.Context, h *helper.Helper, instance *swiftv1beta1.SwiftStorage) ([]string, error) {
+	rings := &swiftv1beta1.SwiftRingList{}
+	err := h.GetClient().List(ctx, rings, client.InNamespace(instance.Namespace))
+	if err != nil {
+		return nil, err
+	}
+
+	indexes := map[int32]bool{}
+	for i := range rings.Items {
+		for _, index := range swiftring.DevicePolicies(&rings.Items[i], instance.Spec.DeviceLabels) {
+			indexes[index] = true
+		}
+	}
+	sorted := []int32{}
+	for index := range indexes {
+		sorted = append(sorted, index)
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	policies := []string{}
+	for _, index := range sorted {
+		policies = append(policies, strconv.Itoa(int(index)))
+	}
+	if len(policies) == 0 {
+		policies = append(policies, defaultPolicy)
+	}
+	return policies, nil
+}
+
+// alertPolicies returns all policies with thresholds and the given policies
+// of the devices, including the default policy
+func alertPolicies(alerts swiftv1beta1.SwiftStorageAlerts, policies []string) []string {
+	found := map[string]bool{defaultPolicy: true}
+	for policy := range alerts.Policies {
+		found[policy] = true
+	}
+	for _, policy := range policies {
+		found[policy] = true
+	}
+	result := []string{}
+	for policy := range found {
+		result = append(result, policy)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// policyMessage adds the policy to the message of an object threshold,
+// unless it is the default policy
+func policyMessage(message string, policy string) string {
+	if policy == defaultPolicy {
+		return message
+	}
+	return fmt.Sprintf("%s (policy %s)", message, policy)
+}
+
+// EvaluateAlerts returns a message for every exceeded threshold. Devices are
+// shared by all services, therefore the object thresholds of every policy
+// stored on the devices are used for the disk usage. The recon data of the
+// async pendings and the object replication is not split by policy, it is
+// checked against the thresholds of all given policies
+func EvaluateAlerts(alerts swiftv1beta1.SwiftStorageAlerts, stats []ReconStats, policies []string) []string {
+	messages := []string{}
+
+	for _, podStats := range stats {
+		devices := []string{}
+		for device := range podStats.DiskUsage {
+			devices = append(devices, device)
+		}
+		sort.Strings(devices)
+
+		for _, policy := range policies {
+			thresholds := AlertThresholds(alerts, "object", policy)
+			for _, device := range devices {
+				usage := podStats.DiskUsage[device]
+				if usage > float64(*thresholds.DiskUsagePercent) {
+					messages = append(messages, policyMessage(fmt.Sprintf("%s device %s %.0f%% used", podStats.Pod, device, usage), policy))
+				}
+			}
+			if podStats.AsyncPending > int64(*thresholds.AsyncPending) {
+				messages = append(messages, policyMessage(fmt.Sprintf("%s %d async pendings", podStats.Pod, podStats.AsyncPending), policy))
+			}
+			if age, ok := podStats.ReplicationAge["object"]; ok && age > float64(*thresholds.ReplicationAgeSeconds) {
+				messages = append(messages, policyMessage(fmt.Sprintf("%s last object replication %.0fs ago", podStats.Pod, age), policy))
+			}
+		}
+
+		for _, service := range []string{"account", "container"} {
+			age, ok := podStats.ReplicationAge[service]
+			if !ok {
+				continue
+			}
+			thresholds := AlertThresholds(alerts, service, "")
+			if age > float64(*thresholds.ReplicationAgeSeconds) {
+				messages = append(messages, fmt.Sprintf("%s last %s replication %.0fs ago", podStats.Pod, service, age))
+			}
+		}
+	}
+	return messages
+}
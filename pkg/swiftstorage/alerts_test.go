@@ -0,0 +1,99 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package swiftstorage
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	swiftv1beta1 "github.com/openstack-k8s-operators/swift-operator/api/v1beta1"
+	"github.com/openstack-k8s-operators/swift-operator/pkg/swiftring"
+)
+
+func TestEvaluateAlertsPolicies(t *testing.T) {
+	ring := &swiftv1beta1.SwiftRing{}
+	ring.Spec.RingParameters.Object.DeviceSelector = map[string]string{"disk": "hdd"}
+	ring.Spec.StoragePolicies = []swiftv1beta1.SwiftRingStoragePolicy{{
+		Index:      1,
+		Name:       "ssd",
+		Parameters: swiftv1beta1.SwiftRingParameters{DeviceSelector: map[string]string{"disk": "ssd"}},
+	}}
+
+	fifty := int32(50)
+	alerts := swiftv1beta1.SwiftStorageAlerts{
+		Policies: map[string]swiftv1beta1.SwiftAlertThresholds{
+			"1": {DiskUsagePercent: &fifty},
+		},
+	}
+	stats := []ReconStats{{
+		Pod:       "swift-storage-0",
+		DiskUsage: map[string]float64{"pv": 60},
+	}}
+
+	tests := []struct {
+		name     string
+		labels   map[string]string
+		policies []string
+		expected []string
+	}{
+		{
+			name:     "default policy",
+			labels:   map[string]string{"disk": "hdd"},
+			policies: []string{"0"},
+			expected: []string{},
+		},
+		{
+			name:     "policy with threshold",
+			labels:   map[string]string{"disk": "ssd"},
+			policies: []string{"1"},
+			expected: []string{"swift-storage-0 device pv 60% used (policy 1)"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := NewWithT(t)
+			instance := &swiftv1beta1.SwiftStorage{
+				ObjectMeta: metav1.ObjectMeta{Name: "swift-storage", Namespace: "openstack"},
+			}
+			instance.Spec.DeviceLabels = test.labels
+			instance.Spec.Alerts = alerts
+
+			policies := []string{}
+			for _, index := range swiftring.DevicePolicies(ring, test.labels) {
+				policies = append(policies, strconv.Itoa(int(index)))
+			}
+			g.Expect(policies).To(Equal(test.policies))
+			g.Expect(EvaluateAlerts(alerts, stats, policies)).To(Equal(test.expected))
+
+			// The series carry the policy matched by the rule selector
+			UpdateMetrics(instance, stats, policies)
+			defer DeleteMetrics(instance)
+			g.Expect(testutil.CollectAndCount(diskUsageMetric)).To(Equal(1))
+			g.Expect(testutil.ToFloat64(diskUsageMetric.WithLabelValues(
+				instance.Namespace, instance.Name, "swift-storage-0", "pv", test.policies[0]))).To(Equal(60.0))
+
+			spec := fmt.Sprint(prometheusRuleSpec(instance, policies))
+			g.Expect(spec).To(ContainSubstring(
+				`swift_storage_disk_usage_percent{swiftstorage_namespace="openstack",swiftstorage="swift-storage",policy="1"} > 50`))
+		})
+	}
+}
@@ -0,0 +1,132 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package swiftstorage
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+
+	swiftv1beta1 "github.com/openstack-k8s-operators/swift-operator/api/v1beta1"
+)
+
+// DeviceListKey returns the key of the device list of the SwiftStorage in
+// the device ConfigMap
+func DeviceListKey(instance *swiftv1beta1.SwiftStorage) string {
+	return instance.Name + swiftv1beta1.DeviceListKeySuffix
+}
+
+// FailedDeviceListKey returns the key of the failed devices of the
+// SwiftStorage in the device ConfigMap
+func FailedDeviceListKey(instance *swiftv1beta1.SwiftStorage) string {
+	return instance.Name + swiftv1beta1.FailedDeviceListKeySuffix
+}
+
+// deviceLabels returns the DeviceLabels as <key>=<value> list separated by
+// semicolons, which are neither used in label keys nor values
+func deviceLabels(instance *swiftv1beta1.SwiftStorage) (string, error) {
+	labels := []string{}
+	for key, value := range instance.Spec.DeviceLabels {
+		if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+			return "", fmt.Errorf("deviceLabels: invalid key %q: %s", key, strings.Join(errs, ", "))
+		}
+		if errs := validation.IsValidLabelValue(value); len(errs) > 0 {
+			return "", fmt.Errorf("deviceLabels: invalid value %q of %s: %s", value, key, strings.Join(errs, ", "))
+		}
+		labels = append(labels, key+"="+value)
+	}
+	sort.Strings(labels)
+	return strings.Join(labels, ";"), nil
+}
+
+// storedDevices returns the lines of the stored device list of the
+// SwiftStorage by the name of their pod, which is the last field
+func storedDevices(ctx context.Context, h *helper.Helper, instance *swiftv1beta1.SwiftStorage) (map[string]string, error) {
+	devices := map[string]string{}
+	configMap := &corev1.ConfigMap{}
+	err := h.GetClient().Get(ctx, types.NamespacedName{Name: swiftv1beta1.DeviceConfigMapName, Namespace: instance.Namespace}, configMap)
+	if apierrors.IsNotFound(err) {
+		return devices, nil
+	} else if err != nil {
+		return nil, err
+	}
+	for _, line := range strings.Split(configMap.Data[DeviceListKey(instance)], "\n") {
+		fields := strings.Split(line, ",")
+		if len(fields) < 2 {
+			continue
+		}
+		devices[fields[len(fields)-1]] = line
+	}
+	return devices, nil
+}
+
+// HasDeviceList returns true if the device ConfigMap contains the device
+// list of the SwiftStorage
+func HasDeviceList(ctx context.Context, h *helper.Helper, instance *swiftv1beta1.SwiftStorage) (bool, error) {
+	configMap := &corev1.ConfigMap{}
+	err := h.GetClient().Get(ctx, types.NamespacedName{Name: swiftv1beta1.DeviceConfigMapName, Namespace: instance.Namespace}, configMap)
+	if apierrors.IsNotFound(err) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	_, ok := configMap.Data[DeviceListKey(instance)]
+	return ok, nil
+}
+
+// EnsureDeviceList stores the device list and the failed devices of the
+// SwiftStorage in the device ConfigMap. Every SwiftStorage of the namespace
+// owns the ConfigMap and only patches its own keys
+func EnsureDeviceList(ctx context.Context, h *helper.Helper, instance *swiftv1beta1.SwiftStorage, devices string, failedDevices string) error {
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      swiftv1beta1.DeviceConfigMapName,
+			Namespace: instance.Namespace,
+		},
+	}
+
+	_, err := controllerutil.CreateOrPatch(ctx, h.GetClient(), configMap, func() error {
+		if configMap.Data == nil {
+			configMap.Data = map[string]string{}
+		}
+		// The keys used before the ConfigMap was shared
+		delete(configMap.Data, "devices.csv")
+		delete(configMap.Data, "failed.csv")
+
+		configMap.Data[DeviceListKey(instance)] = devices
+		if failedDevices != "" {
+			configMap.Data[FailedDeviceListKey(instance)] = failedDevices
+		} else {
+			delete(configMap.Data, FailedDeviceListKey(instance))
+		}
+		return controllerutil.SetOwnerReference(instance, configMap, h.GetScheme())
+	})
+	if err != nil {
+		return fmt.Errorf("error create/updating configmap: %w", err)
+	}
+	return nil
+}
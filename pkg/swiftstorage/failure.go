@@ -0,0 +1,207 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package swiftstorage
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+
+	swiftv1beta1 "github.com/openstack-k8s-operators/swift-operator/api/v1beta1"
+	"github.com/openstack-k8s-operators/swift-operator/pkg/swift"
+)
+
+// FailedDevicesInterval is the interval to check the volumes of the storage
+// pods again, the PVCs and PersistentVolumes are not watched
+const FailedDevicesInterval = 5 * time.Minute
+
+// volumeNodes returns the nodes a local PersistentVolume is bound to
+func volumeNodes(volume *corev1.PersistentVolume) []string {
+	nodes := []string{}
+	if volume.Spec.NodeAffinity == nil || volume.Spec.NodeAffinity.Required == nil {
+		return nodes
+	}
+	for _, term := range volume.Spec.NodeAffinity.Required.NodeSelectorTerms {
+		for _, expression := range term.MatchExpressions {
+			if expression.Key == corev1.LabelHostname && expression.Operator == corev1.NodeSelectorOpIn {
+				nodes = append(nodes, expression.Values...)
+			}
+		}
+	}
+	return nodes
+}
+
+// deviceFailure returns why the volume of the PVC is lost, or an empty
+// string if it is usable. The PVC is lost if its PersistentVolume was
+// deleted or failed, or if all nodes of a local volume were removed
+func deviceFailure(ctx context.Context, h *helper.Helper, namespace string, claimName string) (string, error) {
+	claim := &corev1.PersistentVolumeClaim{}
+	err := h.GetClient().Get(ctx, types.NamespacedName{Name: claimName, Namespace: namespace}, claim)
+	if apierrors.IsNotFound(err) {
+		return "", nil
+	} else if err != nil {
+		return "", err
+	}
+	if claim.Status.Phase == corev1.ClaimLost {
+		return fmt.Sprintf("PVC %s lost its PersistentVolume", claimName), nil
+	}
+	if claim.Spec.VolumeName == "" {
+		return "", nil
+	}
+
+	volume := &corev1.PersistentVolume{}
+	err = h.GetClient().Get(ctx, types.NamespacedName{Name: claim.Spec.VolumeName}, volume)
+	if apierrors.IsNotFound(err) {
+		return fmt.Sprintf("PersistentVolume %s of PVC %s deleted", claim.Spec.VolumeName, claimName), nil
+	} else if err != nil {
+		return "", err
+	}
+	if volume.Status.Phase == corev1.VolumeFailed {
+		return fmt.Sprintf("PersistentVolume %s of PVC %s failed", volume.Name, claimName), nil
+	}
+
+	nodes := volumeNodes(volume)
+	for _, node := range nodes {
+		err := h.GetClient().Get(ctx, types.NamespacedName{Name: node}, &corev1.Node{})
+		if err == nil {
+			return "", nil
+		} else if !apierrors.IsNotFound(err) {
+			return "", err
+		}
+	}
+	if len(nodes) > 0 {
+		return fmt.Sprintf("node %s of PersistentVolume %s deleted", strings.Join(nodes, ","), volume.Name), nil
+	}
+	return "", nil
+}
+
+// UpdateFailedDevices updates the failed devices in the status and applies
+// the action of FailedDevices to the devices failed for longer than the
+// grace period. Devices with a usable volume again are removed from the
+// status. It returns the time until the next grace period expires, or 0
+func UpdateFailedDevices(ctx context.Context, h *helper.Helper, instance *swiftv1beta1.SwiftStorage) (time.Duration, error) {
+	policy := instance.Spec.FailedDevices
+	gracePeriod := time.Duration(policy.GracePeriodSeconds) * time.Second
+	failedDevices := map[string]swiftv1beta1.SwiftStorageFailedDevice{}
+	var requeue time.Duration
+	var stored map[string]string
+
+	start := OrdinalStart(instance)
+	for replica := start; replica < start+int(*instance.Spec.Replicas); replica++ {
+		podName := fmt.Sprintf("%s-%d", instance.Name, replica)
+		claimName := fmt.Sprintf("%s-%s-%d", swift.ClaimName, instance.Name, replica)
+		reason, err := deviceFailure(ctx, h, instance.Namespace, claimName)
+		if err != nil {
+			return 0, err
+		}
+		if reason == "" {
+			if _, ok := instance.Status.FailedDevices[podName]; ok {
+				h.GetLogger().Info(fmt.Sprintf("Device of %s available again", podName))
+			}
+			continue
+		}
+
+		failed, ok := instance.Status.FailedDevices[podName]
+		if !ok {
+			// The pod of a failed device is usually not running, its host
+			// is taken from the device list the rings were built from
+			if stored == nil {
+				stored, err = storedDevices(ctx, h, instance)
+				if err != nil {
+					return 0, err
+				}
+			}
+			host := deviceHost(ctx, h, instance, replica)
+			if fields := strings.Split(stored[podName], ","); len(fields) > 2 {
+				host = fields[2]
+			}
+			failed = swiftv1beta1.SwiftStorageFailedDevice{
+				Since: metav1.Now(),
+				Host:  host,
+			}
+			h.GetLogger().Info(fmt.Sprintf("Device of %s failed: %s", podName, reason))
+		}
+		failed.Reason = reason
+
+		action := ""
+		if policy.Action != "" && policy.Action != swiftv1beta1.FailedDeviceNone {
+			if remaining := time.Until(failed.Since.Add(gracePeriod)); remaining > 0 {
+				if requeue == 0 || remaining < requeue {
+					requeue = remaining
+				}
+			} else {
+				action = policy.Action
+			}
+		}
+		if action != failed.Action && action != "" {
+			h.GetLogger().Info(fmt.Sprintf("Applying %s to the failed device of %s", action, podName))
+		}
+		failed.Action = action
+		failedDevices[podName] = failed
+	}
+
+	instance.Status.FailedDevices = nil
+	if len(failedDevices) > 0 {
+		instance.Status.FailedDevices = failedDevices
+	}
+	return requeue, nil
+}
+
+// FailedDeviceCount returns the number of failed devices with an applied
+// action. Their pods might never become ready again
+func FailedDeviceCount(instance *swiftv1beta1.SwiftStorage) int32 {
+	count := int32(0)
+	for _, failed := range instance.Status.FailedDevices {
+		if failed.Action != "" {
+			count++
+		}
+	}
+	return count
+}
+
+// FailedDeviceMessages returns the failed devices and their reasons
+func FailedDeviceMessages(instance *swiftv1beta1.SwiftStorage) []string {
+	messages := []string{}
+	for podName, failed := range instance.Status.FailedDevices {
+		messages = append(messages, fmt.Sprintf("%s: %s", podName, failed.Reason))
+	}
+	sort.Strings(messages)
+	return messages
+}
+
+// FailedDeviceList returns the CSV list of the failed devices with an
+// applied action. These devices are not part of the device list
+func FailedDeviceList(instance *swiftv1beta1.SwiftStorage) string {
+	lines := []string{}
+	for _, failed := range instance.Status.FailedDevices {
+		if failed.Action != "" {
+			// CSV: hostname,devicename,action
+			lines = append(lines, fmt.Sprintf("%s,%s,%s\n", failed.Host, "d1", failed.Action))
+		}
+	}
+	sort.Strings(lines)
+	return strings.Join(lines, "")
+}
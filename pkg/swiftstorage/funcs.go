@@ -19,13 +19,17 @@ package swiftstorage
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strings"
 
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/networkattachment"
 
 	swiftv1beta1 "github.com/openstack-k8s-operators/swift-operator/api/v1beta1"
 	"github.com/openstack-k8s-operators/swift-operator/pkg/swift"
@@ -33,14 +37,53 @@ import (
 
 //+kubebuilder:rbac:groups=core,resources=persistentvolumeclaims,verbs=get;list;watch
 
-func DeviceList(ctx context.Context, h *helper.Helper, instance *swiftv1beta1.SwiftStorage) string {
+func DeviceList(ctx context.Context, h *helper.Helper, instance *swiftv1beta1.SwiftStorage) (string, []string, error) {
 	// Creates a CSV list of devices. If PVCs do not exist yet (because not
 	// all StatefulSets are up yet), it will just use the request capacity
-	// as value.
+	// as value. The regions and zones from the node labels are only known
+	// once the pods are scheduled. DeviceWeights overrides the weight of
+	// single devices, eg. if the actual disk size differs from the PVC.
+	// Failed devices with an applied action are listed by FailedDeviceList.
+	// The DeviceLabels are matched by the device selectors of the rings.
+	// Pods that are not scheduled keep their stored device, eg. while they
+	// are rescheduled, and are returned to check them again later.
 	var devices strings.Builder
+	labels, err := deviceLabels(instance)
+	if err != nil {
+		return "", nil, err
+	}
+	// The IPs and the topology are only known for scheduled pods
+	unscheduled := []string{}
+	stored := map[string]string{}
+	if len(instance.Spec.NetworkAttachments) > 0 || instance.Spec.RingTopology.Enabled {
+		stored, err = storedDevices(ctx, h, instance)
+		if err != nil {
+			return "", nil, err
+		}
+	}
+	ports := Ports(instance)
+	accountReplication, containerReplication, objectReplication := ReplicationPorts(instance)
 
 	foundClaim := &corev1.PersistentVolumeClaim{}
-	for replica := 0; replica < int(*instance.Spec.Replicas); replica++ {
+	start := OrdinalStart(instance)
+	for replica := start; replica < start+int(*instance.Spec.Replicas); replica++ {
+		podName := fmt.Sprintf("%s-%d", instance.Name, replica)
+		if failed, ok := instance.Status.FailedDevices[podName]; ok && failed.Action != "" {
+			continue
+		}
+		if len(instance.Spec.NetworkAttachments) > 0 || instance.Spec.RingTopology.Enabled {
+			scheduled, err := podScheduled(ctx, h, instance, podName)
+			if err != nil {
+				return "", nil, err
+			}
+			if !scheduled {
+				unscheduled = append(unscheduled, podName)
+				if device, ok := stored[podName]; ok {
+					devices.WriteString(device + "\n")
+				}
+				continue
+			}
+		}
 		cn := fmt.Sprintf("%s-%s-%d", swift.ClaimName, instance.Name, replica)
 		err := h.GetClient().Get(ctx, types.NamespacedName{Name: cn, Namespace: instance.Namespace}, foundClaim)
 		capacity := resource.MustParse(instance.Spec.StorageRequest)
@@ -52,12 +95,167 @@ func DeviceList(ctx context.Context, h *helper.Helper, instance *swiftv1beta1.Sw
 			h.GetLogger().Info(fmt.Sprintf("Did not find PVC %s, assuming %s as capacity", cn, instance.Spec.StorageRequest))
 		}
 		weight = weight / (1000 * 1000 * 1000) // 10GiB gets a weight of 10 etc.
-		// CSV: region,zone,hostname,devicename,weight
-		devices.WriteString(fmt.Sprintf("1,1,%s-%d.%s,%s,%d\n", instance.Name, replica, instance.Name, "d1", weight))
+		if weight == 0 {
+			weight = 1 // do not drain devices smaller than 1GB
+		}
+		if override, ok := instance.Spec.DeviceWeights[podName]; ok {
+			if override < 0 {
+				return "", nil, fmt.Errorf("deviceWeights: invalid weight %d of %s", override, podName)
+			}
+			weight = int64(override)
+		}
+		host := deviceHost(ctx, h, instance, replica)
+		region, zone, err := podTopology(ctx, h, instance, podName)
+		if err != nil {
+			return "", nil, err
+		}
+		// CSV: region,zone,hostname,devicename,weight,accountport,containerport,objectport,rsyncport,
+		// accountreplicationport,containerreplicationport,objectreplicationport,labels,podname
+		devices.WriteString(fmt.Sprintf("%d,%d,%s,%s,%d,%d,%d,%d,%d,%d,%d,%d,%s,%s\n", region, zone, host, "d1", weight,
+			ports.AccountServer, ports.ContainerServer, ports.ObjectServer, ports.Rsync,
+			accountReplication, containerReplication, objectReplication, labels, podName))
+	}
+	return devices.String(), unscheduled, nil
+}
+
+// podScheduled returns true if the storage pod exists and is scheduled to a
+// node
+func podScheduled(ctx context.Context, h *helper.Helper, instance *swiftv1beta1.SwiftStorage, podName string) (bool, error) {
+	pod := &corev1.Pod{}
+	err := h.GetClient().Get(ctx, types.NamespacedName{Name: podName, Namespace: instance.Namespace}, pod)
+	if apierrors.IsNotFound(err) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	return pod.Spec.NodeName != "", nil
+}
+
+// OrdinalStart returns the ordinal of the first storage pod
+func OrdinalStart(instance *swiftv1beta1.SwiftStorage) int {
+	if instance.Spec.OrdinalStart == nil {
+		return 0
+	}
+	return int(*instance.Spec.OrdinalStart)
+}
+
+// deviceHost returns the ring host of the storage pod, which is its IP on
+// the first NetworkAttachment if there is one, otherwise its hostname
+func deviceHost(ctx context.Context, h *helper.Helper, instance *swiftv1beta1.SwiftStorage, replica int) string {
+	host := fmt.Sprintf("%s-%d.%s", instance.Name, replica, instance.Name)
+	if len(instance.Spec.NetworkAttachments) > 0 {
+		ip, err := storageIP(ctx, h, instance, fmt.Sprintf("%s-%d", instance.Name, replica))
+		if err != nil {
+			h.GetLogger().Info(fmt.Sprintf("Did not find IP of %s on %s, using hostname: %s", host, instance.Spec.NetworkAttachments[0], err))
+		} else {
+			host = swift.FormatHost(ip)
+		}
+	}
+	return host
+}
+
+// storageIP returns the IP of the pod on the first NetworkAttachment, which
+// is used for the storage traffic
+func storageIP(ctx context.Context, h *helper.Helper, instance *swiftv1beta1.SwiftStorage, podName string) (string, error) {
+	pod := &corev1.Pod{}
+	err := h.GetClient().Get(ctx, types.NamespacedName{Name: podName, Namespace: instance.Namespace}, pod)
+	if err != nil {
+		return "", err
+	}
+	networkStatus, err := networkattachment.GetNetworkStatusFromAnnotation(pod.Annotations)
+	if err != nil {
+		return "", err
+	}
+	netAtt := fmt.Sprintf("%s/%s", instance.Namespace, instance.Spec.NetworkAttachments[0])
+	for _, status := range networkStatus {
+		if status.Name == netAtt && len(status.IPs) > 0 {
+			return status.IPs[0], nil
+		}
 	}
-	return devices.String()
+	return "", fmt.Errorf("no IP found on %s", netAtt)
+}
+
+// Ports returns the ports of the storage services, unset ports use the
+// Swift defaults
+func Ports(instance *swiftv1beta1.SwiftStorage) swiftv1beta1.SwiftStoragePorts {
+	ports := instance.Spec.Ports
+	if ports.AccountServer == 0 {
+		ports.AccountServer = swift.AccountServerPort
+	}
+	if ports.ContainerServer == 0 {
+		ports.ContainerServer = swift.ContainerServerPort
+	}
+	if ports.ObjectServer == 0 {
+		ports.ObjectServer = swift.ObjectServerPort
+	}
+	if ports.Rsync == 0 {
+		ports.Rsync = swift.RsyncPort
+	}
+	if ports.Memcached == 0 {
+		ports.Memcached = swift.MemcachedPort
+	}
+	if ports.AccountReplication == 0 {
+		ports.AccountReplication = swift.AccountReplicationPort
+	}
+	if ports.ContainerReplication == 0 {
+		ports.ContainerReplication = swift.ContainerReplicationPort
+	}
+	if ports.ObjectReplication == 0 {
+		ports.ObjectReplication = swift.ObjectReplicationPort
+	}
+	return ports
+}
+
+// ReplicationPorts returns the ports used for replication in the rings.
+// These are the server ports unless dedicated replication servers are used
+func ReplicationPorts(instance *swiftv1beta1.SwiftStorage) (int32, int32, int32) {
+	ports := Ports(instance)
+	if !instance.Spec.ReplicationServers {
+		return ports.AccountServer, ports.ContainerServer, ports.ObjectServer
+	}
+	return ports.AccountReplication, ports.ContainerReplication, ports.ObjectReplication
+}
+
+// DevicesRoot returns the parent directory of all devices
+func DevicesRoot(instance *swiftv1beta1.SwiftStorage) string {
+	if instance.Spec.DevicesRoot == "" {
+		return swift.DevicesRoot
+	}
+	return instance.Spec.DevicesRoot
+}
+
+// MountCheck returns if Swift should check that devices are mounted. All
+// devices are PVCs mounted by the kubelet, thus it's disabled by default
+func MountCheck(instance *swiftv1beta1.SwiftStorage) bool {
+	if instance.Spec.MountCheck == nil {
+		return false
+	}
+	return *instance.Spec.MountCheck
 }
 
 func Labels() map[string]string {
 	return map[string]string{"app.kubernetes.io/name": "SwiftStorage"}
 }
+
+// NetworksAnnotation returns the annotation attaching a pod to the
+// NetworkAttachments of all SwiftStorage instances of the namespace. Pods
+// connecting to the ring devices themselves, eg. the ring verification Job,
+// need it to reach the IPs in the rings
+func NetworksAnnotation(ctx context.Context, h *helper.Helper, namespace string) (map[string]string, error) {
+	storages := &swiftv1beta1.SwiftStorageList{}
+	if err := h.GetClient().List(ctx, storages, client.InNamespace(namespace)); err != nil {
+		return nil, err
+	}
+	attached := map[string]bool{}
+	networks := []string{}
+	for _, storage := range storages.Items {
+		for _, netAtt := range storage.Spec.NetworkAttachments {
+			if !attached[netAtt] {
+				attached[netAtt] = true
+				networks = append(networks, netAtt)
+			}
+		}
+	}
+	sort.Strings(networks)
+	return networkattachment.CreateNetworksAnnotation(namespace, networks)
+}
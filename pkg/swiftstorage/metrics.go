@@ -0,0 +1,86 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package swiftstorage
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	swiftv1beta1 "github.com/openstack-k8s-operators/swift-operator/api/v1beta1"
+)
+
+// Label names must not clash with the target labels of the operator pod
+// itself, eg. namespace and pod
+var (
+	diskUsageMetric = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "swift_storage_disk_usage_percent",
+			Help: "Usage of a Swift storage device in percent",
+		},
+		[]string{"swiftstorage_namespace", "swiftstorage", "storage_pod", "device", "policy"},
+	)
+	asyncPendingMetric = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "swift_storage_async_pending",
+			Help: "Number of async pending container updates of a Swift storage pod",
+		},
+		[]string{"swiftstorage_namespace", "swiftstorage", "storage_pod", "policy"},
+	)
+	replicationAgeMetric = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "swift_storage_replication_age_seconds",
+			Help: "Seconds since the last replication pass of a Swift storage pod",
+		},
+		[]string{"swiftstorage_namespace", "swiftstorage", "storage_pod", "service", "policy"},
+	)
+)
+
+func init() {
+	metrics.Registry.MustRegister(diskUsageMetric, asyncPendingMetric, replicationAgeMetric)
+}
+
+// UpdateMetrics replaces all metrics of the instance with the given recon
+// data. The object series are labeled with every given policy stored on the
+// devices of the pod
+func UpdateMetrics(instance *swiftv1beta1.SwiftStorage, stats []ReconStats, policies []string) {
+	DeleteMetrics(instance)
+
+	for _, podStats := range stats {
+		for _, policy := range policies {
+			for device, usage := range podStats.DiskUsage {
+				diskUsageMetric.WithLabelValues(instance.Namespace, instance.Name, podStats.Pod, device, policy).Set(usage)
+			}
+			asyncPendingMetric.WithLabelValues(instance.Namespace, instance.Name, podStats.Pod, policy).Set(float64(podStats.AsyncPending))
+			if age, ok := podStats.ReplicationAge["object"]; ok {
+				replicationAgeMetric.WithLabelValues(instance.Namespace, instance.Name, podStats.Pod, "object", policy).Set(age)
+			}
+		}
+		for service, age := range podStats.ReplicationAge {
+			if service != "object" {
+				replicationAgeMetric.WithLabelValues(instance.Namespace, instance.Name, podStats.Pod, service, "").Set(age)
+			}
+		}
+	}
+}
+
+// DeleteMetrics removes all metrics of the instance
+func DeleteMetrics(instance *swiftv1beta1.SwiftStorage) {
+	labels := prometheus.Labels{"swiftstorage_namespace": instance.Namespace, "swiftstorage": instance.Name}
+	diskUsageMetric.DeletePartialMatch(labels)
+	asyncPendingMetric.DeletePartialMatch(labels)
+	replicationAgeMetric.DeletePartialMatch(labels)
+}
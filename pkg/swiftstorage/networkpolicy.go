@@ -32,6 +32,7 @@ import (
 	swiftv1beta1 "github.com/openstack-k8s-operators/swift-operator/api/v1beta1"
 	"github.com/openstack-k8s-operators/swift-operator/pkg/swift"
 	"github.com/openstack-k8s-operators/swift-operator/pkg/swiftproxy"
+	"github.com/openstack-k8s-operators/swift-operator/pkg/swiftring"
 )
 
 //+kubebuilder:rbac:groups=networking.k8s.io,resources=networkpolicies,verbs=get;list;watch;create;update;patch;delete
@@ -39,14 +40,141 @@ import (
 func NetworkPolicy(
 	instance *swiftv1beta1.SwiftStorage) *networkingv1.NetworkPolicy {
 
-	portAccountServer := intstr.FromInt(int(swift.AccountServerPort))
-	portContainerServer := intstr.FromInt(int(swift.ContainerServerPort))
-	portObjectServer := intstr.FromInt(int(swift.ObjectServerPort))
-	portRsync := intstr.FromInt(int(swift.RsyncPort))
+	ports := Ports(instance)
+	portAccountServer := intstr.FromInt(int(ports.AccountServer))
+	portContainerServer := intstr.FromInt(int(ports.ContainerServer))
+	portObjectServer := intstr.FromInt(int(ports.ObjectServer))
+	portRsync := intstr.FromInt(int(ports.Rsync))
 
 	storageLabels := Labels()
 	proxyLabels := swiftproxy.Labels()
 
+	ingress := []networkingv1.NetworkPolicyIngressRule{
+		{
+			Ports: []networkingv1.NetworkPolicyPort{
+				{
+					Port: &portAccountServer,
+				},
+				{
+					Port: &portContainerServer,
+				},
+				{
+					Port: &portObjectServer,
+				},
+				{
+					Port: &portRsync,
+				},
+			},
+			From: []networkingv1.NetworkPolicyPeer{
+				{
+					PodSelector: &metav1.LabelSelector{
+						MatchLabels: storageLabels,
+					},
+				},
+			},
+		},
+		{
+			Ports: []networkingv1.NetworkPolicyPort{
+				{
+					Port: &portAccountServer,
+				},
+				{
+					Port: &portContainerServer,
+				},
+				{
+					Port: &portObjectServer,
+				},
+			},
+			From: []networkingv1.NetworkPolicyPeer{
+				{
+					PodSelector: &metav1.LabelSelector{
+						MatchLabels: proxyLabels,
+					},
+				},
+			},
+		},
+	}
+
+	// Replication between the storage pods uses the dedicated servers
+	if instance.Spec.ReplicationServers {
+		portAccountReplication := intstr.FromInt(int(ports.AccountReplication))
+		portContainerReplication := intstr.FromInt(int(ports.ContainerReplication))
+		portObjectReplication := intstr.FromInt(int(ports.ObjectReplication))
+		ingress[0].Ports = append(ingress[0].Ports,
+			networkingv1.NetworkPolicyPort{
+				Port: &portAccountReplication,
+			},
+			networkingv1.NetworkPolicyPort{
+				Port: &portContainerReplication,
+			},
+			networkingv1.NetworkPolicyPort{
+				Port: &portObjectReplication,
+			},
+		)
+	}
+
+	// The ring verification Job lists the partitions on the devices
+	ingress = append(ingress, networkingv1.NetworkPolicyIngressRule{
+		Ports: []networkingv1.NetworkPolicyPort{
+			{
+				Port: &portRsync,
+			},
+		},
+		From: []networkingv1.NetworkPolicyPeer{
+			{
+				PodSelector: &metav1.LabelSelector{
+					MatchLabels: swiftring.Labels(),
+				},
+			},
+		},
+	})
+
+	// The dispersion report Job queries the devices directly
+	ingress = append(ingress, networkingv1.NetworkPolicyIngressRule{
+		Ports: []networkingv1.NetworkPolicyPort{
+			{
+				Port: &portAccountServer,
+			},
+			{
+				Port: &portContainerServer,
+			},
+			{
+				Port: &portObjectServer,
+			},
+		},
+		From: []networkingv1.NetworkPolicyPeer{
+			{
+				PodSelector: &metav1.LabelSelector{
+					MatchLabels: swift.DispersionLabels(),
+				},
+			},
+		},
+	})
+
+	// The operator itself queries the recon data of the object servers. It
+	// is only admitted from its own namespace, or the namespace of the
+	// storage pods if that is unknown
+	if instance.Spec.Alerts.Enabled {
+		operator := networkingv1.NetworkPolicyPeer{
+			PodSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"control-plane": "controller-manager"},
+			},
+		}
+		if namespace := swiftv1beta1.OperatorNamespace(); namespace != "" {
+			operator.NamespaceSelector = &metav1.LabelSelector{
+				MatchLabels: map[string]string{"kubernetes.io/metadata.name": namespace},
+			}
+		}
+		ingress = append(ingress, networkingv1.NetworkPolicyIngressRule{
+			Ports: []networkingv1.NetworkPolicyPort{
+				{
+					Port: &portObjectServer,
+				},
+			},
+			From: []networkingv1.NetworkPolicyPeer{operator},
+		})
+	}
+
 	return &networkingv1.NetworkPolicy{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      "np-" + instance.Name,
@@ -56,51 +184,7 @@ func NetworkPolicy(
 			PodSelector: metav1.LabelSelector{
 				MatchLabels: storageLabels,
 			},
-			Ingress: []networkingv1.NetworkPolicyIngressRule{
-				{
-					Ports: []networkingv1.NetworkPolicyPort{
-						{
-							Port: &portAccountServer,
-						},
-						{
-							Port: &portContainerServer,
-						},
-						{
-							Port: &portObjectServer,
-						},
-						{
-							Port: &portRsync,
-						},
-					},
-					From: []networkingv1.NetworkPolicyPeer{
-						{
-							PodSelector: &metav1.LabelSelector{
-								MatchLabels: storageLabels,
-							},
-						},
-					},
-				},
-				{
-					Ports: []networkingv1.NetworkPolicyPort{
-						{
-							Port: &portAccountServer,
-						},
-						{
-							Port: &portContainerServer,
-						},
-						{
-							Port: &portObjectServer,
-						},
-					},
-					From: []networkingv1.NetworkPolicyPeer{
-						{
-							PodSelector: &metav1.LabelSelector{
-								MatchLabels: proxyLabels,
-							},
-						},
-					},
-				},
-			},
+			Ingress: ingress,
 		},
 	}
 }
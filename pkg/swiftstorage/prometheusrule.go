@@ -0,0 +1,141 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package swiftstorage
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+
+	swiftv1beta1 "github.com/openstack-k8s-operators/swift-operator/api/v1beta1"
+)
+
+//+kubebuilder:rbac:groups=monitoring.coreos.com,resources=prometheusrules,verbs=get;list;watch;create;update;patch;delete
+
+// The Prometheus operator API is not vendored, the PrometheusRule is
+// handled as unstructured object
+var prometheusRuleGVK = schema.GroupVersionKind{
+	Group:   "monitoring.coreos.com",
+	Version: "v1",
+	Kind:    "PrometheusRule",
+}
+
+func alertRule(alert string, expr string, description string) map[string]interface{} {
+	return map[string]interface{}{
+		"alert": alert,
+		"expr":  expr,
+		"for":   "5m",
+		"labels": map[string]interface{}{
+			"severity": "warning",
+		},
+		"annotations": map[string]interface{}{
+			"summary":     fmt.Sprintf("%s on {{ $labels.storage_pod }}", alert),
+			"description": description,
+		},
+	}
+}
+
+func prometheusRuleSpec(instance *swiftv1beta1.SwiftStorage, policies []string) map[string]interface{} {
+	alerts := instance.Spec.Alerts
+	selector := func(extra string) string {
+		return fmt.Sprintf("{swiftstorage_namespace=%q,swiftstorage=%q%s}", instance.Namespace, instance.Name, extra)
+	}
+
+	rules := []interface{}{}
+	for _, policy := range alertPolicies(alerts, policies) {
+		thresholds := AlertThresholds(alerts, "object", policy)
+		policySelector := selector(fmt.Sprintf(",policy=%q", policy))
+		rules = append(rules,
+			alertRule(
+				"SwiftStorageDiskUsageHigh",
+				fmt.Sprintf("swift_storage_disk_usage_percent%s > %d", policySelector, *thresholds.DiskUsagePercent),
+				fmt.Sprintf("Device {{ $labels.device }} is more than %d%% used", *thresholds.DiskUsagePercent)),
+			alertRule(
+				"SwiftStorageAsyncPendingHigh",
+				fmt.Sprintf("swift_storage_async_pending%s > %d", policySelector, *thresholds.AsyncPending),
+				fmt.Sprintf("More than %d async pending container updates", *thresholds.AsyncPending)),
+			alertRule(
+				"SwiftStorageReplicationStale",
+				fmt.Sprintf("swift_storage_replication_age_seconds%s > %d", selector(fmt.Sprintf(",service=\"object\",policy=%q", policy)), *thresholds.ReplicationAgeSeconds),
+				fmt.Sprintf("Last object replication pass more than %ds ago", *thresholds.ReplicationAgeSeconds)),
+		)
+	}
+	for _, service := range []string{"account", "container"} {
+		thresholds := AlertThresholds(alerts, service, "")
+		rules = append(rules,
+			alertRule(
+				"SwiftStorageReplicationStale",
+				fmt.Sprintf("swift_storage_replication_age_seconds%s > %d", selector(fmt.Sprintf(",service=%q", service)), *thresholds.ReplicationAgeSeconds),
+				fmt.Sprintf("Last %s replication pass more than %ds ago", service, *thresholds.ReplicationAgeSeconds)),
+		)
+	}
+
+	return map[string]interface{}{
+		"groups": []interface{}{
+			map[string]interface{}{
+				"name":  fmt.Sprintf("swift-%s", instance.Name),
+				"rules": rules,
+			},
+		},
+	}
+}
+
+// EnsurePrometheusRule creates or updates the PrometheusRule of the instance
+// with rules for the given policies of the devices
+func EnsurePrometheusRule(ctx context.Context, h *helper.Helper, instance *swiftv1beta1.SwiftStorage, labels map[string]string, policies []string) error {
+	rule := &unstructured.Unstructured{}
+	rule.SetGroupVersionKind(prometheusRuleGVK)
+	rule.SetName(instance.Name + "-alerts")
+	rule.SetNamespace(instance.Namespace)
+
+	op, err := controllerutil.CreateOrPatch(ctx, h.GetClient(), rule, func() error {
+		rule.SetLabels(labels)
+		rule.Object["spec"] = prometheusRuleSpec(instance, policies)
+		return controllerutil.SetControllerReference(h.GetBeforeObject(), rule, h.GetScheme())
+	})
+	if err != nil {
+		h.GetLogger().Error(err, "Error creating PrometheusRule")
+		return err
+	}
+	if op != controllerutil.OperationResultNone {
+		h.GetLogger().Info(fmt.Sprintf("PrometheusRule %s - %s", rule.GetName(), op))
+	}
+	return nil
+}
+
+// DeletePrometheusRule removes the PrometheusRule of the instance if it
+// exists. It's not an error if the Prometheus operator is not installed
+func DeletePrometheusRule(ctx context.Context, h *helper.Helper, instance *swiftv1beta1.SwiftStorage) error {
+	rule := &unstructured.Unstructured{}
+	rule.SetGroupVersionKind(prometheusRuleGVK)
+	rule.SetName(instance.Name + "-alerts")
+	rule.SetNamespace(instance.Namespace)
+
+	err := h.GetClient().Delete(ctx, rule, client.PropagationPolicy("Background"))
+	if err != nil && !apierrors.IsNotFound(err) && !meta.IsNoMatchError(err) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package swiftstorage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	swiftv1beta1 "github.com/openstack-k8s-operators/swift-operator/api/v1beta1"
+)
+
+// ReconStats contains the recon data of a single storage pod
+type ReconStats struct {
+	Pod string
+	// Usage in percent per device
+	DiskUsage map[string]float64
+	// Number of async pending container updates
+	AsyncPending int64
+	// Seconds since the last replication pass per service. Services that
+	// did not finish a replication pass yet are missing
+	ReplicationAge map[string]float64
+}
+
+type reconDiskUsage struct {
+	Device  string  `json:"device"`
+	Mounted bool    `json:"mounted"`
+	Size    float64 `json:"size"`
+	Used    float64 `json:"used"`
+}
+
+type reconAsync struct {
+	AsyncPending int64 `json:"async_pending"`
+}
+
+type reconReplication struct {
+	ReplicationLast       *float64 `json:"replication_last"`
+	ObjectReplicationLast *float64 `json:"object_replication_last"`
+}
+
+func getRecon(ctx context.Context, client *http.Client, host string, port int32, path string, data interface{}) error {
+	url := fmt.Sprintf("http://%s:%d/recon/%s", host, port, path)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s returned %s", url, resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(data)
+}
+
+// GetReconStats queries the recon middleware of all storage pods. The recon
+// cache is shared between all containers of a pod, thus all data can be
+// fetched from the object server
+func GetReconStats(ctx context.Context, instance *swiftv1beta1.SwiftStorage) ([]ReconStats, error) {
+	client := &http.Client{Timeout: 5 * time.Second}
+	now := float64(time.Now().Unix())
+	port := Ports(instance).ObjectServer
+
+	stats := []ReconStats{}
+	start := OrdinalStart(instance)
+	for replica := start; replica < start+int(*instance.Spec.Replicas); replica++ {
+		pod := fmt.Sprintf("%s-%d", instance.Name, replica)
+		host := fmt.Sprintf("%s.%s.%s.svc", pod, instance.Name, instance.Namespace)
+		podStats := ReconStats{
+			Pod:            pod,
+			DiskUsage:      map[string]float64{},
+			ReplicationAge: map[string]float64{},
+		}
+
+		diskUsage := []reconDiskUsage{}
+		if err := getRecon(ctx, client, host, port, "diskusage", &diskUsage); err != nil {
+			return nil, err
+		}
+		for _, d := range diskUsage {
+			if d.Mounted && d.Size > 0 {
+				podStats.DiskUsage[d.Device] = 100 * d.Used / d.Size
+			}
+		}
+
+		async := reconAsync{}
+		if err := getRecon(ctx, client, host, port, "async", &async); err != nil {
+			return nil, err
+		}
+		podStats.AsyncPending = async.AsyncPending
+
+		for _, service := range []string{"account", "container", "object"} {
+			replication := reconReplication{}
+			if err := getRecon(ctx, client, host, port, "replication/"+service, &replication); err != nil {
+				return nil, err
+			}
+			last := replication.ReplicationLast
+			if last == nil {
+				last = replication.ObjectReplicationLast
+			}
+			if last != nil {
+				podStats.ReplicationAge[service] = now - *last
+			}
+		}
+
+		stats = append(stats, podStats)
+	}
+	return stats, nil
+}
+
+// ReplicationFinishedSince returns if the replicators of the service
+// finished a replication pass after the given time on all storage pods
+func ReplicationFinishedSince(stats []ReconStats, service string, since time.Time) bool {
+	for _, podStats := range stats {
+		age, ok := podStats.ReplicationAge[service]
+		if !ok || time.Since(since).Seconds() <= age {
+			return false
+		}
+	}
+	return true
+}
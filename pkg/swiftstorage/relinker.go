@@ -0,0 +1,144 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package swiftstorage
+
+import (
+	"fmt"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/env"
+
+	swiftv1beta1 "github.com/openstack-k8s-operators/swift-operator/api/v1beta1"
+	"github.com/openstack-k8s-operators/swift-operator/pkg/swift"
+)
+
+// RelinkerJobName returns the name of the relinker Job of the storage pod
+func RelinkerJobName(podName string, mode string) string {
+	return fmt.Sprintf("%s-%s", podName, mode)
+}
+
+// RelinkerJob returns the Job running swift-object-relinker in the given
+// mode, relink or cleanup, on the device of the storage pod. The Job runs on
+// the node of the pod and mounts the same PVC, thus it must not use the
+// ReadWriteOncePod access mode. The target part power is part of the pod
+// template, thus the Job runs again for the next increase
+func RelinkerJob(instance *swiftv1beta1.SwiftStorage, pod *corev1.Pod, mode string, policy int32, partPower int32, labels map[string]string) (*batchv1.Job, error) {
+	claimName := ""
+	for _, volume := range pod.Spec.Volumes {
+		if volume.Name == swift.ClaimName && volume.PersistentVolumeClaim != nil {
+			claimName = volume.PersistentVolumeClaim.ClaimName
+		}
+	}
+	if claimName == "" {
+		return nil, fmt.Errorf("storage pod %s has no %s volume", pod.Name, swift.ClaimName)
+	}
+
+	securityContext := swift.GetSecurityContext()
+	backoffLimit := int32(2)
+	devicesRoot := DevicesRoot(instance)
+
+	envVars := map[string]env.Setter{}
+	envVars["PART_POWER"] = env.SetValue(fmt.Sprint(partPower))
+
+	command := fmt.Sprintf("swift-object-relinker %s --swift-dir /etc/swift --devices %s --skip-mount-check --policy %d", mode, devicesRoot, policy)
+
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      RelinkerJobName(pod.Name, mode),
+			Namespace: instance.Namespace,
+			Labels:    labels,
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: &backoffLimit,
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					RestartPolicy:      corev1.RestartPolicyNever,
+					ServiceAccountName: swift.ServiceAccount,
+					ImagePullSecrets:   instance.Spec.ImagePullSecrets,
+					// The PVC is already attached to the node of the pod
+					NodeName:        pod.Spec.NodeName,
+					Tolerations:     pod.Spec.Tolerations,
+					SecurityContext: pod.Spec.SecurityContext,
+					Containers: []corev1.Container{
+						{
+							Name:            "object-" + mode,
+							Image:           instance.Spec.ContainerImageObject,
+							ImagePullPolicy: corev1.PullIfNotPresent,
+							SecurityContext: &securityContext,
+							Env:             env.MergeEnvs([]corev1.EnvVar{}, envVars),
+							Command: []string{
+								"/bin/sh", "-c",
+								"cp -t /etc/swift/ /var/lib/config-data/swiftconf/* && " +
+									"tar -xzf /var/lib/config-data/rings/swiftrings.tar.gz -C /etc/swift/ && " +
+									"{ [ ! -e /etc/swift/storage-policies.conf ] || cat /etc/swift/storage-policies.conf >> /etc/swift/swift.conf; } && " +
+									command,
+							},
+							VolumeMounts: []corev1.VolumeMount{
+								{
+									Name:      swift.ClaimName,
+									MountPath: devicesRoot + "/d1",
+								},
+								{
+									Name:      "swiftconf",
+									MountPath: "/var/lib/config-data/swiftconf",
+									ReadOnly:  true,
+								},
+								{
+									Name:      "ring-data",
+									MountPath: "/var/lib/config-data/rings",
+									ReadOnly:  true,
+								},
+								{
+									Name:      "etc-swift",
+									MountPath: "/etc/swift",
+								},
+							},
+						},
+					},
+					Volumes: []corev1.Volume{
+						{
+							Name: swift.ClaimName,
+							VolumeSource: corev1.VolumeSource{
+								PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+									ClaimName: claimName,
+								},
+							},
+						},
+						{
+							Name: "swiftconf",
+							VolumeSource: corev1.VolumeSource{
+								Secret: &corev1.SecretVolumeSource{
+									SecretName: instance.Spec.SwiftConfSecret,
+								},
+							},
+						},
+						swift.RingDataVolume(instance.Spec.RingDistribution, false),
+						{
+							Name: "etc-swift",
+							VolumeSource: corev1.VolumeSource{
+								EmptyDir: &corev1.EmptyDirVolumeSource{},
+							},
+						},
+					},
+				},
+			},
+		},
+	}, nil
+}
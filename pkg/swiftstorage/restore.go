@@ -0,0 +1,201 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package swiftstorage
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/util"
+
+	swiftv1beta1 "github.com/openstack-k8s-operators/swift-operator/api/v1beta1"
+	"github.com/openstack-k8s-operators/swift-operator/pkg/swift"
+)
+
+//+kubebuilder:rbac:groups=core,resources=persistentvolumeclaims,verbs=get;list;watch;create;update;patch
+//+kubebuilder:rbac:groups=core,resources=persistentvolumes,verbs=get;list;watch;update;patch
+
+// findRestoreVolume returns the PersistentVolume that was bound to the claim
+// with the given name, or nil if there is none
+func findRestoreVolume(ctx context.Context, h *helper.Helper, namespace string, claimName string) (*corev1.PersistentVolume, error) {
+	volumes := &corev1.PersistentVolumeList{}
+	if err := h.GetClient().List(ctx, volumes); err != nil {
+		return nil, err
+	}
+	for i := range volumes.Items {
+		ref := volumes.Items[i].Spec.ClaimRef
+		if ref != nil && ref.Namespace == namespace && ref.Name == claimName {
+			return &volumes.Items[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// prebindVolume reserves the volume for the given claim. Volumes that are
+// still bound to an existing claim are not changed
+func prebindVolume(ctx context.Context, h *helper.Helper, volume *corev1.PersistentVolume, namespace string, claimName string) (bool, error) {
+	ref := volume.Spec.ClaimRef
+	if ref != nil && ref.Namespace == namespace && ref.Name == claimName {
+		return true, nil
+	}
+	if ref != nil && volume.Status.Phase == corev1.VolumeBound {
+		return false, nil
+	}
+
+	patch := client.MergeFrom(volume.DeepCopy())
+	volume.Spec.ClaimRef = &corev1.ObjectReference{
+		Kind:       "PersistentVolumeClaim",
+		APIVersion: "v1",
+		Namespace:  namespace,
+		Name:       claimName,
+	}
+	if err := h.GetClient().Patch(ctx, volume, patch); err != nil {
+		return false, err
+	}
+	h.GetLogger().Info(fmt.Sprintf("PersistentVolume %s reserved for PVC %s", volume.Name, claimName))
+	return true, nil
+}
+
+// EnsureRestoreClaims creates the PVCs of the StatefulSet ordinals listed in
+// RestoreClaims before the StatefulSet does, so that the existing volumes
+// are used instead of new ones. The PVC names match the volumeClaimTemplate
+// of the StatefulSet, thus the ring devices are unchanged
+func EnsureRestoreClaims(ctx context.Context, h *helper.Helper, instance *swiftv1beta1.SwiftStorage, labels map[string]string) (ctrl.Result, error) {
+	for _, restoreClaim := range instance.Spec.RestoreClaims {
+		start := int32(OrdinalStart(instance))
+		if restoreClaim.Ordinal < start || restoreClaim.Ordinal >= start+*instance.Spec.Replicas {
+			continue
+		}
+		name := fmt.Sprintf("%s-%s-%d", swift.ClaimName, instance.Name, restoreClaim.Ordinal)
+
+		claim := &corev1.PersistentVolumeClaim{}
+		err := h.GetClient().Get(ctx, types.NamespacedName{Name: name, Namespace: instance.Namespace}, claim)
+		if err == nil {
+			continue
+		} else if !apierrors.IsNotFound(err) {
+			return ctrl.Result{}, err
+		}
+
+		claim = &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: instance.Namespace,
+				Labels:    labels,
+			},
+			Spec: corev1.PersistentVolumeClaimSpec{
+				StorageClassName: &instance.Spec.StorageClass,
+				AccessModes: []corev1.PersistentVolumeAccessMode{
+					corev1.ReadWriteOnce,
+				},
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceStorage: resource.MustParse(instance.Spec.StorageRequest),
+					},
+				},
+			},
+		}
+
+		var volume *corev1.PersistentVolume
+		switch {
+		case restoreClaim.ClaimName != "":
+			volume, err = findRestoreVolume(ctx, h, instance.Namespace, restoreClaim.ClaimName)
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+			if volume == nil {
+				return ctrl.Result{}, fmt.Errorf("no PersistentVolume found that was bound to PVC %s", restoreClaim.ClaimName)
+			}
+		case restoreClaim.VolumeName != "":
+			volume = &corev1.PersistentVolume{}
+			err = h.GetClient().Get(ctx, types.NamespacedName{Name: restoreClaim.VolumeName}, volume)
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+		case restoreClaim.Selector != nil:
+			claim.Spec.Selector = restoreClaim.Selector
+		default:
+			return ctrl.Result{}, fmt.Errorf("restore claim for ordinal %d requires claimName, volumeName or selector", restoreClaim.Ordinal)
+		}
+
+		if volume != nil {
+			reserved, err := prebindVolume(ctx, h, volume, instance.Namespace, name)
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+			if !reserved {
+				h.GetLogger().Info(fmt.Sprintf("PersistentVolume %s still bound, waiting to adopt it for PVC %s", volume.Name, name))
+				return ctrl.Result{RequeueAfter: 10 * time.Second}, nil
+			}
+			// The claim must match the volume to bind it
+			claim.Spec.VolumeName = volume.Name
+			claim.Spec.StorageClassName = &volume.Spec.StorageClassName
+			claim.Spec.Resources.Requests[corev1.ResourceStorage] = volume.Spec.Capacity[corev1.ResourceStorage]
+		}
+
+		if err := h.GetClient().Create(ctx, claim); err != nil {
+			return ctrl.Result{}, err
+		}
+		h.GetLogger().Info(fmt.Sprintf("PVC %s created to restore ordinal %d", name, restoreClaim.Ordinal))
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// EnsureClaimMetadata adds the extra PVC labels and annotations to the PVCs
+// of the StatefulSet. The volumeClaimTemplates of an existing StatefulSet
+// can't be changed, thus the PVCs are patched directly
+func EnsureClaimMetadata(ctx context.Context, h *helper.Helper, instance *swiftv1beta1.SwiftStorage) error {
+	extra := instance.Spec.ExtraMetadata.PersistentVolumeClaim
+	if len(extra.Labels) == 0 && len(extra.Annotations) == 0 {
+		return nil
+	}
+
+	start := OrdinalStart(instance)
+	for replica := start; replica < start+int(*instance.Spec.Replicas); replica++ {
+		name := fmt.Sprintf("%s-%s-%d", swift.ClaimName, instance.Name, replica)
+		claim := &corev1.PersistentVolumeClaim{}
+		err := h.GetClient().Get(ctx, types.NamespacedName{Name: name, Namespace: instance.Namespace}, claim)
+		if apierrors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return err
+		}
+
+		original := claim.DeepCopy()
+		claim.Labels = util.MergeStringMaps(claim.Labels, extra.Labels)
+		claim.Annotations = util.MergeStringMaps(claim.Annotations, extra.Annotations)
+		if equality.Semantic.DeepEqual(original.ObjectMeta, claim.ObjectMeta) {
+			continue
+		}
+		patch := client.MergeFrom(original)
+		if err := h.GetClient().Patch(ctx, claim, patch); err != nil {
+			return err
+		}
+	}
+	return nil
+}
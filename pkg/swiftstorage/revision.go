@@ -0,0 +1,165 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package swiftstorage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+
+	swiftv1beta1 "github.com/openstack-k8s-operators/swift-operator/api/v1beta1"
+)
+
+//+kubebuilder:rbac:groups=apps,resources=controllerrevisions,verbs=get;list;watch
+
+// revisionConfigLabel marks the copies of the config-data ConfigMap that
+// belong to a StatefulSet revision
+const revisionConfigLabel = "swift.openstack.org/revision-config"
+
+// ConfigMapName returns the name of the config-data ConfigMap
+func ConfigMapName(instance *swiftv1beta1.SwiftStorage) string {
+	return instance.Name + "-config-data"
+}
+
+// revisionConfigName returns the name of the copy of the config-data
+// ConfigMap used by the given ControllerRevision
+func revisionConfigName(revisionName string) string {
+	return revisionName + "-config"
+}
+
+// GetRollbackRevision returns the ControllerRevision of the StatefulSet with
+// the revision number given by rollbackToRevision. The StatefulSet controller
+// renumbers a revision once it is used again, thus an applied rollback is
+// looked up by name
+func GetRollbackRevision(ctx context.Context, h *helper.Helper, instance *swiftv1beta1.SwiftStorage) (*appsv1.ControllerRevision, error) {
+	if rollback := instance.Status.Rollback; rollback != nil && rollback.Revision == *instance.Spec.RollbackToRevision {
+		revision := &appsv1.ControllerRevision{}
+		err := h.GetClient().Get(ctx, types.NamespacedName{Name: rollback.ControllerRevision, Namespace: instance.Namespace}, revision)
+		return revision, err
+	}
+
+	revisions := &appsv1.ControllerRevisionList{}
+	err := h.GetClient().List(ctx, revisions, client.InNamespace(instance.Namespace), client.MatchingLabels(Labels()))
+	if err != nil {
+		return nil, err
+	}
+	for i := range revisions.Items {
+		revision := &revisions.Items[i]
+		owner := metav1.GetControllerOf(revision)
+		if owner == nil || owner.Kind != "StatefulSet" || owner.Name != instance.Name {
+			continue
+		}
+		if revision.Revision == *instance.Spec.RollbackToRevision {
+			return revision, nil
+		}
+	}
+	return nil, fmt.Errorf("StatefulSet %s has no revision %d", instance.Name, *instance.Spec.RollbackToRevision)
+}
+
+// RevisionTemplate returns the pod template stored in the ControllerRevision
+func RevisionTemplate(revision *appsv1.ControllerRevision) (*corev1.PodTemplateSpec, error) {
+	patch := struct {
+		Spec struct {
+			Template corev1.PodTemplateSpec `json:"template"`
+		} `json:"spec"`
+	}{}
+	if err := json.Unmarshal(revision.Data.Raw, &patch); err != nil {
+		return nil, fmt.Errorf("invalid ControllerRevision %s: %w", revision.Name, err)
+	}
+	return &patch.Spec.Template, nil
+}
+
+// SaveRevisionConfig stores a copy of the config-data ConfigMap for the
+// given ControllerRevision. The configuration is not part of the pod
+// template, thus the copy is updated as long as the revision is current
+func SaveRevisionConfig(ctx context.Context, h *helper.Helper, instance *swiftv1beta1.SwiftStorage, revisionName string) error {
+	config := &corev1.ConfigMap{}
+	err := h.GetClient().Get(ctx, types.NamespacedName{Name: ConfigMapName(instance), Namespace: instance.Namespace}, config)
+	if err != nil {
+		return err
+	}
+
+	saved := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      revisionConfigName(revisionName),
+			Namespace: instance.Namespace,
+		},
+	}
+	_, err = controllerutil.CreateOrPatch(ctx, h.GetClient(), saved, func() error {
+		saved.Labels = map[string]string{revisionConfigLabel: instance.Name}
+		saved.Data = config.Data
+		saved.BinaryData = config.BinaryData
+		return controllerutil.SetControllerReference(h.GetBeforeObject(), saved, h.GetScheme())
+	})
+	return err
+}
+
+// RestoreRevisionConfig replaces the content of the config-data ConfigMap
+// with the copy stored for the given ControllerRevision
+func RestoreRevisionConfig(ctx context.Context, h *helper.Helper, instance *swiftv1beta1.SwiftStorage, revisionName string) error {
+	saved := &corev1.ConfigMap{}
+	err := h.GetClient().Get(ctx, types.NamespacedName{Name: revisionConfigName(revisionName), Namespace: instance.Namespace}, saved)
+	if err != nil {
+		return fmt.Errorf("configuration of revision %s not found: %w", revisionName, err)
+	}
+
+	config := &corev1.ConfigMap{}
+	err = h.GetClient().Get(ctx, types.NamespacedName{Name: ConfigMapName(instance), Namespace: instance.Namespace}, config)
+	if err != nil {
+		return err
+	}
+	patch := client.MergeFrom(config.DeepCopy())
+	config.Data = saved.Data
+	config.BinaryData = saved.BinaryData
+	return h.GetClient().Patch(ctx, config, patch)
+}
+
+// PruneRevisionConfigs deletes the configuration copies of revisions that
+// were removed from the StatefulSet history
+func PruneRevisionConfigs(ctx context.Context, h *helper.Helper, instance *swiftv1beta1.SwiftStorage) error {
+	configs := &corev1.ConfigMapList{}
+	err := h.GetClient().List(ctx, configs, client.InNamespace(instance.Namespace), client.MatchingLabels{revisionConfigLabel: instance.Name})
+	if err != nil {
+		return err
+	}
+	for i := range configs.Items {
+		revisionName := strings.TrimSuffix(configs.Items[i].Name, "-config")
+		err := h.GetClient().Get(ctx, types.NamespacedName{Name: revisionName, Namespace: instance.Namespace}, &appsv1.ControllerRevision{})
+		if !apierrors.IsNotFound(err) {
+			if err != nil {
+				return err
+			}
+			continue
+		}
+		if err := h.GetClient().Delete(ctx, &configs.Items[i]); err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+		h.GetLogger().Info(fmt.Sprintf("Configuration of revision %s deleted", revisionName))
+	}
+	return nil
+}
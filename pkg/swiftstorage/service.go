@@ -20,8 +20,9 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	"github.com/openstack-k8s-operators/lib-common/modules/common/util"
+
 	swiftv1beta1 "github.com/openstack-k8s-operators/swift-operator/api/v1beta1"
-	"github.com/openstack-k8s-operators/swift-operator/pkg/swift"
 )
 
 /*
@@ -49,7 +50,6 @@ import (
 	"k8s.io/client-go/kubernetes"
 
 	swiftv1beta1 "github.com/openstack-k8s-operators/swift-operator/api/v1beta1"
-	"github.com/openstack-k8s-operators/swift-operator/pkg/swift"
 	"github.com/openstack-k8s-operators/swift-operator/pkg/swiftproxy"
 	"github.com/openstack-k8s-operators/swift-operator/pkg/swiftstorage"
 
@@ -64,38 +64,67 @@ func Service(
 	instance *swiftv1beta1.SwiftStorage) *corev1.Service {
 
 	storageLabels := Labels()
+	ports := Ports(instance)
+	extra := instance.Spec.ExtraMetadata.Service
+
+	servicePorts := []corev1.ServicePort{
+		{
+			Name:     "account",
+			Port:     ports.AccountServer,
+			Protocol: corev1.ProtocolTCP,
+		},
+		{
+			Name:     "container",
+			Port:     ports.ContainerServer,
+			Protocol: corev1.ProtocolTCP,
+		},
+		{
+			Name:     "object",
+			Port:     ports.ObjectServer,
+			Protocol: corev1.ProtocolTCP,
+		},
+		{
+			Name:     "rsync",
+			Port:     ports.Rsync,
+			Protocol: corev1.ProtocolTCP,
+		},
+	}
+	if instance.Spec.ReplicationServers {
+		servicePorts = append(servicePorts,
+			corev1.ServicePort{
+				Name:     "account-repl",
+				Port:     ports.AccountReplication,
+				Protocol: corev1.ProtocolTCP,
+			},
+			corev1.ServicePort{
+				Name:     "container-repl",
+				Port:     ports.ContainerReplication,
+				Protocol: corev1.ProtocolTCP,
+			},
+			corev1.ServicePort{
+				Name:     "object-repl",
+				Port:     ports.ObjectReplication,
+				Protocol: corev1.ProtocolTCP,
+			},
+		)
+	}
 
 	return &corev1.Service{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      instance.Name,
-			Namespace: instance.Namespace,
-			Labels:    storageLabels,
+			Name:        instance.Name,
+			Namespace:   instance.Namespace,
+			Labels:      util.MergeStringMaps(storageLabels, extra.Labels),
+			Annotations: extra.Annotations,
 		},
 		Spec: corev1.ServiceSpec{
-			Selector: storageLabels,
-			Ports: []corev1.ServicePort{
-				{
-					Name:     "account",
-					Port:     swift.AccountServerPort,
-					Protocol: corev1.ProtocolTCP,
-				},
-				{
-					Name:     "container",
-					Port:     swift.ContainerServerPort,
-					Protocol: corev1.ProtocolTCP,
-				},
-				{
-					Name:     "object",
-					Port:     swift.ObjectServerPort,
-					Protocol: corev1.ProtocolTCP,
-				},
-				{
-					Name:     "rsync",
-					Port:     swift.RsyncPort,
-					Protocol: corev1.ProtocolTCP,
-				},
-			},
-			ClusterIP: "None", // headless service
+			Selector:       storageLabels,
+			IPFamilyPolicy: instance.Spec.IPFamilyPolicy,
+			IPFamilies:     instance.Spec.IPFamilies,
+			Ports:          servicePorts,
+			ClusterIP:      "None", // headless service
+			// The pod DNS names are used in the rings and by replication,
+			// they have to resolve before the pods are ready
+			PublishNotReadyAddresses: true,
 		},
 	}
 }
@@ -17,10 +17,23 @@ limitations under the License.
 package swiftstorage
 
 import (
+	"context"
+	"fmt"
+	"strings"
+
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/affinity"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/util"
 
 	swiftv1beta1 "github.com/openstack-k8s-operators/swift-operator/api/v1beta1"
 	"github.com/openstack-k8s-operators/swift-operator/pkg/swift"
@@ -37,14 +50,15 @@ func getPorts(port int32, name string) []corev1.ContainerPort {
 
 func getStorageContainers(swiftstorage *swiftv1beta1.SwiftStorage) []corev1.Container {
 	securityContext := swift.GetSecurityContext()
+	ports := Ports(swiftstorage)
 
-	return []corev1.Container{
+	containers := []corev1.Container{
 		{
 			Name:            "ring-sync",
 			Image:           swiftstorage.Spec.ContainerImageProxy,
 			ImagePullPolicy: corev1.PullIfNotPresent,
 			SecurityContext: &securityContext,
-			VolumeMounts:    getStorageVolumeMounts(),
+			VolumeMounts:    getStorageVolumeMounts(swiftstorage),
 			Command:         []string{"/usr/local/bin/container-scripts/ring-sync.sh"},
 		},
 		{
@@ -52,8 +66,8 @@ func getStorageContainers(swiftstorage *swiftv1beta1.SwiftStorage) []corev1.Cont
 			Image:           swiftstorage.Spec.ContainerImageAccount,
 			ImagePullPolicy: corev1.PullIfNotPresent,
 			SecurityContext: &securityContext,
-			Ports:           getPorts(swift.AccountServerPort, "account"),
-			VolumeMounts:    getStorageVolumeMounts(),
+			Ports:           getPorts(ports.AccountServer, "account"),
+			VolumeMounts:    getStorageVolumeMounts(swiftstorage),
 			Command:         []string{"/usr/bin/swift-account-server", "/etc/swift/account-server.conf", "-v"},
 		},
 		{
@@ -61,7 +75,7 @@ func getStorageContainers(swiftstorage *swiftv1beta1.SwiftStorage) []corev1.Cont
 			Image:           swiftstorage.Spec.ContainerImageAccount,
 			ImagePullPolicy: corev1.PullIfNotPresent,
 			SecurityContext: &securityContext,
-			VolumeMounts:    getStorageVolumeMounts(),
+			VolumeMounts:    getStorageVolumeMounts(swiftstorage),
 			Command:         []string{"/usr/bin/swift-account-replicator", "/etc/swift/account-server.conf", "-v"},
 		},
 		{
@@ -69,7 +83,7 @@ func getStorageContainers(swiftstorage *swiftv1beta1.SwiftStorage) []corev1.Cont
 			Image:           swiftstorage.Spec.ContainerImageAccount,
 			ImagePullPolicy: corev1.PullIfNotPresent,
 			SecurityContext: &securityContext,
-			VolumeMounts:    getStorageVolumeMounts(),
+			VolumeMounts:    getStorageVolumeMounts(swiftstorage),
 			Command:         []string{"/usr/bin/swift-account-auditor", "/etc/swift/account-server.conf", "-v"},
 		},
 		{
@@ -77,7 +91,7 @@ func getStorageContainers(swiftstorage *swiftv1beta1.SwiftStorage) []corev1.Cont
 			Image:           swiftstorage.Spec.ContainerImageAccount,
 			ImagePullPolicy: corev1.PullIfNotPresent,
 			SecurityContext: &securityContext,
-			VolumeMounts:    getStorageVolumeMounts(),
+			VolumeMounts:    getStorageVolumeMounts(swiftstorage),
 			Command:         []string{"/usr/bin/swift-account-reaper", "/etc/swift/account-server.conf", "-v"},
 		},
 		{
@@ -85,8 +99,8 @@ func getStorageContainers(swiftstorage *swiftv1beta1.SwiftStorage) []corev1.Cont
 			Image:           swiftstorage.Spec.ContainerImageContainer,
 			ImagePullPolicy: corev1.PullIfNotPresent,
 			SecurityContext: &securityContext,
-			Ports:           getPorts(swift.ContainerServerPort, "container"),
-			VolumeMounts:    getStorageVolumeMounts(),
+			Ports:           getPorts(ports.ContainerServer, "container"),
+			VolumeMounts:    getStorageVolumeMounts(swiftstorage),
 			Command:         []string{"/usr/bin/swift-container-server", "/etc/swift/container-server.conf", "-v"},
 		},
 		{
@@ -94,7 +108,7 @@ func getStorageContainers(swiftstorage *swiftv1beta1.SwiftStorage) []corev1.Cont
 			Image:           swiftstorage.Spec.ContainerImageContainer,
 			ImagePullPolicy: corev1.PullIfNotPresent,
 			SecurityContext: &securityContext,
-			VolumeMounts:    getStorageVolumeMounts(),
+			VolumeMounts:    getStorageVolumeMounts(swiftstorage),
 			Command:         []string{"/usr/bin/swift-container-replicator", "/etc/swift/container-server.conf", "-v"},
 		},
 		{
@@ -102,24 +116,24 @@ func getStorageContainers(swiftstorage *swiftv1beta1.SwiftStorage) []corev1.Cont
 			Image:           swiftstorage.Spec.ContainerImageContainer,
 			ImagePullPolicy: corev1.PullIfNotPresent,
 			SecurityContext: &securityContext,
-			VolumeMounts:    getStorageVolumeMounts(),
-			Command:         []string{"/usr/bin/swift-container-replicator", "/etc/swift/container-server.conf", "-v"},
+			VolumeMounts:    getStorageVolumeMounts(swiftstorage),
+			Command:         []string{"/usr/bin/swift-container-auditor", "/etc/swift/container-server.conf", "-v"},
 		},
 		{
 			Name:            "container-updater",
 			Image:           swiftstorage.Spec.ContainerImageContainer,
 			ImagePullPolicy: corev1.PullIfNotPresent,
 			SecurityContext: &securityContext,
-			VolumeMounts:    getStorageVolumeMounts(),
-			Command:         []string{"/usr/bin/swift-container-replicator", "/etc/swift/container-server.conf", "-v"},
+			VolumeMounts:    getStorageVolumeMounts(swiftstorage),
+			Command:         []string{"/usr/bin/swift-container-updater", "/etc/swift/container-server.conf", "-v"},
 		},
 		{
 			Name:            "object-server",
 			Image:           swiftstorage.Spec.ContainerImageObject,
 			ImagePullPolicy: corev1.PullIfNotPresent,
 			SecurityContext: &securityContext,
-			Ports:           getPorts(swift.ObjectServerPort, "object"),
-			VolumeMounts:    getStorageVolumeMounts(),
+			Ports:           getPorts(ports.ObjectServer, "object"),
+			VolumeMounts:    getStorageVolumeMounts(swiftstorage),
 			Command:         []string{"/usr/bin/swift-object-server", "/etc/swift/object-server.conf", "-v"},
 		},
 		{
@@ -127,7 +141,7 @@ func getStorageContainers(swiftstorage *swiftv1beta1.SwiftStorage) []corev1.Cont
 			Image:           swiftstorage.Spec.ContainerImageObject,
 			ImagePullPolicy: corev1.PullIfNotPresent,
 			SecurityContext: &securityContext,
-			VolumeMounts:    getStorageVolumeMounts(),
+			VolumeMounts:    getStorageVolumeMounts(swiftstorage),
 			Command:         []string{"/usr/bin/swift-object-replicator", "/etc/swift/object-server.conf", "-v"},
 		},
 		{
@@ -135,82 +149,235 @@ func getStorageContainers(swiftstorage *swiftv1beta1.SwiftStorage) []corev1.Cont
 			Image:           swiftstorage.Spec.ContainerImageObject,
 			ImagePullPolicy: corev1.PullIfNotPresent,
 			SecurityContext: &securityContext,
-			VolumeMounts:    getStorageVolumeMounts(),
-			Command:         []string{"/usr/bin/swift-object-replicator", "/etc/swift/object-server.conf", "-v"},
+			VolumeMounts:    getStorageVolumeMounts(swiftstorage),
+			Command:         []string{"/usr/bin/swift-object-auditor", "/etc/swift/object-server.conf", "-v"},
 		},
 		{
 			Name:            "object-updater",
 			Image:           swiftstorage.Spec.ContainerImageObject,
 			ImagePullPolicy: corev1.PullIfNotPresent,
 			SecurityContext: &securityContext,
-			VolumeMounts:    getStorageVolumeMounts(),
-			Command:         []string{"/usr/bin/swift-object-replicator", "/etc/swift/object-server.conf", "-v"},
-		},
-		{
-			Name:            "object-expirer",
-			Image:           swiftstorage.Spec.ContainerImageProxy,
-			ImagePullPolicy: corev1.PullIfNotPresent,
-			SecurityContext: &securityContext,
-			VolumeMounts:    getStorageVolumeMounts(),
-			Command:         []string{"/usr/bin/swift-object-expirer", "/etc/swift/object-expirer.conf", "-v"},
-		},
-		{
-			Name:            "rsync",
-			Image:           swiftstorage.Spec.ContainerImageObject,
-			ImagePullPolicy: corev1.PullIfNotPresent,
-			SecurityContext: &securityContext,
-			Ports:           getPorts(swift.RsyncPort, "rsync"),
-			VolumeMounts:    getStorageVolumeMounts(),
-			Command:         []string{"/usr/bin/rsync", "--daemon", "--no-detach", "--config=/etc/swift/rsyncd.conf", "--log-file=/dev/stdout"},
+			VolumeMounts:    getStorageVolumeMounts(swiftstorage),
+			Command:         []string{"/usr/bin/swift-object-updater", "/etc/swift/object-server.conf", "-v"},
 		},
-		{
+		objectExpirerContainer(swiftstorage),
+		rsyncContainer(swiftstorage),
+	}
+
+	if swiftstorage.Spec.ReplicationServers {
+		containers = append(containers,
+			replicationServerContainer(swiftstorage, "account", swiftstorage.Spec.ContainerImageAccount, ports.AccountReplication),
+			replicationServerContainer(swiftstorage, "container", swiftstorage.Spec.ContainerImageContainer, ports.ContainerReplication),
+			replicationServerContainer(swiftstorage, "object", swiftstorage.Spec.ContainerImageObject, ports.ObjectReplication),
+		)
+	}
+
+	for i := range containers {
+		switch {
+		case strings.HasSuffix(containers[i].Name, "-server"):
+			containers[i].Lifecycle = gracefulStopLifecycle("server")
+		case strings.HasSuffix(containers[i].Name, "-replicator"):
+			containers[i].Lifecycle = gracefulStopLifecycle("replicator")
+		}
+	}
+
+	// A shared Memcached instance replaces the sidecar
+	if swiftstorage.Spec.MemcachedInstance == "" {
+		containers = append(containers, corev1.Container{
 			Name:            "memcached",
 			Image:           swiftstorage.Spec.ContainerImageMemcached,
 			ImagePullPolicy: corev1.PullIfNotPresent,
 			SecurityContext: &securityContext,
-			Ports:           getPorts(swift.MemcachedPort, "memcached"),
-			Command:         []string{"/usr/bin/memcached", "-p", "11211", "-u", "memcached"},
+			Ports:           getPorts(ports.Memcached, "memcached"),
+			Command:         []string{"/usr/bin/memcached", "-p", fmt.Sprint(ports.Memcached), "-u", "memcached"},
+		})
+	}
+
+	if swiftstorage.Spec.LogForwarding.Enabled {
+		containers = append(containers, swift.LogForwardingContainer(swiftstorage.Spec.LogForwarding))
+	}
+
+	return containers
+}
+
+// replicationServerContainer returns a server of the given type that only
+// handles the replication requests on the replication port
+func replicationServerContainer(swiftstorage *swiftv1beta1.SwiftStorage, server string, image string, port int32) corev1.Container {
+	securityContext := swift.GetSecurityContext()
+
+	return corev1.Container{
+		Name:            server + "-replication-server",
+		Image:           image,
+		ImagePullPolicy: corev1.PullIfNotPresent,
+		SecurityContext: &securityContext,
+		Ports:           getPorts(port, server+"-repl"),
+		VolumeMounts:    getStorageVolumeMounts(swiftstorage),
+		Command:         []string{"/usr/bin/swift-" + server + "-server", "/etc/swift/" + server + "-replication-server.conf", "-v"},
+	}
+}
+
+// gracefulStopLifecycle returns the preStop hook shutting down the given
+// kind of Swift service gracefully
+func gracefulStopLifecycle(kind string) *corev1.Lifecycle {
+	return &corev1.Lifecycle{
+		PreStop: &corev1.LifecycleHandler{
+			Exec: &corev1.ExecAction{
+				Command: []string{"/usr/local/bin/container-scripts/graceful-stop.sh", kind},
+			},
 		},
 	}
 }
 
+// objectExpirerContainer returns the object expirer container. If the
+// expiration queue is split, the part processed by the expirer is given by
+// the ordinal of the pod
+func objectExpirerContainer(swiftstorage *swiftv1beta1.SwiftStorage) corev1.Container {
+	securityContext := swift.GetSecurityContext()
+
+	container := corev1.Container{
+		Name:            "object-expirer",
+		Image:           swiftstorage.Spec.ContainerImageProxy,
+		ImagePullPolicy: corev1.PullIfNotPresent,
+		SecurityContext: &securityContext,
+		VolumeMounts:    getStorageVolumeMounts(swiftstorage),
+		Command:         []string{"/usr/bin/swift-object-expirer", "/etc/swift/object-expirer.conf", "-v"},
+	}
+
+	if processes := swiftstorage.Spec.ObjectExpirer.Processes; processes != nil && *processes > 0 {
+		container.Env = []corev1.EnvVar{
+			{
+				Name:  "PROCESSES",
+				Value: fmt.Sprint(*processes),
+			},
+			{
+				Name:  "ORDINAL_START",
+				Value: fmt.Sprint(OrdinalStart(swiftstorage)),
+			},
+		}
+		container.Command = []string{"/usr/local/bin/container-scripts/object-expirer.sh"}
+	}
+
+	return container
+}
+
+// rsyncContainer returns the rsync daemon container. If the pods are attached
+// to a NetworkAttachment, rsync is bound to the IP on the first one
+func rsyncContainer(swiftstorage *swiftv1beta1.SwiftStorage) corev1.Container {
+	securityContext := swift.GetSecurityContext()
+
+	container := corev1.Container{
+		Name:            "rsync",
+		Image:           swiftstorage.Spec.ContainerImageObject,
+		ImagePullPolicy: corev1.PullIfNotPresent,
+		SecurityContext: &securityContext,
+		Ports:           getPorts(Ports(swiftstorage).Rsync, "rsync"),
+		VolumeMounts:    getStorageVolumeMounts(swiftstorage),
+		Command:         []string{"/usr/bin/rsync", "--daemon", "--no-detach", "--config=/etc/swift/rsyncd.conf", "--log-file=/dev/stdout"},
+	}
+
+	if len(swiftstorage.Spec.NetworkAttachments) > 0 {
+		container.Env = []corev1.EnvVar{{
+			Name:  "NETWORK_ATTACHMENT",
+			Value: fmt.Sprintf("%s/%s", swiftstorage.Namespace, swiftstorage.Spec.NetworkAttachments[0]),
+		}}
+		container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+			Name:      "podinfo",
+			MountPath: "/etc/podinfo",
+			ReadOnly:  true,
+		})
+		container.Command = []string{"/usr/local/bin/container-scripts/rsync.sh"}
+	}
+
+	return container
+}
+
+// unprivilegedPortStart returns the lowest port used by the storage
+// services, as these are running unprivileged
+func unprivilegedPortStart(swiftstorage *swiftv1beta1.SwiftStorage) int32 {
+	ports := Ports(swiftstorage)
+	start := ports.Rsync
+	accountReplication, containerReplication, objectReplication := ReplicationPorts(swiftstorage)
+	for _, port := range []int32{ports.AccountServer, ports.ContainerServer, ports.ObjectServer, ports.Memcached,
+		accountReplication, containerReplication, objectReplication} {
+		if port < start {
+			start = port
+		}
+	}
+	return start
+}
+
+// storageAffinity returns the affinity of the storage pods. By default two
+// pods are not scheduled to the same node if possible
+func storageAffinity(swiftstorage *swiftv1beta1.SwiftStorage, labels map[string]string) *corev1.Affinity {
+	if swiftstorage.Spec.Affinity != nil {
+		return swiftstorage.Spec.Affinity
+	}
+
+	switch swiftstorage.Spec.AntiAffinity {
+	case "None":
+		return nil
+	case "Required":
+		return &corev1.Affinity{
+			PodAntiAffinity: &corev1.PodAntiAffinity{
+				RequiredDuringSchedulingIgnoredDuringExecution: []corev1.PodAffinityTerm{{
+					LabelSelector: &metav1.LabelSelector{
+						MatchLabels: labels,
+					},
+					TopologyKey: corev1.LabelHostname,
+				}},
+			},
+		}
+	default:
+		return affinity.DistributePods("app.kubernetes.io/name", []string{labels["app.kubernetes.io/name"]}, corev1.LabelHostname)
+	}
+}
+
 func StatefulSet(
-	swiftstorage *swiftv1beta1.SwiftStorage, labels map[string]string) *appsv1.StatefulSet {
+	swiftstorage *swiftv1beta1.SwiftStorage, labels map[string]string, annotations map[string]string) *appsv1.StatefulSet {
 
 	trueVal := true
 	OnRootMismatch := corev1.FSGroupChangeOnRootMismatch
 	user := int64(swift.RunAsUser)
 
-	return &appsv1.StatefulSet{
+	extra := swiftstorage.Spec.ExtraMetadata
+
+	sset := &appsv1.StatefulSet{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      swiftstorage.Name,
-			Namespace: swiftstorage.Namespace,
-			Labels:    labels,
+			Name:        swiftstorage.Name,
+			Namespace:   swiftstorage.Namespace,
+			Labels:      util.MergeStringMaps(labels, extra.StatefulSet.Labels),
+			Annotations: extra.StatefulSet.Annotations,
 		},
 		Spec: appsv1.StatefulSetSpec{
 			ServiceName: swiftstorage.Name,
 			Selector: &metav1.LabelSelector{
 				MatchLabels: labels,
 			},
-			Replicas: swiftstorage.Spec.Replicas,
+			Replicas:             swiftstorage.Spec.Replicas,
+			RevisionHistoryLimit: swiftstorage.Spec.RevisionHistoryLimit,
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
-					Labels: labels,
+					Labels:      util.MergeStringMaps(labels, extra.Pod.Labels),
+					Annotations: util.MergeStringMaps(annotations, extra.Pod.Annotations),
 				},
 				Spec: corev1.PodSpec{
-					ServiceAccountName: swift.ServiceAccount,
+					ServiceAccountName:            swift.ServiceAccount,
+					ImagePullSecrets:              swiftstorage.Spec.ImagePullSecrets,
+					PriorityClassName:             swiftstorage.Spec.PriorityClassName,
+					TerminationGracePeriodSeconds: swiftstorage.Spec.TerminationGracePeriodSeconds,
 					SecurityContext: &corev1.PodSecurityContext{
 						FSGroup:             &user,
 						FSGroupChangePolicy: &OnRootMismatch,
 						Sysctls: []corev1.Sysctl{{
 							Name:  "net.ipv4.ip_unprivileged_port_start",
-							Value: "873",
+							Value: fmt.Sprint(unprivilegedPortStart(swiftstorage)),
 						}},
 						RunAsNonRoot: &trueVal,
 						SeccompProfile: &corev1.SeccompProfile{
 							Type: corev1.SeccompProfileTypeRuntimeDefault,
 						},
 					},
+					Affinity:   storageAffinity(swiftstorage, labels),
 					Volumes:    getStorageVolumes(swiftstorage),
 					Containers: getStorageContainers(swiftstorage),
 				},
@@ -233,4 +400,39 @@ func StatefulSet(
 			}},
 		},
 	}
+
+	if swiftstorage.Spec.OrdinalStart != nil {
+		sset.Spec.Ordinals = &appsv1.StatefulSetOrdinals{
+			Start: *swiftstorage.Spec.OrdinalStart,
+		}
+	}
+
+	return sset
+}
+
+// EnsureOrdinals sets the ordinals of the StatefulSet, as these are not
+// handled by lib-common. A new StatefulSet is created including the
+// ordinals, otherwise the first pods would use the default ordinals
+func EnsureOrdinals(ctx context.Context, h *helper.Helper, sset *appsv1.StatefulSet) error {
+	current := &appsv1.StatefulSet{}
+	err := h.GetClient().Get(ctx, types.NamespacedName{Name: sset.Name, Namespace: sset.Namespace}, current)
+	if apierrors.IsNotFound(err) {
+		if sset.Spec.Ordinals == nil {
+			return nil
+		}
+		sset = sset.DeepCopy()
+		if err := controllerutil.SetControllerReference(h.GetBeforeObject(), sset, h.GetScheme()); err != nil {
+			return err
+		}
+		return h.GetClient().Create(ctx, sset)
+	} else if err != nil {
+		return err
+	}
+
+	if equality.Semantic.DeepEqual(current.Spec.Ordinals, sset.Spec.Ordinals) {
+		return nil
+	}
+	patch := client.MergeFrom(current.DeepCopy())
+	current.Spec.Ordinals = sset.Spec.Ordinals
+	return h.GetClient().Patch(ctx, current, patch)
 }
@@ -20,19 +20,85 @@ import (
 	"fmt"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/util"
 	swiftv1beta1 "github.com/openstack-k8s-operators/swift-operator/api/v1beta1"
+	"github.com/openstack-k8s-operators/swift-operator/pkg/swift"
 )
 
-func ConfigMapTemplates(instance *swiftv1beta1.SwiftStorage, labels map[string]string) []util.Template {
+func ConfigMapTemplates(instance *swiftv1beta1.SwiftStorage, labels map[string]string, memcached *swift.Memcached) []util.Template {
 	templateParameters := make(map[string]interface{})
+	swift.LogForwardingTemplateParameters(instance.Spec.LogForwarding, templateParameters)
+	serverTuningTemplateParameters("Account", instance.Spec.AccountServer, templateParameters)
+	serverTuningTemplateParameters("Container", instance.Spec.ContainerServer, templateParameters)
+	serverTuningTemplateParameters("Object", instance.Spec.ObjectServer.SwiftServerTuning, templateParameters)
+	templateParameters["AllowVersions"] = instance.Spec.AllowVersions
+	templateParameters["ObjectServersPerPort"] = swift.OptionalValue(instance.Spec.ObjectServer.ServersPerPort)
+	ports := Ports(instance)
+	templateParameters["AccountServerPort"] = ports.AccountServer
+	templateParameters["ContainerServerPort"] = ports.ContainerServer
+	templateParameters["ObjectServerPort"] = ports.ObjectServer
+	templateParameters["RsyncPort"] = ports.Rsync
+	templateParameters["ReplicationServers"] = instance.Spec.ReplicationServers
+	templateParameters["AccountReplicationPort"] = ports.AccountReplication
+	templateParameters["ContainerReplicationPort"] = ports.ContainerReplication
+	templateParameters["ObjectReplicationPort"] = ports.ObjectReplication
+	swift.MemcacheTemplateParameters(memcached, instance.Spec.IPFamilies, ports.Memcached, instance.Spec.CABundleSecretName, templateParameters)
+	templateParameters["BindIP"] = swift.BindIP(instance.Spec.IPFamilies)
+	templateParameters["DevicesRoot"] = DevicesRoot(instance)
+	templateParameters["MountCheck"] = MountCheck(instance)
+	templateParameters["ReconCachePath"] = swift.ReconCachePath
+	templateParameters["FallocateReserve"] = instance.Spec.FallocateReserve
+	templateParameters["DBPreallocation"] = ""
+	if instance.Spec.DBPreallocation != nil {
+		templateParameters["DBPreallocation"] = fmt.Sprint(*instance.Spec.DBPreallocation)
+	}
+	templateParameters["IONiceClass"] = instance.Spec.IONice.Class
+	templateParameters["IONicePriority"] = swift.OptionalValue(instance.Spec.IONice.Priority)
+	templateParameters["ReaperDelayReaping"] = swift.OptionalValue(instance.Spec.AccountReaper.DelayReaping)
+	templateParameters["ReaperConcurrency"] = swift.OptionalValue(instance.Spec.AccountReaper.Concurrency)
+	templateParameters["ReaperInterval"] = swift.OptionalValue(instance.Spec.AccountReaper.Interval)
+	replicatorTemplateParameters("Account", instance.Spec.AccountReplicator, templateParameters)
+	replicatorTemplateParameters("Container", instance.Spec.ContainerReplicator, templateParameters)
+	replicatorTemplateParameters("Object", instance.Spec.ObjectReplicator.SwiftReplicatorSpec, templateParameters)
+	templateParameters["ObjectReplicatorRsyncTimeout"] = swift.OptionalValue(instance.Spec.ObjectReplicator.RsyncTimeout)
+	templateParameters["ObjectReplicatorRsyncIOTimeout"] = swift.OptionalValue(instance.Spec.ObjectReplicator.RsyncIOTimeout)
+	templateParameters["ObjectReplicatorHandoffsFirst"] = instance.Spec.ObjectReplicator.HandoffsFirst
+	templateParameters["ObjectReplicatorHandoffDelete"] = swift.OptionalValue(instance.Spec.ObjectReplicator.HandoffDelete)
+	auditors := instance.Spec.Auditors
+	templateParameters["AuditorAccountsPerSecond"] = swift.OptionalValue(auditors.AccountsPerSecond)
+	templateParameters["AuditorContainersPerSecond"] = swift.OptionalValue(auditors.ContainersPerSecond)
+	templateParameters["AuditorFilesPerSecond"] = swift.OptionalValue(auditors.FilesPerSecond)
+	templateParameters["AuditorBytesPerSecond"] = swift.OptionalValue(auditors.BytesPerSecond)
+	templateParameters["AuditorZeroByteFilesPerSecond"] = swift.OptionalValue(auditors.ZeroByteFilesPerSecond)
+	updaterTemplateParameters("Container", instance.Spec.ContainerUpdater, templateParameters)
+	updaterTemplateParameters("Object", instance.Spec.ObjectUpdater.SwiftUpdaterSpec, templateParameters)
+	objectUpdater := instance.Spec.ObjectUpdater
+	templateParameters["ObjectUpdaterWorkers"] = swift.OptionalValue(objectUpdater.UpdaterWorkers)
+	templateParameters["ObjectUpdaterObjectsPerSecond"] = swift.OptionalValue(objectUpdater.ObjectsPerSecond)
+	templateParameters["ObjectUpdaterMaxObjectsPerContainerPerSecond"] = swift.OptionalValue(objectUpdater.MaxObjectsPerContainerPerSecond)
+	templateParameters["ObjectUpdaterMaxDeferredUpdates"] = swift.OptionalValue(objectUpdater.MaxDeferredUpdates)
+	templateParameters["ExpirerProcesses"] = swift.OptionalValue(instance.Spec.ObjectExpirer.Processes)
+	templateParameters["ExpirerConcurrency"] = swift.OptionalValue(instance.Spec.ObjectExpirer.Concurrency)
+	templateParameters["ExpirerReportInterval"] = swift.OptionalValue(instance.Spec.ObjectExpirer.ReportInterval)
+
+	additionalTemplates := map[string]string{"ring-sync.sh": "/common/ring-sync.sh"}
+	configTemplates := map[string]string{}
+	if instance.Spec.LogForwarding.Enabled {
+		configTemplates["rsyslog.conf"] = "/common/config/rsyslog.conf"
+	}
+	if instance.Spec.ReplicationServers {
+		for _, server := range []string{"account", "container", "object"} {
+			configTemplates[server+"-replication-server.conf"] = "/swiftstorage/replication/" + server + "-server.conf"
+		}
+	}
 
 	return []util.Template{
 		{
-			Name:          fmt.Sprintf("%s-config-data", instance.Name),
-			Namespace:     instance.Namespace,
-			Type:          util.TemplateTypeConfig,
-			InstanceType:  instance.Kind,
-			Labels:        labels,
-			ConfigOptions: templateParameters,
+			Name:               ConfigMapName(instance),
+			Namespace:          instance.Namespace,
+			Type:               util.TemplateTypeConfig,
+			InstanceType:       instance.Kind,
+			Labels:             labels,
+			ConfigOptions:      templateParameters,
+			AdditionalTemplate: configTemplates,
 		},
 		{
 			Name:               fmt.Sprintf("%s-scripts", instance.Name),
@@ -40,22 +106,30 @@ func ConfigMapTemplates(instance *swiftv1beta1.SwiftStorage, labels map[string]s
 			Type:               util.TemplateTypeScripts,
 			InstanceType:       instance.Kind,
 			Labels:             labels,
-			AdditionalTemplate: map[string]string{"ring-sync.sh": "/common/ring-sync.sh"},
+			AdditionalTemplate: additionalTemplates,
 		},
 	}
 }
 
-func DeviceConfigMapTemplates(instance *swiftv1beta1.SwiftStorage, devices string) []util.Template {
-	data := make(map[string]string)
-	data["devices.csv"] = devices
+// serverTuningTemplateParameters adds the workers and max_clients settings
+// of one server type, prefixed with the given server name
+func serverTuningTemplateParameters(server string, tuning swiftv1beta1.SwiftServerTuning, templateParameters map[string]interface{}) {
+	templateParameters[server+"Workers"] = swift.OptionalValue(tuning.Workers)
+	templateParameters[server+"MaxClients"] = swift.OptionalValue(tuning.MaxClients)
+}
 
-	return []util.Template{
-		{
-			Name:         swiftv1beta1.DeviceConfigMapName,
-			Namespace:    instance.Namespace,
-			Type:         util.TemplateTypeNone,
-			InstanceType: instance.Kind,
-			CustomData:   data,
-		},
-	}
+// replicatorTemplateParameters adds the settings of one replicator, prefixed
+// with the given server name
+func replicatorTemplateParameters(server string, replicator swiftv1beta1.SwiftReplicatorSpec, templateParameters map[string]interface{}) {
+	templateParameters[server+"ReplicatorConcurrency"] = swift.OptionalValue(replicator.Concurrency)
+	templateParameters[server+"ReplicatorInterval"] = swift.OptionalValue(replicator.Interval)
+	templateParameters[server+"ReplicatorNodeTimeout"] = swift.OptionalValue(replicator.NodeTimeout)
+}
+
+// updaterTemplateParameters adds the settings of one updater, prefixed with
+// the given server name
+func updaterTemplateParameters(server string, updater swiftv1beta1.SwiftUpdaterSpec, templateParameters map[string]interface{}) {
+	templateParameters[server+"UpdaterInterval"] = swift.OptionalValue(updater.Interval)
+	templateParameters[server+"UpdaterConcurrency"] = swift.OptionalValue(updater.Concurrency)
+	templateParameters[server+"UpdaterSlowdown"] = updater.Slowdown
 }
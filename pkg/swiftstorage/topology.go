@@ -0,0 +1,81 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package swiftstorage
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+
+	swiftv1beta1 "github.com/openstack-k8s-operators/swift-operator/api/v1beta1"
+	"github.com/openstack-k8s-operators/swift-operator/pkg/swift"
+)
+
+//+kubebuilder:rbac:groups=core,resources=nodes,verbs=get;list;watch
+
+// topologyValue returns the ring region or zone for the value of a node
+// label, using the mapping first
+func topologyValue(label string, value string, mapping map[string]int32, fallback int) (int, error) {
+	if value == "" {
+		return fallback, nil
+	}
+	if mapped, ok := mapping[value]; ok {
+		return int(mapped), nil
+	}
+	number, err := strconv.Atoi(value)
+	if err != nil || number < 0 {
+		return 0, fmt.Errorf("no ring mapping for %s=%s", label, value)
+	}
+	return number, nil
+}
+
+// podTopology returns the ring region and zone of the storage pod, derived
+// from the labels of its node
+func podTopology(ctx context.Context, h *helper.Helper, instance *swiftv1beta1.SwiftStorage, podName string) (int, int, error) {
+	topology := instance.Spec.RingTopology
+	if !topology.Enabled {
+		return swift.RingRegion, swift.RingZone, nil
+	}
+
+	pod := &corev1.Pod{}
+	err := h.GetClient().Get(ctx, types.NamespacedName{Name: podName, Namespace: instance.Namespace}, pod)
+	if err != nil {
+		return 0, 0, err
+	}
+	if pod.Spec.NodeName == "" {
+		return 0, 0, fmt.Errorf("pod %s is not scheduled yet", podName)
+	}
+	node := &corev1.Node{}
+	if err := h.GetClient().Get(ctx, types.NamespacedName{Name: pod.Spec.NodeName}, node); err != nil {
+		return 0, 0, err
+	}
+
+	region, err := topologyValue(topology.RegionLabel, node.Labels[topology.RegionLabel], topology.Regions, swift.RingRegion)
+	if err != nil {
+		return 0, 0, fmt.Errorf("node %s of pod %s: %w", node.Name, podName, err)
+	}
+	zone, err := topologyValue(topology.ZoneLabel, node.Labels[topology.ZoneLabel], topology.Zones, swift.RingZone)
+	if err != nil {
+		return 0, 0, fmt.Errorf("node %s of pod %s: %w", node.Name, podName, err)
+	}
+	return region, zone, nil
+}
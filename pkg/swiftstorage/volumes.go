@@ -25,7 +25,7 @@ import (
 
 func getStorageVolumes(instance *swiftv1beta1.SwiftStorage) []corev1.Volume {
 	var scriptsVolumeDefaultMode int32 = 0755
-	return []corev1.Volume{
+	volumes := []corev1.Volume{
 		{
 			Name: swift.ClaimName,
 			VolumeSource: corev1.VolumeSource{
@@ -39,7 +39,7 @@ func getStorageVolumes(instance *swiftv1beta1.SwiftStorage) []corev1.Volume {
 			VolumeSource: corev1.VolumeSource{
 				ConfigMap: &corev1.ConfigMapVolumeSource{
 					LocalObjectReference: corev1.LocalObjectReference{
-						Name: instance.Name + "-config-data",
+						Name: ConfigMapName(instance),
 					},
 				},
 			},
@@ -52,16 +52,10 @@ func getStorageVolumes(instance *swiftv1beta1.SwiftStorage) []corev1.Volume {
 				},
 			},
 		},
-		{
-			Name: "ring-data",
-			VolumeSource: corev1.VolumeSource{
-				ConfigMap: &corev1.ConfigMapVolumeSource{
-					LocalObjectReference: corev1.LocalObjectReference{
-						Name: swiftv1beta1.RingConfigMapName,
-					},
-				},
-			},
-		},
+		// The rings are created once the IPs and nodes of all pods are
+		// known, thus the pods have to start without them
+		swift.RingDataVolume(instance.Spec.RingDistribution,
+			len(instance.Spec.NetworkAttachments) > 0 || instance.Spec.RingTopology.Enabled),
 		{
 			Name: "config-data-merged",
 			VolumeSource: corev1.VolumeSource{
@@ -86,13 +80,40 @@ func getStorageVolumes(instance *swiftv1beta1.SwiftStorage) []corev1.Volume {
 			},
 		},
 	}
+
+	if len(instance.Spec.NetworkAttachments) > 0 {
+		// The network status is needed to bind rsync to the storage network
+		volumes = append(volumes, corev1.Volume{
+			Name: "podinfo",
+			VolumeSource: corev1.VolumeSource{
+				DownwardAPI: &corev1.DownwardAPIVolumeSource{
+					Items: []corev1.DownwardAPIVolumeFile{{
+						Path: "annotations",
+						FieldRef: &corev1.ObjectFieldSelector{
+							FieldPath: "metadata.annotations",
+						},
+					}},
+				},
+			},
+		})
+	}
+
+	if instance.Spec.LogForwarding.Enabled {
+		volumes = append(volumes, swift.LogForwardingVolume())
+	}
+
+	if instance.Spec.CABundleSecretName != "" {
+		volumes = append(volumes, swift.CABundleVolume(instance.Spec.CABundleSecretName))
+	}
+
+	return volumes
 }
 
-func getStorageVolumeMounts() []corev1.VolumeMount {
-	return []corev1.VolumeMount{
+func getStorageVolumeMounts(instance *swiftv1beta1.SwiftStorage) []corev1.VolumeMount {
+	volumeMounts := []corev1.VolumeMount{
 		{
 			Name:      swift.ClaimName,
-			MountPath: "/srv/node/d1",
+			MountPath: DevicesRoot(instance) + "/d1",
 			ReadOnly:  false,
 		},
 		{
@@ -117,7 +138,7 @@ func getStorageVolumeMounts() []corev1.VolumeMount {
 		},
 		{
 			Name:      "cache",
-			MountPath: "/var/cache/swift",
+			MountPath: swift.ReconCachePath,
 			ReadOnly:  false,
 		},
 		{
@@ -126,4 +147,14 @@ func getStorageVolumeMounts() []corev1.VolumeMount {
 			ReadOnly:  true,
 		},
 	}
+
+	if instance.Spec.LogForwarding.Enabled {
+		volumeMounts = append(volumeMounts, swift.LogForwardingVolumeMount())
+	}
+
+	if instance.Spec.CABundleSecretName != "" {
+		volumeMounts = append(volumeMounts, swift.CABundleVolumeMount())
+	}
+
+	return volumeMounts
 }
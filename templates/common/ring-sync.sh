@@ -10,6 +10,11 @@ while true; do
         _MTIME=$(stat -L --printf "%Y" $TARFILE)
         if [ $MTIME != $_MTIME ]; then
             tar -xvzf $TARFILE -C etc/swift/
+            # The storage policies are published with the rings. They are
+            # loaded when the services start
+            if [ -e /etc/swift/storage-policies.conf ]; then
+                cat /var/lib/config-data/swiftconf/swift.conf /etc/swift/storage-policies.conf > /etc/swift/swift.conf
+            fi
         fi
         MTIME=$_MTIME
     fi
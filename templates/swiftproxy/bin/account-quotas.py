@@ -0,0 +1,41 @@
+#!/usr/bin/env python3
+# Sets the default quota on the accounts of all projects that have no quota
+# yet. Listing all projects requires the admin role, setting a quota requires
+# the ResellerAdmin role.
+import os
+
+from keystoneauth1.identity import v3
+from keystoneauth1 import session
+
+auth = v3.Password(
+    auth_url=os.environ["OS_AUTH_URL"] + "/v3",
+    username=os.environ["OS_USERNAME"],
+    password=os.environ["OS_PASSWORD"],
+    project_name="service",
+    user_domain_id="default",
+    project_domain_id="default",
+)
+sess = session.Session(auth=auth, verify=os.environ.get("OS_CACERT", True))
+url = sess.get_endpoint(service_type="object-store", interface="internal")
+base = url[:url.rindex("/AUTH_")]
+
+
+def list_projects():
+    # The identity endpoint of the catalog may or may not include the
+    # version, the projects are listed from the unversioned auth URL
+    url = os.environ["OS_AUTH_URL"] + "/v3/projects"
+    while url:
+        body = sess.get(url).json()
+        for project in body["projects"]:
+            yield project
+        url = body.get("links", {}).get("next")
+
+
+for project in list_projects():
+    account = "%s/AUTH_%s" % (base, project["id"])
+    # The account is created by account_autocreate if it does not exist
+    resp = sess.head(account, raise_exc=False)
+    if resp.status_code < 300 and "X-Account-Meta-Quota-Bytes" in resp.headers:
+        continue
+    sess.post(account, headers={"X-Account-Meta-Quota-Bytes": os.environ["QUOTA_BYTES"]})
+    print("Quota of %s set to %s bytes" % (account, os.environ["QUOTA_BYTES"]))
@@ -0,0 +1,29 @@
+#!/bin/sh
+# Drains the proxy using the disable_path of the healthcheck middleware. The
+# healthcheck returns 503 while DRAIN_FILE exists, thus the pod gets unready
+# and the load balancers stop sending new requests to it.
+#
+# stop is the preStop hook of the proxy server. It gives the load balancers
+# and the requests in flight DRAIN_SECONDS before the proxy gets SIGTERM.
+#
+# watch drains the proxy while the pod has the drain annotation, or once
+# the pod is being stopped.
+case "$1" in
+    stop)
+        touch "${DRAIN_FILE}.stopping" "$DRAIN_FILE"
+        sleep "$DRAIN_SECONDS"
+    ;;
+
+    watch)
+        trap 'exit 0' TERM
+        while true; do
+            if [ -e "${DRAIN_FILE}.stopping" ] || grep -qs '^swift.openstack.org/drain="true"$' /etc/podinfo/annotations; then
+                touch "$DRAIN_FILE"
+            else
+                rm -f "$DRAIN_FILE"
+            fi
+            sleep 5 &
+            wait $!
+        done
+    ;;
+esac
@@ -0,0 +1,28 @@
+#!/usr/bin/env python3
+# Sets the temp URL keys of the account of the service project. Keys that
+# are not set are removed from the account.
+import os
+
+from keystoneauth1.identity import v3
+from keystoneauth1 import session
+
+auth = v3.Password(
+    auth_url=os.environ["OS_AUTH_URL"] + "/v3",
+    username=os.environ["OS_USERNAME"],
+    password=os.environ["OS_PASSWORD"],
+    project_name="service",
+    user_domain_id="default",
+    project_domain_id="default",
+)
+sess = session.Session(auth=auth, verify=os.environ.get("OS_CACERT", True))
+url = sess.get_endpoint(service_type="object-store", interface="internal")
+
+headers = {}
+for meta, var in (("Temp-URL-Key", "TEMPURL_KEY"), ("Temp-URL-Key-2", "TEMPURL_KEY_2")):
+    if os.environ.get(var):
+        headers["X-Account-Meta-" + meta] = os.environ[var]
+    else:
+        headers["X-Remove-Account-Meta-" + meta] = "x"
+
+# Raises on an error response, the Job is retried
+sess.post(url, headers=headers)
@@ -5,24 +5,42 @@
 # manages these and also checks replication status to decide if rebalancing is
 # safe.
 
-# TODO: this should be set properly according to the number of devices
-PART_POWER=8
-MIN_PART_HOURS=1
 TARFILE="/tmp/swiftrings.tar.gz"
-BASE_URL="https://kubernetes.default.svc/api/v1/namespaces/${NAMESPACE}/configmaps"
+CM_BASE_URL="https://kubernetes.default.svc/api/v1/namespaces/${NAMESPACE}/configmaps"
+BASE_URL="${CM_BASE_URL}"
 URL="${BASE_URL}/${CM_NAME}"
 METHOD="PUT"
+# The rings are published in a ConfigMap or a Secret, the Secret stores the
+# tar file in its data instead of the binaryData
+RING_KIND="ConfigMap"
+RING_NAME="${CM_NAME}"
+RING_DATA_FIELD="binaryData"
+if [ "${RING_DISTRIBUTION}" = "Secret" ]; then
+    BASE_URL="https://kubernetes.default.svc/api/v1/namespaces/${NAMESPACE}/secrets"
+    URL="${BASE_URL}/${RING_SECRET_NAME}"
+    RING_KIND="Secret"
+    RING_NAME="${RING_SECRET_NAME}"
+    RING_DATA_FIELD="data"
+fi
+BUILDER_BASE_URL="https://kubernetes.default.svc/api/v1/namespaces/${NAMESPACE}/secrets"
+BUILDER_URL="${BUILDER_BASE_URL}/${BUILDER_SECRET_NAME}"
+BUILDER_METHOD="PUT"
 
 # Credentials to be used by curl
 export CURL_CA_BUNDLE=/var/run/secrets/kubernetes.io/serviceaccount/ca.crt
 TOKEN=$(cat /var/run/secrets/kubernetes.io/serviceaccount/token)
 
+# Ignore SIGTERM, eg. when the Job is deleted or the node is drained. The
+# rings are either published completely or not at all, and the Pod is given
+# enough time to finish before it is killed.
+trap '' TERM
+
 cp -t /etc/swift/ /var/lib/config-data/swiftconf/*
 
-# Get the ConfigMap with the Swiftrings if it exists. If it exists, untar it
-# and update the rings. If it does not exist, create a new ConfigMap using a
-# POST request. If the response code is neither 200 or 404 fail early, b/c it
-# is unclear if there might be an existing set of rings that should not be
+# Get the ConfigMap or Secret with the Swiftrings if it exists. If it exists,
+# untar it and update the rings. If it does not exist, create a new one using
+# a POST request. If the response code is neither 200 or 404 fail early, b/c
+# it is unclear if there might be an existing set of rings that should not be
 # overwritten
 HTTP_CODE=$(/usr/bin/curl \
     -H "Authorization: Bearer $TOKEN" \
@@ -30,6 +48,29 @@ HTTP_CODE=$(/usr/bin/curl \
     -w "%{http_code}" \
     -X GET "${URL}" 2>/dev/null)
 
+# The rings of a Secret that does not exist yet are taken from the ConfigMap
+# if the rings were published in a ConfigMap before
+if [ "$HTTP_CODE" = "404" ] && [ "${RING_KIND}" = "Secret" ]; then
+    CM_HTTP_CODE=$(/usr/bin/curl \
+        -H "Authorization: Bearer $TOKEN" \
+        -o /tmp/configmap \
+        -w "%{http_code}" \
+        -X GET "${CM_BASE_URL}/${CM_NAME}" 2>/dev/null)
+    case $CM_HTTP_CODE in
+        "200")
+            grep -e '"swiftrings.tar.gz": ".*"' /tmp/configmap  | cut -f 4 -d '"' | base64 -d > $TARFILE
+            tar -xvzf $TARFILE -C /etc/swift/ || exit 1
+        ;;
+
+        "404")
+        ;;
+
+        *)
+            exit 1
+        ;;
+    esac
+fi
+
 case $HTTP_CODE in
     "200")
         # Configmap was found
@@ -41,6 +82,24 @@ case $HTTP_CODE in
     "404")
         METHOD="POST"
         URL="${BASE_URL}"
+        # Initialize the rings from the snapshot if given, e.g. when
+        # restoring a deployment with existing data. The builders of the
+        # snapshot are used unless the builder Secret still exists
+        SNAPSHOT=/var/lib/config-data/ring-snapshot
+        if [ -e $SNAPSHOT/swiftrings.tar.gz ] && [ "$CM_HTTP_CODE" != "200" ]; then
+            tar -xvzf $SNAPSHOT/swiftrings.tar.gz -C /etc/swift/ || exit 1
+            if [ -e $SNAPSHOT/swiftbuilders.tar.gz ]; then
+                tar -xvzf $SNAPSHOT/swiftbuilders.tar.gz -C /etc/swift/ || exit 1
+            fi
+            RESTORED=1
+        fi
+        # Adopt the builders and rings of an existing cluster if given, one
+        # file per key of the Secret
+        IMPORT=/var/lib/config-data/ring-import
+        if [ -d $IMPORT ] && [ "$CM_HTTP_CODE" != "200" ]; then
+            cp -t /etc/swift/ $IMPORT/* || exit 1
+            IMPORTED=1
+        fi
     ;;
 
     *)
@@ -48,65 +107,463 @@ case $HTTP_CODE in
     ;;
 esac
 
-cd /etc/swift
+# The builders are kept in their own Secret, together with the previous
+# versions. Rings published before the Secret was used still contain the
+# builders in the ConfigMap
+HTTP_CODE=$(/usr/bin/curl \
+    -H "Authorization: Bearer $TOKEN" \
+    -o /tmp/builders \
+    -w "%{http_code}" \
+    -X GET "${BUILDER_URL}" 2>/dev/null)
 
-# Create new rings if not existing
-for f in account.builder container.builder object.builder; do
-    [ ! -e $f ] && swift-ring-builder $f create 8 ${SWIFT_REPLICAS} 1
-done
+case $HTTP_CODE in
+    "200")
+        grep -e '"swiftbuilders.tar.gz": ".*"' /tmp/builders | cut -f 4 -d '"' | base64 -d > /tmp/swiftbuilders.tar.gz
+        tar -xvzf /tmp/swiftbuilders.tar.gz -C /etc/swift/ || exit 1
+    ;;
+
+    "404")
+        BUILDER_METHOD="POST"
+        BUILDER_URL="${BUILDER_BASE_URL}"
+    ;;
 
-# Iterate over all devices from the list created by the SwiftStorage CR.
-# This does not check for existing ones, which is OK for smaller rings but will
-# be replaced in the improved version. It's basically a dumb brute-force
-# approach to add devices and set their weights
-for DEV in $(cat /var/lib/config-data/ring-devices/devices.csv); do
-    REGION=$(echo $DEV | cut -f1 -d,)
-    ZONE=$(echo $DEV | cut -f2 -d,)
-    HOST=$(echo $DEV | cut -f3 -d,)
-    DEVICE_NAME=$(echo $DEV | cut -f4 -d,)
-    WEIGHT=$(echo $DEV | cut -f5 -d,)
-
-    swift-ring-builder account.builder add --region $REGION --zone $ZONE --ip $HOST --port 6202 --device $DEVICE_NAME --weight $WEIGHT
-    swift-ring-builder container.builder add --region $REGION --zone $ZONE --ip $HOST --port 6201 --device $DEVICE_NAME --weight $WEIGHT
-    swift-ring-builder object.builder add --region $REGION --zone $ZONE --ip $HOST --port 6200 --device $DEVICE_NAME --weight $WEIGHT
-
-    # This will change the weights, eg. after bootstrapping and correct PVC
-    # sizes are known.
-    swift-ring-builder account.builder set_weight --region $REGION --zone $ZONE --ip $HOST --port 6202 --device $DEVICE_NAME $WEIGHT
-    swift-ring-builder container.builder set_weight --region $REGION --zone $ZONE --ip $HOST --port 6201 --device $DEVICE_NAME $WEIGHT
-    swift-ring-builder object.builder set_weight --region $REGION --zone $ZONE --ip $HOST --port 6200 --device $DEVICE_NAME $WEIGHT
+    *)
+        exit 1
+    ;;
+esac
+
+# Returns the base64 encoded value of a key of the builder Secret
+builder_data() {
+    grep -e "\"$1\": \".*\"" /tmp/builders 2>/dev/null | cut -f 4 -d '"'
+}
+
+# Tars the rings and the storage policies to $TARFILE. The ConfigMap and
+# Secret are limited in size, fail with an explicit message instead of a
+# rejected request
+tar_rings() {
+    tar cvz *.ring.gz builders.md5 $(ls storage-policies.conf 2>/dev/null) > $TARFILE || exit 1
+    RING_SIZE=$(stat -c %s $TARFILE)
+    if [ "$RING_SIZE" -gt "${RING_SIZE_LIMIT}" ]; then
+        echo "Rings with ${RING_SIZE} bytes exceed the ${RING_KIND} size limit of ${RING_SIZE_LIMIT} bytes"
+        exit 1
+    fi
+}
+
+# Prints the checksums of the builders and the storage policies. Added or
+# removed files change them as well
+builders_md5() {
+    md5sum *.builder $(ls storage-policies.conf 2>/dev/null)
+}
+
+# Succeeds if the balance of a ring improved or the builders were changed by
+# more than the rebalance, eg. by added devices, changed weights or replicas,
+# or the storage policies changed. Rings of a scheduled rebalance are only
+# published in that case, otherwise partitions would be moved without any
+# benefit
+balance_improved() {
+    [ "$(builders_md5 | grep -v '\.builder$')" = "$(grep -v '\.builder$' builders.md5 2>/dev/null)" ] || return 0
+    python3 - <<'EOF'
+import glob
+import os
+import sys
+
+from swift.common.ring import RingBuilder
+
+
+def weights(builder):
+    """Returns the weight per device of the builder"""
+    return {(d["ip"], d["port"], d["device"]): d["weight"]
+            for d in builder.devs if d is not None}
+
+
+for f in glob.glob("*.builder"):
+    if not os.path.exists("/tmp/before/" + f):
+        sys.exit(0)
+    builder = RingBuilder.load(f)
+    before = RingBuilder.load("/tmp/before/" + f)
+    if (weights(builder) != weights(before) or
+            builder.replicas != before.replicas or
+            builder.part_power != before.part_power or
+            builder.min_part_hours != before.min_part_hours):
+        sys.exit(0)
+    if round(builder.get_balance(), 2) < round(before.get_balance(), 2):
+        sys.exit(0)
+sys.exit(1)
+EOF
+}
+
+# Deletes the Secret with the rings waiting for approval
+delete_pending() {
+    HTTP_CODE=$(/usr/bin/curl \
+        -H "Authorization: Bearer $TOKEN" \
+        -o /dev/null \
+        -w "%{http_code}" \
+        -X DELETE "${BUILDER_BASE_URL}/${PENDING_SECRET_NAME}" 2>/dev/null)
+    case $HTTP_CODE in
+        "200"|"404")
+        ;;
+
+        *)
+            exit 1
+        ;;
+    esac
+}
+
+cd /etc/swift
+
+# The builder of a published ring is missing if the builder Secret was lost.
+# It is recreated from the ring with RECOVER_BUILDERS, otherwise the Job fails
+# instead of creating a new builder, which would move all partitions. The
+# component builders of a composite ring can't be recreated from the ring
+for f in *.ring.gz; do
+    [ -e $f ] || continue
+    RING=${f%.ring.gz}
+    [ -e $RING.builder ] && continue
+    if [ "$RING" = "object" ] && [ -n "${COMPOSITE_COMPONENTS}" ]; then
+        ls object.*.builder > /dev/null 2>&1 && continue
+        echo "The component builders of the composite object ring are missing and can't be recovered"
+        exit 1
+    fi
+    if [ "${RECOVER_BUILDERS}" != "true" ] && [ -n "${RESTORED}" ]; then
+        echo "The ring snapshot contains no $RING.builder, add the swiftbuilders.tar.gz key of the ${BUILDER_SECRET_NAME} Secret to it or set recoverBuilders to recreate it from $f"
+        exit 1
+    fi
+    if [ "${RECOVER_BUILDERS}" != "true" ]; then
+        echo "$RING.builder is missing, set recoverBuilders to recreate it from $f"
+        exit 1
+    fi
+    MIN_PART_HOURS=1
+    for PARAMETERS in ${RING_PARAMETERS}; do
+        [ "${PARAMETERS%%:*}" = "$RING" ] && MIN_PART_HOURS=$(echo $PARAMETERS | cut -f3 -d:)
+    done
+    swift-ring-builder $f write_builder ${MIN_PART_HOURS} || exit 1
+    echo "Recovered $RING.builder from $f"
 done
 
-# TODO: needs a check if it is safe to rebalance individual rings
+# Keep the builders before any change, the changes are reported with the
+# ring stats
+mkdir -p /tmp/before
 for f in *.builder; do
-    swift-ring-builder $f rebalance
+    [ -e $f ] && cp $f /tmp/before/
 done
 
-# Tar up all the ring data and either create or update the SwiftRing ConfigMap
-BINARY_DATA=`tar cvz *.builder *.ring.gz backups/*.builder | /usr/bin/base64 -w 0`
-CONFIGMAP_JSON='{
-    "apiVersion":"v1",
-    "kind":"ConfigMap",
-    "metadata":{
-        "name":"'${CM_NAME}'",
-        "namespace":"'${NAMESPACE}'",
-        "ownerReferences": [
-            {
-                "apiVersion": "'${OWNER_APIVERSION}'",
-                "kind": "'${OWNER_KIND}'",
-                "name": "'${OWNER_NAME}'",
-                "uid": "'${OWNER_UID}'"
-            }
-        ]
-    },
-    "binaryData":{
-        "swiftrings.tar.gz": "'${BINARY_DATA}'"
+# Publish the approved rings prepared by a previous run unchanged, otherwise
+# update and rebalance the builders
+if [ "${PUBLISH_PENDING}" = "true" ]; then
+    HTTP_CODE=$(/usr/bin/curl \
+        -H "Authorization: Bearer $TOKEN" \
+        -o /tmp/pending \
+        -w "%{http_code}" \
+        -X GET "${BUILDER_BASE_URL}/${PENDING_SECRET_NAME}" 2>/dev/null)
+    [ "$HTTP_CODE" = "200" ] || exit 1
+    grep -e '"swiftbuilders.tar.gz": ".*"' /tmp/pending | cut -f 4 -d '"' | base64 -d > /tmp/pending-builders.tar.gz
+    grep -e '"swiftrings.tar.gz": ".*"' /tmp/pending | cut -f 4 -d '"' | base64 -d > /tmp/pending-rings.tar.gz
+    tar -xvzf /tmp/pending-builders.tar.gz -C /etc/swift/ || exit 1
+    tar -xvzf /tmp/pending-rings.tar.gz -C /etc/swift/ || exit 1
+elif [ -n "${PART_POWER_INCREASE}" ]; then
+    # Run a step of the partition power increase, <ring>:<step>:<part power>.
+    # The builder is not rebalanced, and a step that is already done, eg.
+    # when the Job is retried, is skipped
+    set -- $(echo ${PART_POWER_INCREASE} | tr ':' ' ')
+    RING=$1
+    STEP=$2
+    TARGET=$3
+    set -- $(python3 -c "from swift.common.ring import RingBuilder; b = RingBuilder.load('${RING}.builder'); print(b.part_power, b.next_part_power)")
+    case $STEP in
+        "prepare")
+            if [ "$2" != "${TARGET}" ]; then
+                swift-ring-builder ${RING}.builder prepare_increase_partition_power || exit 1
+            fi
+        ;;
+
+        "increase")
+            if [ "$1" != "${TARGET}" ]; then
+                swift-ring-builder ${RING}.builder increase_partition_power || exit 1
+            fi
+        ;;
+
+        "finish")
+            if [ "$2" != "None" ]; then
+                swift-ring-builder ${RING}.builder finish_increase_partition_power || exit 1
+            fi
+        ;;
+
+        *)
+            exit 1
+        ;;
+    esac
+    swift-ring-builder ${RING}.builder write_ring || exit 1
+else
+    . /usr/local/bin/container-scripts/swift-ring-update.sh
+fi
+
+# The published rings contain the checksums of the builders and storage
+# policies they were written from. They are only published again if one of
+# them changed, eg. by a rebalance or changed devices. A dry run only reports
+# the changes
+# With the Manual RingUpdatePolicy, rebalanced rings are kept in the pending
+# Secret until they are approved. Pending rings are outdated once the rings
+# are unchanged or new rings are pending
+if [ "${DRY_RUN}" = "true" ]; then
+    echo "Dry run, not publishing the rings"
+elif [ "${PUBLISH_PENDING}" != "true" ] && [ "$METHOD" = "PUT" ] && [ -e builders.md5 ] && [ "$(builders_md5)" = "$(cat builders.md5)" ]; then
+    echo "Rings unchanged, not publishing them"
+    [ "${RING_UPDATE_POLICY}" = "Manual" ] && delete_pending
+elif [ "${PUBLISH_PENDING}" != "true" ] && [ "$METHOD" = "PUT" ] && [ "${SCHEDULED_REBALANCE}" = "true" ] && ! balance_improved; then
+    # The stats report the balance of the published builders
+    echo "Balance not improved by the scheduled rebalance, not publishing the rings"
+    cp -t /etc/swift/ /tmp/before/*.builder
+elif [ "${PUBLISH_PENDING}" != "true" ] && [ "$METHOD" = "PUT" ] && [ "${RING_UPDATE_POLICY}" = "Manual" ]; then
+    echo "Rings changed, waiting for approval before publishing them"
+    builders_md5 > builders.md5
+    tar_rings
+    BUILDER_DATA=`tar cvz *.builder $(ls *.composite 2>/dev/null) | /usr/bin/base64 -w 0`
+    BINARY_DATA=`/usr/bin/base64 -w 0 $TARFILE`
+    PENDING_JSON='{
+        "apiVersion":"v1",
+        "kind":"Secret",
+        "metadata":{
+            "name":"'${PENDING_SECRET_NAME}'",
+            "namespace":"'${NAMESPACE}'",
+            "ownerReferences": [
+                {
+                    "apiVersion": "'${OWNER_APIVERSION}'",
+                    "kind": "'${OWNER_KIND}'",
+                    "name": "'${OWNER_NAME}'",
+                    "uid": "'${OWNER_UID}'"
+                }
+            ]
+        },
+        "data":{
+            "swiftbuilders.tar.gz": "'${BUILDER_DATA}'",
+            "swiftrings.tar.gz": "'${BINARY_DATA}'"
+        }
+    }'
+
+    # The JSON is posted from a file, it may exceed the argument size limit
+    printf '%s' "${PENDING_JSON}" > /tmp/pending.json
+    delete_pending
+    HTTP_CODE=$(/usr/bin/curl \
+        -H "Authorization: Bearer $TOKEN" \
+        --data-binary @/tmp/pending.json \
+        -H 'Content-Type: application/json' \
+        -o /dev/null \
+        -w "%{http_code}" \
+        -X POST "${BUILDER_BASE_URL}" 2>/dev/null)
+
+    case $HTTP_CODE in
+        "200"|"201")
+        ;;
+
+        *)
+            exit 1
+        ;;
+    esac
+else
+    builders_md5 > builders.md5
+
+    # Store the builders first, the published rings must never be ahead of
+    # them. The previous builders are kept as swiftbuilders.tar.gz.<n>
+    BUILDER_DATA=`tar cvz *.builder $(ls *.composite 2>/dev/null) | /usr/bin/base64 -w 0`
+    SECRET_DATA='"swiftbuilders.tar.gz": "'${BUILDER_DATA}'"'
+    PREVIOUS=$(builder_data swiftbuilders.tar.gz)
+    i=1
+    while [ $i -le ${BUILDER_HISTORY_LIMIT} ] && [ -n "$PREVIOUS" ]; do
+        NEXT=$(builder_data swiftbuilders.tar.gz.$i)
+        SECRET_DATA=${SECRET_DATA}', "swiftbuilders.tar.gz.'$i'": "'${PREVIOUS}'"'
+        PREVIOUS=$NEXT
+        i=$((i + 1))
+    done
+    SECRET_JSON='{
+        "apiVersion":"v1",
+        "kind":"Secret",
+        "metadata":{
+            "name":"'${BUILDER_SECRET_NAME}'",
+            "namespace":"'${NAMESPACE}'",
+            "ownerReferences": [
+                {
+                    "apiVersion": "'${OWNER_APIVERSION}'",
+                    "kind": "'${OWNER_KIND}'",
+                    "name": "'${OWNER_NAME}'",
+                    "uid": "'${OWNER_UID}'"
+                }
+            ]
+        },
+        "data":{
+            '${SECRET_DATA}'
+        }
+    }'
+
+    # The previous builders count against the size limit of the Secret as
+    # well, fail with an explicit message instead of a rejected request. The
+    # JSON is posted from a file, it may exceed the argument size limit
+    printf '%s' "${SECRET_JSON}" > /tmp/builders.json
+    BUILDER_SIZE=$(stat -c %s /tmp/builders.json)
+    if [ "$BUILDER_SIZE" -gt "${RING_SIZE_LIMIT}" ]; then
+        echo "Builders with ${BUILDER_SIZE} bytes exceed the Secret size limit of ${RING_SIZE_LIMIT} bytes, reduce builderHistoryLimit"
+        exit 1
+    fi
+    HTTP_CODE=$(/usr/bin/curl \
+        -H "Authorization: Bearer $TOKEN" \
+        --data-binary @/tmp/builders.json \
+        -H 'Content-Type: application/json' \
+        -o /dev/null \
+        -w "%{http_code}" \
+        -X "${BUILDER_METHOD}" "${BUILDER_URL}" 2>/dev/null)
+
+    case $HTTP_CODE in
+        "200"|"201")
+        ;;
+
+        *)
+            exit 1
+        ;;
+    esac
+
+    # Tar up the rings and either create or update the SwiftRing ConfigMap
+    # or Secret
+    tar_rings
+    BINARY_DATA=`/usr/bin/base64 -w 0 $TARFILE`
+    CONFIGMAP_JSON='{
+        "apiVersion":"v1",
+        "kind":"'${RING_KIND}'",
+        "metadata":{
+            "name":"'${RING_NAME}'",
+            "namespace":"'${NAMESPACE}'",
+            "ownerReferences": [
+                {
+                    "apiVersion": "'${OWNER_APIVERSION}'",
+                    "kind": "'${OWNER_KIND}'",
+                    "name": "'${OWNER_NAME}'",
+                    "uid": "'${OWNER_UID}'"
+                }
+            ]
+        },
+        "'${RING_DATA_FIELD}'":{
+            "swiftrings.tar.gz": "'${BINARY_DATA}'"
+        }
+    }'
+
+    # https://kubernetes.io/docs/reference/kubernetes-api/config-and-storage-resources/config-map-v1/#update-replace-the-specified-configmap
+    # https://kubernetes.io/docs/reference/kubernetes-api/config-and-storage-resources/secret-v1/#update-replace-the-specified-secret
+    # Fail if the rings were not stored, the Job will be retried
+    printf '%s' "${CONFIGMAP_JSON}" > /tmp/rings.json
+    HTTP_CODE=$(/usr/bin/curl \
+        -H "Authorization: Bearer $TOKEN" \
+        --data-binary @/tmp/rings.json \
+        -H 'Content-Type: application/json' \
+        -o /dev/null \
+        -w "%{http_code}" \
+        -X "${METHOD}" "${URL}" 2>/dev/null)
+
+    case $HTTP_CODE in
+        "200"|"201")
+        ;;
+
+        *)
+            exit 1
+        ;;
+    esac
+
+    # The approved rings are published
+    [ "${PUBLISH_PENDING}" = "true" ] && delete_pending
+fi
+
+# Report the ring quality back to the operator in the stats ConfigMap, the
+# termination message is too small for the stats of many rings
+python3 - <<'EOF' > /tmp/stats.json
+import glob
+import json
+import os
+import time
+
+from swift.common.ring import RingBuilder
+
+
+def weights(builder):
+    """Returns the weight per device of the builder"""
+    return {(d["ip"], d["port"], d["device"]): d["weight"]
+            for d in builder.devs if d is not None}
+
+
+# A composite object ring reports the stats of its components
+stats = {}
+for ring in sorted(f[:-len(".builder")] for f in glob.glob("*.builder")):
+    builder = RingBuilder.load(ring + ".builder")
+    after = weights(builder)
+    before = {}
+    balance_before = ""
+    if os.path.exists("/tmp/before/%s.builder" % ring):
+        previous = RingBuilder.load("/tmp/before/%s.builder" % ring)
+        before = weights(previous)
+        balance_before = "%.2f" % previous.get_balance()
+    reassigned = 0
+    if os.path.exists("/tmp/%s.reassigned" % ring):
+        with open("/tmp/%s.reassigned" % ring) as f:
+            reassigned = int(f.read().strip() or 0)
+    stats[ring] = {
+        "balance": "%.2f" % builder.get_balance(),
+        "dispersion": "%.2f" % builder.dispersion,
+        "overload": "%.2f" % (builder.overload * 100),
+        "requiredOverload": "%.2f" % (builder.get_required_overload() * 100),
+        "partitions": builder.parts,
+        "partPower": builder.part_power,
+        "minPartHours": builder.min_part_hours,
+        "partitionsMovableTime": time.strftime("%Y-%m-%dT%H:%M:%SZ", time.gmtime(time.time() + builder.min_part_seconds_left)),
+        "replicas": "%g" % builder.replicas,
+        "partitionsReassigned": reassigned,
+        "devices": len(after),
+        "devicesAdded": len(set(after) - set(before)),
+        "devicesRemoved": len(set(before) - set(after)),
+        "weightsChanged": len([d for d in after if d in before and after[d] != before[d]]),
+        "balanceBefore": balance_before,
     }
-}'
+print(json.dumps({
+    "apiVersion": "v1",
+    "kind": "ConfigMap",
+    "metadata": {
+        "name": os.environ["STATS_CM_NAME"],
+        "namespace": os.environ["NAMESPACE"],
+        "ownerReferences": [{
+            "apiVersion": os.environ["OWNER_APIVERSION"],
+            "kind": os.environ["OWNER_KIND"],
+            "name": os.environ["OWNER_NAME"],
+            "uid": os.environ["OWNER_UID"],
+        }],
+    },
+    "data": {
+        os.environ["STATS_KEY"]: json.dumps(stats, separators=(",", ":")),
+    },
+}))
+EOF
+[ -s /tmp/stats.json ] || exit 1
 
-# https://kubernetes.io/docs/reference/kubernetes-api/config-and-storage-resources/config-map-v1/#update-replace-the-specified-configmap
-/usr/bin/curl \
+# Fail if the stats were not stored, the Job will be retried. Unchanged rings
+# are not published again
+HTTP_CODE=$(/usr/bin/curl \
     -H "Authorization: Bearer $TOKEN" \
-    --data-binary "${CONFIGMAP_JSON}" \
+    --data-binary @/tmp/stats.json \
     -H 'Content-Type: application/json' \
-    -X "${METHOD}" "${URL}"
+    -o /dev/null \
+    -w "%{http_code}" \
+    -X PUT "${CM_BASE_URL}/${STATS_CM_NAME}" 2>/dev/null)
+
+if [ "$HTTP_CODE" = "404" ]; then
+    HTTP_CODE=$(/usr/bin/curl \
+        -H "Authorization: Bearer $TOKEN" \
+        --data-binary @/tmp/stats.json \
+        -H 'Content-Type: application/json' \
+        -o /dev/null \
+        -w "%{http_code}" \
+        -X POST "${CM_BASE_URL}" 2>/dev/null)
+fi
+
+case $HTTP_CODE in
+    "200"|"201")
+    ;;
+
+    *)
+        echo "Storing the ring stats failed with HTTP code ${HTTP_CODE}"
+        exit 1
+    ;;
+esac
@@ -0,0 +1,316 @@
+#!/bin/sh
+# Updates the builders in /etc/swift with the ring parameters and the device
+# list and rebalances them. Sourced by swift-ring-rebalance.sh, unless it
+# publishes approved pending rings
+
+# Create a builder if not existing, otherwise apply the changed replica count
+# and min_part_hours. The overload is set on new and existing builders:
+# <builder> <part power> <min part hours> <replicas> <overload percent>
+ensure_builder() {
+    f=$1
+    if [ ! -e $f ]; then
+        swift-ring-builder $f create $2 $4 $3 || exit 1
+    else
+        set -- $1 $2 $3 $4 $5 $(python3 -c "from swift.common.ring import RingBuilder; b = RingBuilder.load('$f'); print(b.min_part_hours, b.replicas)")
+        if [ "$3" != "$6" ]; then
+            swift-ring-builder $f set_min_part_hours $3 || exit 1
+        fi
+        if python3 -c "import sys; sys.exit(float('$4') == float('$7'))"; then
+            swift-ring-builder $f set_replicas $4 || exit 1
+        fi
+    fi
+    if python3 -c "import sys; from swift.common.ring import RingBuilder; sys.exit(round(RingBuilder.load('$f').overload * 100) == int('${5:-0}'))"; then
+        swift-ring-builder $f set_overload ${5:-0}% || exit 1
+    fi
+}
+
+# Changes the IP of the device of a storage pod in the builder if the pod got
+# a new IP, eg. on a NetworkAttachment after it was recreated. The devices
+# are identified by the pod name in their meta field, thus the device keeps
+# its partitions instead of being added again. Devices added before the pod
+# name was stored get it if their IP matches:
+# <builder> <pod> <device> <host>
+update_device_host() {
+    python3 - "$@" <<'EOF_HOST'
+import sys
+
+from swift.common.ring import RingBuilder
+
+path, pod, device, host = sys.argv[1:]
+ip = host.strip("[]")
+builder = RingBuilder.load(path)
+devs = [d for d in builder.devs if d is not None and d["device"] == device]
+owned = [d for d in devs if d.get("meta") == pod]
+changed = False
+for dev in owned:
+    if dev["ip"] != ip:
+        print("Changing the IP of %s/%s of %s in %s to %s" % (dev["ip"], device, pod, path, ip))
+        if dev["replication_ip"] == dev["ip"]:
+            dev["replication_ip"] = ip
+        dev["ip"] = ip
+        changed = True
+if not owned:
+    for dev in devs:
+        if dev["ip"] == ip and not dev.get("meta"):
+            dev["meta"] = pod
+            changed = True
+if changed:
+    builder.save(path)
+EOF_HOST
+}
+
+# Returns the builder of the object ring for a device in the given region.
+# Devices in a region without a component are not part of a composite ring
+object_builder() {
+    if [ -z "${COMPOSITE_COMPONENTS}" ]; then
+        echo object.builder
+        return
+    fi
+    for COMPONENT in ${COMPOSITE_COMPONENTS}; do
+        for R in $(echo $COMPONENT | cut -f3 -d: | tr ',' ' '); do
+            if [ "$R" = "$1" ]; then
+                echo object.$(echo $COMPONENT | cut -f1 -d:).builder
+                return
+            fi
+        done
+    done
+}
+
+# Returns success if the device labels match the device selector of the
+# ring: <ring> <labels>. DEVICE_SELECTORS contains <ring>:<key>=<value>,...
+# per ring with a selector, the labels are <key>=<value>;... Rings without a
+# selector use all devices
+device_selected() {
+    for SELECTOR in ${DEVICE_SELECTORS}; do
+        [ "${SELECTOR%%:*}" = "$1" ] || continue
+        for LABEL in $(echo ${SELECTOR#*:} | tr ',' ' '); do
+            case ";$2;" in
+                *";$LABEL;"*)
+                ;;
+
+                *)
+                    return 1
+                ;;
+            esac
+        done
+    done
+    return 0
+}
+
+# A composite object ring can't replace an existing object ring and vice
+# versa, all partitions would be moved
+if [ -n "${COMPOSITE_COMPONENTS}" ] && [ -e object.builder ]; then
+    echo "The object ring exists and can't be replaced by a composite ring"
+    exit 1
+fi
+if [ -z "${COMPOSITE_COMPONENTS}" ] && [ -e object.composite ]; then
+    echo "The composite object ring can't be replaced by a single builder"
+    exit 1
+fi
+
+# Imported builders are used as they are and must match the ring
+# parameters, otherwise the first rebalance would move partitions. Imported
+# rings must be written from the imported builders
+if [ -n "$IMPORTED" ]; then
+    python3 - <<'EOF_IMPORT' || exit 1
+import os
+import sys
+
+from swift.common.ring import RingBuilder, RingData
+
+builders = {}
+for parameters in os.environ["RING_PARAMETERS"].split():
+    ring, part_power, _, replicas = parameters.split(":")[:4]
+    if ring == "object" and os.environ.get("COMPOSITE_COMPONENTS"):
+        for component in os.environ["COMPOSITE_COMPONENTS"].split():
+            name, component_replicas, _ = component.split(":")
+            builders["object.%s" % name] = (part_power, component_replicas)
+        continue
+    builders[ring] = (part_power, replicas)
+
+errors = []
+for ring, (part_power, replicas) in sorted(builders.items()):
+    if not os.path.exists(ring + ".builder"):
+        errors.append("%s.builder is missing" % ring)
+        continue
+    builder = RingBuilder.load(ring + ".builder")
+    if builder.part_power != int(part_power):
+        errors.append("%s.builder has part power %d instead of %s" % (ring, builder.part_power, part_power))
+    if builder.replicas != float(replicas):
+        errors.append("%s.builder has %g replicas instead of %s" % (ring, builder.replicas, replicas))
+    if not os.path.exists(ring + ".ring.gz"):
+        continue
+    try:
+        expected = builder.get_ring()
+    except Exception as e:
+        errors.append("%s.builder can't be used: %s" % (ring, e))
+        continue
+    current = RingData.load(ring + ".ring.gz")
+    if [list(r) for r in current._replica2part2dev_id] != [list(r) for r in expected._replica2part2dev_id]:
+        errors.append("%s.ring.gz was not written from %s.builder" % (ring, ring))
+
+for error in errors:
+    print("Ring import failed: %s" % error)
+sys.exit(1 if errors else 0)
+EOF_IMPORT
+fi
+
+# Create new rings if not existing, otherwise apply the changed replica count,
+# min_part_hours and overload. RING_PARAMETERS contains
+# <ring>:<part power>:<min part hours>:<replicas>:<overload> per ring, the
+# part power of existing rings is never changed. COMPOSITE_COMPONENTS
+# contains <name>:<replicas>:<regions> per component builder of a composite
+# object ring, using the part power, min_part_hours and overload of the
+# object ring
+for PARAMETERS in ${RING_PARAMETERS}; do
+    set -- $(echo $PARAMETERS | tr ':' ' ')
+    if [ "$1" = "object" ] && [ -n "${COMPOSITE_COMPONENTS}" ]; then
+        for COMPONENT in ${COMPOSITE_COMPONENTS}; do
+            ensure_builder object.$(echo $COMPONENT | cut -f1 -d:).builder $2 $3 $(echo $COMPONENT | cut -f2 -d:) $5
+        done
+        continue
+    fi
+    ensure_builder $1.builder $2 $3 $4 $5
+done
+
+# The storage policies are published with the rings and appended to
+# swift.conf by the pods
+if [ -n "${STORAGE_POLICIES_CONF}" ]; then
+    printf '%s\n' "${STORAGE_POLICIES_CONF}" > storage-policies.conf
+else
+    rm -f storage-policies.conf
+fi
+
+# Iterate over all devices from the lists created by the SwiftStorage CRs.
+# This does not check for existing ones, which is OK for smaller rings but will
+# be replaced in the improved version. It's basically a dumb brute-force
+# approach to add devices and set their weights
+for DEV in $(cat /var/lib/config-data/ring-devices/*-devices.csv 2>/dev/null); do
+    REGION=$(echo $DEV | cut -f1 -d,)
+    ZONE=$(echo $DEV | cut -f2 -d,)
+    HOST=$(echo $DEV | cut -f3 -d,)
+    DEVICE_NAME=$(echo $DEV | cut -f4 -d,)
+    WEIGHT=$(echo $DEV | cut -f5 -d,)
+    ACCOUNT_PORT=$(echo $DEV | cut -f6 -d,)
+    CONTAINER_PORT=$(echo $DEV | cut -f7 -d,)
+    OBJECT_PORT=$(echo $DEV | cut -f8 -d,)
+    ACCOUNT_REPLICATION_PORT=$(echo $DEV | cut -f10 -d,)
+    CONTAINER_REPLICATION_PORT=$(echo $DEV | cut -f11 -d,)
+    OBJECT_REPLICATION_PORT=$(echo $DEV | cut -f12 -d,)
+    ACCOUNT_REPLICATION_PORT=${ACCOUNT_REPLICATION_PORT:-${ACCOUNT_PORT:-6202}}
+    CONTAINER_REPLICATION_PORT=${CONTAINER_REPLICATION_PORT:-${CONTAINER_PORT:-6201}}
+    OBJECT_REPLICATION_PORT=${OBJECT_REPLICATION_PORT:-${OBJECT_PORT:-6200}}
+    LABELS=$(echo $DEV | cut -f13 -d,)
+    POD=$(echo $DEV | cut -f14 -d,)
+
+    # <builder>:<port>:<replication port> of all rings selecting the device
+    DEVICE_BUILDERS=""
+    for PARAMETERS in ${RING_PARAMETERS}; do
+        RING=${PARAMETERS%%:*}
+        device_selected $RING "$LABELS" || continue
+        case $RING in
+            "account")
+                DEVICE_BUILDERS="${DEVICE_BUILDERS} account.builder:${ACCOUNT_PORT:-6202}:${ACCOUNT_REPLICATION_PORT}"
+            ;;
+
+            "container")
+                DEVICE_BUILDERS="${DEVICE_BUILDERS} container.builder:${CONTAINER_PORT:-6201}:${CONTAINER_REPLICATION_PORT}"
+            ;;
+
+            "object")
+                OBJECT_BUILDER=$(object_builder $REGION)
+                if [ -z "$OBJECT_BUILDER" ]; then
+                    echo "No composite ring component for region $REGION, device $HOST/$DEVICE_NAME not added to the object ring"
+                    continue
+                fi
+                DEVICE_BUILDERS="${DEVICE_BUILDERS} ${OBJECT_BUILDER}:${OBJECT_PORT:-6200}:${OBJECT_REPLICATION_PORT}"
+            ;;
+
+            *)
+                DEVICE_BUILDERS="${DEVICE_BUILDERS} $RING.builder:${OBJECT_PORT:-6200}:${OBJECT_REPLICATION_PORT}"
+            ;;
+        esac
+    done
+
+    for DEVICE_BUILDER in ${DEVICE_BUILDERS}; do
+        BUILDER=$(echo $DEVICE_BUILDER | cut -f1 -d:)
+        PORT=$(echo $DEVICE_BUILDER | cut -f2 -d:)
+        REPLICATION_PORT=$(echo $DEVICE_BUILDER | cut -f3 -d:)
+
+        if [ -n "$POD" ]; then
+            update_device_host $BUILDER $POD $DEVICE_NAME $HOST || exit 1
+        fi
+        swift-ring-builder $BUILDER add --region $REGION --zone $ZONE --ip $HOST --port $PORT --device $DEVICE_NAME --weight $WEIGHT ${POD:+--meta $POD}
+
+        # The replication port changes if dedicated replication servers are
+        # enabled or disabled
+        swift-ring-builder $BUILDER set_info --region $REGION --zone $ZONE --ip $HOST --port $PORT --device $DEVICE_NAME --change-replication-port $REPLICATION_PORT
+
+        # This will change the weights, eg. after bootstrapping and correct PVC
+        # sizes are known.
+        swift-ring-builder $BUILDER set_weight --region $REGION --zone $ZONE --ip $HOST --port $PORT --device $DEVICE_NAME $WEIGHT
+    done
+done
+
+# Devices of storage pods with a lost volume are set to a weight of 0 or
+# removed from all builders. The list contains <host>,<device>,<action> per
+# device. The device of a ring selecting other devices is not in its builder.
+# A device with a weight of 0 stays in the builders, thus the Job fails if it
+# is in none of them, eg. because of a wrong host. A removed device is only in
+# none of them once it was removed by a previous rebalance
+for DEV in $(cat /var/lib/config-data/ring-devices/*-failed.csv 2>/dev/null); do
+    HOST=$(echo $DEV | cut -f1 -d,)
+    DEVICE_NAME=$(echo $DEV | cut -f2 -d,)
+    ACTION=$(echo $DEV | cut -f3 -d,)
+    FOUND=""
+    for f in *.builder; do
+        swift-ring-builder $f search --ip $HOST --device $DEVICE_NAME > /dev/null 2>&1 || continue
+        FOUND=1
+        case $ACTION in
+            "ZeroWeight")
+                swift-ring-builder $f set_weight --ip $HOST --device $DEVICE_NAME 0 --yes || exit 1
+            ;;
+
+            "Remove")
+                swift-ring-builder $f remove --ip $HOST --device $DEVICE_NAME --yes || exit 1
+            ;;
+        esac
+    done
+    if [ -z "$FOUND" ] && [ "$ACTION" = "ZeroWeight" ]; then
+        echo "The failed device $DEVICE_NAME of $HOST is in none of the builders"
+        exit 1
+    fi
+done
+
+# TODO: needs a check if it is safe to rebalance individual rings
+# swift-ring-builder returns 1 if there was nothing to rebalance, anything
+# above is an error and the rings must not be published
+for f in *.builder; do
+    # A ring without devices can't be written, eg. if its device selector
+    # does not match any device yet
+    if swift-ring-builder $f | grep -q " 0 devices,"; then
+        echo "No devices in $f, check the device selector of the ring"
+        exit 1
+    fi
+    OUTPUT=$(swift-ring-builder $f rebalance)
+    RC=$?
+    echo "$OUTPUT"
+    [ $RC -gt 1 ] && exit 1
+    # Nothing was rebalanced, but the device info might have changed
+    if [ $RC -eq 1 ]; then
+        swift-ring-builder $f write_ring || exit 1
+    fi
+    # Number of moved partitions, reported in the ring stats
+    echo "$OUTPUT" | sed -n 's/^Reassigned \([0-9]*\) .*/\1/p' > /tmp/${f%.builder}.reassigned
+done
+
+# Compose the object ring from the rebalanced components. The component rings
+# are not published
+if [ -n "${COMPOSITE_COMPONENTS}" ]; then
+    COMPONENT_BUILDERS=""
+    for COMPONENT in ${COMPOSITE_COMPONENTS}; do
+        COMPONENT_BUILDERS="${COMPONENT_BUILDERS} object.$(echo $COMPONENT | cut -f1 -d:).builder"
+    done
+    swift-ring-composer object.composite compose ${COMPONENT_BUILDERS} --output object.ring.gz --force || exit 1
+    rm -f object.*.ring.gz
+fi
@@ -0,0 +1,134 @@
+#!/bin/sh
+# Verifies the rings restored from a snapshot against the data stored on the
+# devices. For every device in the rings the partitions are listed using the
+# rsync daemon of the storage pod, and for a sample of these partitions the
+# hashes are checked to belong to the partition. The result is reported to the
+# operator using the termination message.
+
+tar -xzf /var/lib/config-data/rings/swiftrings.tar.gz -C /etc/swift/ || exit 1
+cp -t /etc/swift/ /var/lib/config-data/swiftconf/*
+
+python3 - <<'EOF_PYTHON' > /dev/termination-log
+import binascii
+import glob
+import json
+import os
+import struct
+import subprocess
+
+from swift.common.ring import Ring
+
+DATADIRS = {"account": "accounts", "container": "containers", "object": "objects"}
+# The rings of the storage policies use the object rsync module
+for path in glob.glob("/etc/swift/object-*.ring.gz"):
+    ring_name = os.path.basename(path)[:-len(".ring.gz")]
+    DATADIRS[ring_name] = "objects-%s" % ring_name[len("object-"):]
+# Number of partitions per device whose hashes are checked
+SAMPLE_PARTITIONS = int(os.environ.get("SAMPLE_PARTITIONS", "16"))
+# Keep the termination message below its size limit
+MAX_REPORTED = 10
+MAX_LENGTH = 150
+
+# The rsync port is the 9th column of the device lists
+rsync_ports = {}
+for path in glob.glob("/var/lib/config-data/ring-devices/*-devices.csv"):
+    with open(path) as f:
+        for line in f:
+            fields = line.strip().split(",")
+            if len(fields) >= 9:
+                rsync_ports[fields[2].strip("[]")] = fields[8]
+
+
+def listing(host, module, path, recursive=False):
+    """Returns the directories below path, None if path does not exist"""
+    url = "rsync://%s:%s/%s/%s/" % (
+        "[%s]" % host if ":" in host else host,
+        rsync_ports.get(host, "873"), module, path)
+    cmd = ["rsync", "--list-only", "--no-motd"]
+    if recursive:
+        # Only suffix and hash directories
+        cmd += ["-r", "--exclude=/*/*/*"]
+    result = subprocess.run(cmd + [url], capture_output=True, text=True,
+                            timeout=300)
+    if result.returncode != 0:
+        if "No such file or directory" in result.stderr:
+            return None
+        lines = result.stderr.strip().splitlines() or ["rsync exit code %d" % result.returncode]
+        raise Exception(lines[0])
+    names = []
+    for line in result.stdout.splitlines():
+        parts = line.split(None, 4)
+        if len(parts) == 5 and line.startswith("d") and parts[4] != ".":
+            names.append(parts[4])
+    return names
+
+
+mismatches = []
+handoffs = []
+devices = 0
+for ring_name, datadir in sorted(DATADIRS.items()):
+    ring = Ring("/etc/swift", ring_name=ring_name)
+    module = ring_name.split("-")[0]
+    assigned = {}
+    for part2dev_id in ring._replica2part2dev_id:
+        for part, dev_id in enumerate(part2dev_id):
+            assigned.setdefault(dev_id, set()).add(part)
+
+    for dev in ring.devs:
+        if dev is None:
+            continue
+        devices += 1
+        name = "%s ring device %s/%s" % (ring_name, dev["ip"], dev["device"])
+        try:
+            partitions = listing(dev["ip"], module, "%s/%s" % (dev["device"], datadir))
+        except Exception as e:
+            mismatches.append("%s: not reachable: %s" % (name, e))
+            continue
+        if partitions is None:
+            continue
+
+        checked = 0
+        for partition in sorted(p for p in partitions if p.isdigit()):
+            part = int(partition)
+            if part >= ring.partition_count:
+                mismatches.append("%s: partition %d outside the ring with %d partitions" % (
+                    name, part, ring.partition_count))
+                continue
+            # Partitions written to handoff devices, eg. during a failure,
+            # are moved by the replicators
+            if part not in assigned.get(dev["id"], set()):
+                handoffs.append("%s: partition %d not assigned to the device" % (name, part))
+                continue
+            if checked >= SAMPLE_PARTITIONS:
+                continue
+            checked += 1
+            try:
+                entries = listing(dev["ip"], module, "%s/%s/%d" % (
+                    dev["device"], datadir, part), recursive=True) or []
+            except Exception as e:
+                mismatches.append("%s: partition %d not listed: %s" % (name, part, e))
+                continue
+            for entry in entries:
+                if "/" not in entry:
+                    continue
+                suffix, hsh = entry.split("/", 1)
+                try:
+                    hash_part = struct.unpack_from(">I", binascii.unhexlify(hsh))[0] >> ring._part_shift
+                except (binascii.Error, struct.error, ValueError):
+                    mismatches.append("%s: invalid hash %s in partition %d" % (name, hsh, part))
+                    continue
+                if not hsh.endswith(suffix):
+                    mismatches.append("%s: hash %s in suffix %s of partition %d" % (name, hsh, suffix, part))
+                elif hash_part != part:
+                    mismatches.append("%s: hash %s of partition %d stored in partition %d" % (
+                        name, hsh, hash_part, part))
+
+print(json.dumps({
+    "verified": not mismatches,
+    "devices": devices,
+    "mismatches": [m[:MAX_LENGTH] for m in mismatches[:MAX_REPORTED]],
+    "totalMismatches": len(mismatches),
+    "handoffs": [h[:MAX_LENGTH] for h in handoffs[:MAX_REPORTED]],
+    "totalHandoffs": len(handoffs),
+}))
+EOF_PYTHON
@@ -0,0 +1,25 @@
+#!/bin/sh
+# preStop hook of the storage containers. Kubernetes sends SIGTERM once the
+# hook returns, and SIGKILL at the end of the termination grace period.
+#
+# The account, container and object servers are stopped using SIGHUP: they
+# close the listening sockets and finish the requests in flight before they
+# exit. The replicators are given time to finish the rsync transfers of the
+# current partition, otherwise these are started from scratch by another
+# replicator after the pod is gone.
+case "$1" in
+    server)
+        kill -HUP 1
+        # The container terminates once the server exited
+        while kill -0 1 2>/dev/null; do
+            sleep 1
+        done
+    ;;
+
+    replicator)
+        # The bracket keeps grep from matching its own command line
+        while grep -qs '[r]sync' /proc/[0-9]*/cmdline; do
+            sleep 1
+        done
+    ;;
+esac
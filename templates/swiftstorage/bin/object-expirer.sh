@@ -0,0 +1,17 @@
+#!/bin/sh
+# Run the object expirer on the part of the expiration queue given by the
+# ordinal of the pod. The queue is split between PROCESSES expirers, pods
+# with higher ordinals don't run an expirer.
+PROCESS=$(( ${HOSTNAME##*-} - ORDINAL_START ))
+if [ $PROCESS -ge $PROCESSES ]; then
+    echo "Expiration queue is split between ${PROCESSES} expirers, nothing to do for process ${PROCESS}"
+    exec sleep infinity
+fi
+
+# The configuration is copied to /etc/swift by the ring-sync container
+until [ -e /etc/swift/object-expirer.conf ]; do
+    sleep 1
+done
+
+sed -e "s/^\[object-expirer\]$/[object-expirer]\nprocess = ${PROCESS}/" /etc/swift/object-expirer.conf > /tmp/object-expirer.conf
+exec /usr/bin/swift-object-expirer /tmp/object-expirer.conf -v
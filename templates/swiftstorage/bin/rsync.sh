@@ -0,0 +1,30 @@
+#!/bin/sh
+# Bind rsync to the IP of the storage pod on the given NetworkAttachment. The
+# network status is set by Multus and is read from the Downward API volume,
+# wait until it is available.
+ANNOTATIONS="/etc/podinfo/annotations"
+
+get_address() {
+    python3 - "$ANNOTATIONS" "$NETWORK_ATTACHMENT" <<'PYEOF'
+import json
+import sys
+
+with open(sys.argv[1]) as f:
+    for line in f:
+        key, _, value = line.strip().partition("=")
+        if key != "k8s.v1.cni.cncf.io/network-status":
+            continue
+        for network in json.loads(json.loads(value)):
+            if network.get("name") == sys.argv[2] and network.get("ips"):
+                print(network["ips"][0])
+                sys.exit(0)
+sys.exit(1)
+PYEOF
+}
+
+until ADDRESS=$(get_address); do
+    echo "Waiting for the IP on ${NETWORK_ATTACHMENT}"
+    sleep 5
+done
+
+exec /usr/bin/rsync --daemon --no-detach --config=/etc/swift/rsyncd.conf --log-file=/dev/stdout --address="${ADDRESS}"